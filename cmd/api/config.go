@@ -3,27 +3,51 @@ package main
 import (
 	"log"
 	"reflect"
+	"time"
 
 	"github.com/caarlos0/env/v6"
 )
 
 type Config struct {
 	API struct {
-		Port        int `env:"PORT" envDefault:"7077"`
-		MetricsPort int `env:"METRICS_PORT" envDefault:"9010"`
+		Port            int           `env:"PORT" envDefault:"7077"`
+		MetricsPort     int           `env:"METRICS_PORT" envDefault:"9010"`
+		SessionSecret   string        `env:"SESSION_SECRET"`
+		SessionLifetime time.Duration `env:"SESSION_TOKEN_LIFETIME" envDefault:"15m"`
+		RefreshLifetime time.Duration `env:"SESSION_REFRESH_TOKEN_LIFETIME" envDefault:"168h"`
 	}
 	App struct {
-		LogLevel    string `env:"LOG_LEVEL" envDefault:"INFO"`
-		PostgresURI string `env:"POSTGRES_URI,required"`
+		LogLevel        string        `env:"LOG_LEVEL" envDefault:"INFO"`
+		PostgresURI     string        `env:"POSTGRES_URI,required"`
+		DigestWindow    time.Duration `env:"NOTIFICATION_DIGEST_WINDOW" envDefault:"3s"`
+		DryRun          bool          `env:"NOTIFICATION_DRY_RUN" envDefault:"false"`
+		Dedup           string        `env:"NOTIFICATION_DEDUP" envDefault:"memory"`
+		OutboxRetention time.Duration `env:"OUTBOX_RETENTION" envDefault:"168h"`
+		ShutdownTimeout time.Duration `env:"SHUTDOWN_TIMEOUT" envDefault:"20s"`
 	}
 	TonAPI struct {
-		ApiKey string `env:"TONAPI_KEY,required"`
+		ApiKey             string `env:"TONAPI_KEY,required"`
+		TraceWorkers       int    `env:"TRACE_WORKERS" envDefault:"16"`
+		TraceQueueSize     int    `env:"TRACE_QUEUE_SIZE" envDefault:"1000"`
+		TraceQueueOverflow string `env:"TRACE_QUEUE_OVERFLOW" envDefault:"block"`
+		ReplicaID          string `env:"REPLICA_ID"`
 	}
 	TonConnect struct {
 		Secret string `env:"TON_CONNECT_SECRET,required"`
 	}
 	Telegram struct {
-		BotSecretKey string `env:"TELEGRAM_BOT_SECRET_KEY,required"`
+		BotSecretKey       string        `env:"TELEGRAM_BOT_SECRET_KEY,required"`
+		GlobalRateLimit    float64       `env:"TELEGRAM_GLOBAL_RATE_LIMIT" envDefault:"30"`
+		PerChatRateLimit   float64       `env:"TELEGRAM_PER_CHAT_RATE_LIMIT" envDefault:"1"`
+		WebAppURL          string        `env:"TWA_URL" envDefault:"https://wallet.tonkeeper.com"`
+		WebhookURL         string        `env:"TELEGRAM_WEBHOOK_URL"`
+		WebhookSecret      string        `env:"TELEGRAM_WEBHOOK_SECRET"`
+		Polling            bool          `env:"TELEGRAM_POLLING" envDefault:"true"`
+		BotID              int64         `env:"TELEGRAM_BOT_ID"`
+		InitDataValidation string        `env:"TWA_INIT_DATA_VALIDATION" envDefault:"hash"`
+		InitDataLifetime   time.Duration `env:"TWA_INIT_DATA_LIFETIME" envDefault:"1h"`
+		ReplayGuard        string        `env:"TWA_INIT_DATA_REPLAY_GUARD" envDefault:"memory"`
+		TestEnvironment    bool          `env:"TELEGRAM_TEST_ENVIRONMENT" envDefault:"false"`
 	}
 }
 
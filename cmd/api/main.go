@@ -4,6 +4,9 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.uber.org/zap"
@@ -16,6 +19,9 @@ import (
 	"github.com/tonkeeper/tonkeeper-twa-api/pkg/telegram"
 )
 
+// expiryInterval is how often rows with an expired ttl are deleted from storage.
+const expiryInterval = time.Minute
+
 func createLogger(level string) (*zap.Logger, error) {
 	cfg := zap.NewProductionConfig()
 	if level != "" {
@@ -28,8 +34,21 @@ func createLogger(level string) (*zap.Logger, error) {
 	return cfg.Build()
 }
 
+// waitDone waits until done is closed or ctx is done.
+func waitDone(ctx context.Context, done <-chan struct{}) error {
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 func main() {
 	cfg := Load()
+	// ctx is canceled by SIGINT or SIGTERM to start a graceful shutdown.
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
 	logger, err := createLogger(cfg.App.LogLevel)
 	if err != nil {
 		logger.Fatal("createLogger() failed", zap.Error(err))
@@ -38,35 +57,120 @@ func main() {
 		logger.Fatal("migrateDb() failed", zap.Error(err))
 	}
 	config := api.Config{
-		TonConnectSecret:  cfg.TonConnect.Secret,
-		TelegramBotSecret: cfg.Telegram.BotSecretKey,
+		TonConnectSecret:        cfg.TonConnect.Secret,
+		TelegramBotSecret:       cfg.Telegram.BotSecretKey,
+		TelegramBotID:           cfg.Telegram.BotID,
+		TelegramTestEnvironment: cfg.Telegram.TestEnvironment,
+		InitDataValidation:      telegram.InitDataValidation(cfg.Telegram.InitDataValidation),
+		InitDataLifetime:        cfg.Telegram.InitDataLifetime,
+		SessionSecret:           cfg.API.SessionSecret,
+		SessionLifetime:         cfg.API.SessionLifetime,
+		RefreshLifetime:         cfg.API.RefreshLifetime,
 	}
 	s, err := storage.New(logger, cfg.App.PostgresURI)
 	if err != nil {
 		logger.Fatal("storage.New() failed", zap.Error(err))
 	}
-	notificator, err := core.NewNotificator(logger, s, cfg.TonAPI.ApiKey)
+	go s.RunExpiry(ctx, expiryInterval)
+	switch cfg.Telegram.ReplayGuard {
+	case "memory":
+	case "postgres":
+		config.ReplayGuard = s
+	default:
+		logger.Fatal("unknown TWA_INIT_DATA_REPLAY_GUARD", zap.String("value", cfg.Telegram.ReplayGuard))
+	}
+	overflow := core.TraceOverflow(cfg.TonAPI.TraceQueueOverflow)
+	if overflow != core.TraceOverflowBlock && overflow != core.TraceOverflowDrop {
+		logger.Fatal("unknown TRACE_QUEUE_OVERFLOW", zap.String("value", cfg.TonAPI.TraceQueueOverflow))
+	}
+	notificatorOptions := []core.Option{
+		core.WithWebAppURL(cfg.Telegram.WebAppURL),
+		core.WithTraceWorkers(cfg.TonAPI.TraceWorkers, cfg.TonAPI.TraceQueueSize, overflow),
+		core.WithReplicaID(cfg.TonAPI.ReplicaID),
+	}
+	switch cfg.App.Dedup {
+	case "memory":
+	case "postgres":
+		notificatorOptions = append(notificatorOptions, core.WithDeduplicator(s))
+	default:
+		logger.Fatal("unknown NOTIFICATION_DEDUP", zap.String("value", cfg.App.Dedup))
+	}
+	notificator, err := core.NewNotificator(logger, s, cfg.TonAPI.ApiKey, notificatorOptions...)
 	if err != nil {
 		logger.Fatal("core.NewNotificator() failed", zap.Error(err))
 	}
-	bot, err := telegram.NewBot(logger, cfg.Telegram.BotSecretKey)
+	bot, err := telegram.NewBot(logger, cfg.Telegram.BotSecretKey,
+		telegram.WithRateLimits(cfg.Telegram.GlobalRateLimit, cfg.Telegram.PerChatRateLimit))
 	if err != nil {
 		logger.Fatal("telegram.NewBot() failed", zap.Error(err))
 	}
-	messageCh := bot.Run(context.TODO())
+	var delivery core.Notifier = core.NewTelegramNotifier(bot)
+	if cfg.App.DryRun {
+		logger.Warn("dry run: notifications are logged instead of being sent")
+		delivery = core.NewLogNotifier(logger)
+	}
+	outbox := core.NewOutbox(logger, s, delivery)
+	go outbox.Dispatch(context.Background())
+	go outbox.Sweep(ctx, cfg.App.OutboxRetention)
 
-	go notificator.Run(context.TODO(), messageCh)
+	// the digest is stopped after the notificator, so it gets the notifications about the last events.
+	digestCtx, stopDigest := context.WithCancel(context.Background())
+	defer stopDigest()
+	digest := core.NewDigest(logger, cfg.App.DigestWindow, outbox)
+	digestDone := make(chan struct{})
+	go func() {
+		defer close(digestDone)
+		digest.Run(digestCtx)
+	}()
 
-	bridge, err := core.NewBridge(logger, s, messageCh)
+	notificatorDone := make(chan struct{})
+	go func() {
+		defer close(notificatorDone)
+		notificator.Run(ctx, digest)
+	}()
+
+	bridge, err := core.NewBridge(logger, s, digest, core.WithWebAppURL(cfg.Telegram.WebAppURL))
 	if err != nil {
 		logger.Fatal("core.NewBridge() failed", zap.Error(err))
 	}
 
-	handler, err := api.NewHandler(logger, notificator, bridge, config)
+	bot.SetUnreachableUserHandler(core.UnsubscribeUnreachableUsers(logger, s, notificator, bridge))
+	core.NewCommands(s, notificator, bridge, core.WithWebAppURL(cfg.Telegram.WebAppURL)).Register(bot)
+	bot.Run(context.Background())
+
+	var serverOptions []api.ServerOption
+	// updatesDone is closed once the bot stops handling updates received by polling,
+	// webhook updates are handled by the server.
+	updatesDone := make(chan struct{})
+	if len(cfg.Telegram.WebhookURL) > 0 {
+		if len(cfg.Telegram.WebhookSecret) == 0 {
+			logger.Fatal("TELEGRAM_WEBHOOK_SECRET is required when TELEGRAM_WEBHOOK_URL is set")
+		}
+		if err := bot.SetWebhook(cfg.Telegram.WebhookURL, cfg.Telegram.WebhookSecret); err != nil {
+			logger.Fatal("bot.SetWebhook() failed", zap.Error(err))
+		}
+		serverOptions = append(serverOptions, api.WithTelegramWebhook(cfg.Telegram.WebhookSecret, bot))
+		close(updatesDone)
+	} else if cfg.Telegram.Polling {
+		// telegram allows a single getUpdates consumer per bot,
+		// so with several replicas either a webhook is used or only one replica polls.
+		if err := bot.DeleteWebhook(); err != nil {
+			logger.Fatal("bot.DeleteWebhook() failed", zap.Error(err))
+		}
+		go func() {
+			defer close(updatesDone)
+			bot.ReceiveUpdates(ctx)
+		}()
+	} else {
+		close(updatesDone)
+		logger.Warn("bot updates are not received by this replica: TELEGRAM_WEBHOOK_URL is not set and TELEGRAM_POLLING is false")
+	}
+
+	handler, err := api.NewHandler(logger, s, notificator, bridge, config)
 	if err != nil {
 		logger.Fatal("api.NewHandler() failed", zap.Error(err))
 	}
-	server, err := api.NewServer(logger, s.Pool(), handler, fmt.Sprintf(":%v", cfg.API.Port))
+	server, err := api.NewServer(logger, s.Pool(), handler, fmt.Sprintf(":%v", cfg.API.Port), serverOptions...)
 	if err != nil {
 		logger.Fatal("api.NewServer() failed", zap.Error(err))
 	}
@@ -81,5 +185,41 @@ func main() {
 	}()
 
 	fmt.Printf("running server :%v\n", cfg.API.Port)
-	server.Run()
+	go server.Run()
+
+	<-ctx.Done()
+	// a second signal kills the service immediately.
+	stop()
+	logger.Info("shutting down", zap.Duration("timeout", cfg.App.ShutdownTimeout))
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.App.ShutdownTimeout)
+	defer cancel()
+
+	// requests and bot updates are stopped first,
+	// so the notifications and command replies they produce still reach the bot queue.
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		logger.Warn("server.Shutdown() failed", zap.Error(err))
+	}
+	if err := waitDone(shutdownCtx, updatesDone); err != nil {
+		logger.Warn("bot updates haven't stopped in time", zap.Error(err))
+	}
+	// the SSE streams are closed by ctx, the notifications about the traces being processed
+	// go through the digest and the outbox to the bot queue, the queued traces are received again after a restart.
+	if err := waitDone(shutdownCtx, notificatorDone); err != nil {
+		logger.Warn("notificator hasn't stopped in time", zap.Error(err))
+	}
+	stopDigest()
+	if err := waitDone(shutdownCtx, digestDone); err != nil {
+		logger.Warn("digest hasn't been flushed in time", zap.Error(err))
+	}
+	if err := outbox.Shutdown(shutdownCtx); err != nil {
+		logger.Warn("outbox hasn't been drained in time", zap.Error(err))
+	}
+	if err := bot.Shutdown(shutdownCtx); err != nil {
+		logger.Warn("bot queue hasn't been drained in time", zap.Error(err))
+	}
+	if err := metricServer.Shutdown(shutdownCtx); err != nil {
+		logger.Warn("metricServer.Shutdown() failed", zap.Error(err))
+	}
+	s.Pool().Close()
+	logger.Info("shutdown completed")
 }
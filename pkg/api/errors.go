@@ -13,6 +13,13 @@ func BadRequest(msg string) *oas.ErrorStatusCode {
 	}
 }
 
+func NotFound(err error) *oas.ErrorStatusCode {
+	return &oas.ErrorStatusCode{
+		StatusCode: http.StatusNotFound,
+		Response:   oas.Error{Error: err.Error()},
+	}
+}
+
 func InternalError(err error) *oas.ErrorStatusCode {
 	return &oas.ErrorStatusCode{
 		StatusCode: http.StatusInternalServerError,
@@ -26,3 +33,17 @@ func Unauthorized(err error) *oas.ErrorStatusCode {
 		Response:   oas.Error{Error: err.Error()},
 	}
 }
+
+// ErrorCodeWriteAccessRequired tells the TWA that the bot isn't allowed to message the user,
+// so the TWA should ask for it with requestWriteAccess.
+const ErrorCodeWriteAccessRequired = "write_access_required"
+
+func WriteAccessRequired() *oas.ErrorStatusCode {
+	return &oas.ErrorStatusCode{
+		StatusCode: http.StatusForbidden,
+		Response: oas.Error{
+			Error: "the bot is not allowed to send messages to the user",
+			Code:  oas.NewOptString(ErrorCodeWriteAccessRequired),
+		},
+	}
+}
@@ -2,8 +2,11 @@ package api
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
+	"github.com/ogen-go/ogen/ogenerrors"
 	"github.com/tonkeeper/tongo"
 	"github.com/tonkeeper/tongo/liteapi"
 	"github.com/tonkeeper/tongo/tonconnect"
@@ -19,29 +22,52 @@ import (
 type Handler struct {
 	logger *zap.Logger
 
-	telegramSecret string
-	tonConnect     *tonconnect.Server
-	notificator    *core.AccountEventsNotificator
-	bridge         *core.Bridge
+	storage     core.Storage
+	tonConnect  *tonconnect.Server
+	notificator *core.AccountEventsNotificator
+	bridge      *core.Bridge
 
-	// extractUserFn is an indirection for testing.
-	extractUserFn extractUserFromTwaInitDataFn
+	// verifyInitDataFn is an indirection for testing.
+	verifyInitDataFn verifyTwaInitDataFn
+	replayGuard      ReplayGuard
+	// initDataLifetime is how long used init data is remembered by replayGuard.
+	initDataLifetime time.Duration
+	sessions         *sessionIssuer
 }
 
-// extractUserFromTwaInitDataFn extracts a telegram user ID from TWA init data.
+// verifyTwaInitDataFn validates TWA init data and extracts a telegram user from it.
 //
 // For more details see
 // https://docs.twa.dev/docs/launch-params/init-data#authorization-and-authentication
-type extractUserFromTwaInitDataFn func(data string, telegramSecret string) (telegram.UserID, error)
+type verifyTwaInitDataFn func(data string) (telegram.InitData, error)
 
 type Config struct {
 	TonConnectSecret  string
 	TelegramBotSecret string
+	// TelegramBotID is required to validate init data signatures if TelegramBotSecret is not set.
+	TelegramBotID int64
+	// TelegramTestEnvironment is true if the TWA works in the telegram test environment.
+	TelegramTestEnvironment bool
+	InitDataValidation      telegram.InitDataValidation
+	// InitDataLifetime is how long TWA init data is accepted after it was issued, default is one hour.
+	InitDataLifetime time.Duration
+	// ReplayGuard rejects init data used more than once by destructive operations and CreateSession
+	// and refresh tokens used more than once.
+	// Default is MemoryReplayGuard.
+	ReplayGuard ReplayGuard
+	// SessionSecret is a key to sign session tokens.
+	// If it is empty, a random key is used and sessions don't survive restarts.
+	SessionSecret string
+	// SessionLifetime is how long a session token is valid, default is 15 minutes.
+	SessionLifetime time.Duration
+	// RefreshLifetime is how long a session can be refreshed after it was created, default is 7 days.
+	RefreshLifetime time.Duration
 }
 
 var _ oas.Handler = (*Handler)(nil)
+var _ oas.SecurityHandler = (*Handler)(nil)
 
-func NewHandler(logger *zap.Logger, notificator *core.AccountEventsNotificator, bridge *core.Bridge, config Config) (*Handler, error) {
+func NewHandler(logger *zap.Logger, storage core.Storage, notificator *core.AccountEventsNotificator, bridge *core.Bridge, config Config) (*Handler, error) {
 	cli, err := liteapi.NewClient(liteapi.Mainnet(), liteapi.FromEnvs())
 	if err != nil {
 		return nil, err
@@ -50,13 +76,37 @@ func NewHandler(logger *zap.Logger, notificator *core.AccountEventsNotificator,
 	if err != nil {
 		return nil, fmt.Errorf("failed to init tonconnect: %w", err)
 	}
+	verifier, err := telegram.NewInitDataVerifier(telegram.InitDataConfig{
+		Validation:      config.InitDataValidation,
+		BotToken:        config.TelegramBotSecret,
+		BotID:           config.TelegramBotID,
+		TestEnvironment: config.TelegramTestEnvironment,
+		Lifetime:        config.InitDataLifetime,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to init twa init data verifier: %w", err)
+	}
+	replayGuard := config.ReplayGuard
+	if replayGuard == nil {
+		replayGuard = NewMemoryReplayGuard()
+	}
+	if len(config.SessionSecret) == 0 {
+		logger.Warn("session secret is not set, session tokens are valid on this replica until restart only")
+	}
+	sessions, err := newSessionIssuer(config.SessionSecret, config.SessionLifetime, config.RefreshLifetime)
+	if err != nil {
+		return nil, fmt.Errorf("failed to init sessions: %w", err)
+	}
 	return &Handler{
-		logger:         logger,
-		bridge:         bridge,
-		tonConnect:     tonConnect,
-		notificator:    notificator,
-		telegramSecret: config.TelegramBotSecret,
-		extractUserFn:  telegram.ExtractUserIDFromInitData,
+		logger:           logger,
+		storage:          storage,
+		bridge:           bridge,
+		tonConnect:       tonConnect,
+		notificator:      notificator,
+		verifyInitDataFn: verifier.Verify,
+		replayGuard:      replayGuard,
+		initDataLifetime: verifier.Lifetime(),
+		sessions:         sessions,
 	}, nil
 }
 
@@ -64,11 +114,135 @@ func (h *Handler) NewError(ctx context.Context, err error) *oas.ErrorStatusCode
 	switch x := err.(type) {
 	case *oas.ErrorStatusCode:
 		return x
+	case *ogenerrors.SecurityError:
+		return Unauthorized(x.Err)
 	default:
 		return InternalError(x)
 	}
 }
 
+// HandleBearerAuth accepts session tokens issued by CreateSession.
+func (h *Handler) HandleBearerAuth(ctx context.Context, operationName string, t oas.BearerAuth) (context.Context, error) {
+	claims, err := h.sessions.verify(t.Token, accessTokenKind, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return context.WithValue(ctx, sessionUserKey{}, claims.UserID), nil
+}
+
+// authenticate returns a user authenticated by a session token and updates when the user was last seen.
+// Without a session token, it extracts a telegram user from TWA init data and saves the user's profile.
+// It returns errors ready to be sent to a client.
+func (h *Handler) authenticate(ctx context.Context, initData oas.OptString) (telegram.UserID, error) {
+	if userID, ok := sessionUser(ctx); ok {
+		return h.touchUser(ctx, userID)
+	}
+	if !initData.IsSet() {
+		return 0, Unauthorized(fmt.Errorf("session token or twa init data is required"))
+	}
+	data, err := h.verifyInitDataFn(initData.Value)
+	if err != nil {
+		return 0, BadRequest(err.Error())
+	}
+	// subscriptions reference users, so the user must be saved before anything else.
+	if err := h.storage.SaveUser(ctx, data.User); err != nil {
+		return 0, InternalError(err)
+	}
+	return data.User.ID, nil
+}
+
+// touchUser updates when a user authenticated by a session token was last seen.
+func (h *Handler) touchUser(ctx context.Context, userID telegram.UserID) (telegram.UserID, error) {
+	if err := h.storage.TouchUser(ctx, userID); err != nil {
+		return 0, InternalError(err)
+	}
+	return userID, nil
+}
+
+// authenticateOnce works like authenticate but accepts the given init data only once,
+// so destructive operations can't be replayed.
+// Session tokens are issued to be reused, so they are accepted until they expire.
+func (h *Handler) authenticateOnce(ctx context.Context, initData oas.OptString) (telegram.UserID, error) {
+	if userID, ok := sessionUser(ctx); ok {
+		return h.touchUser(ctx, userID)
+	}
+	if !initData.IsSet() {
+		return 0, Unauthorized(fmt.Errorf("session token or twa init data is required"))
+	}
+	data, err := h.verifyInitDataFn(initData.Value)
+	if err != nil {
+		return 0, BadRequest(err.Error())
+	}
+	ok, err := h.replayGuard.UseOnce(ctx, data.ReplayKey, h.initDataLifetime)
+	if err != nil {
+		return 0, InternalError(err)
+	}
+	if !ok {
+		return 0, Unauthorized(fmt.Errorf("init data has already been used"))
+	}
+	if err := h.storage.SaveUser(ctx, data.User); err != nil {
+		return 0, InternalError(err)
+	}
+	return data.User.ID, nil
+}
+
+// requireWriteAccess returns an error with ErrorCodeWriteAccessRequired
+// if the bot isn't allowed to send messages to a user.
+func (h *Handler) requireWriteAccess(ctx context.Context, userID telegram.UserID) error {
+	allowed, err := h.storage.HasWriteAccess(ctx, userID)
+	if err != nil {
+		return InternalError(err)
+	}
+	if !allowed {
+		return WriteAccessRequired()
+	}
+	return nil
+}
+
+// CreateSession exchanges TWA init data for a session token.
+// Init data is exchanged only once, otherwise intercepted init data would mint sessions until it expires.
+func (h *Handler) CreateSession(ctx context.Context, req *oas.CreateSessionReq) (*oas.Session, error) {
+	userID, err := h.authenticateOnce(ctx, oas.NewOptString(req.TwaInitData))
+	if err != nil {
+		return nil, err
+	}
+	return h.newSession(userID, time.Time{})
+}
+
+// RefreshSession exchanges a refresh token for a new session.
+// A refresh token is accepted once and the new session ends when the refreshed one does,
+// so a leaked refresh token doesn't give a session forever.
+func (h *Handler) RefreshSession(ctx context.Context, req *oas.RefreshSessionReq) (*oas.Session, error) {
+	now := time.Now()
+	claims, err := h.sessions.verify(req.RefreshToken, refreshTokenKind, now)
+	if err != nil {
+		return nil, Unauthorized(err)
+	}
+	expiresAt := time.Unix(claims.ExpiresAt, 0)
+	ok, err := h.replayGuard.UseOnce(ctx, "refresh:"+claims.ID, expiresAt.Sub(now))
+	if err != nil {
+		return nil, InternalError(err)
+	}
+	if !ok {
+		return nil, Unauthorized(fmt.Errorf("refresh token has already been used"))
+	}
+	return h.newSession(claims.UserID, expiresAt)
+}
+
+// newSession issues a session ending at refreshExpiresAt, a zero refreshExpiresAt starts a new session.
+func (h *Handler) newSession(userID telegram.UserID, refreshExpiresAt time.Time) (*oas.Session, error) {
+	s, err := h.sessions.issue(userID, time.Now(), refreshExpiresAt)
+	if err != nil {
+		return nil, InternalError(err)
+	}
+	return &oas.Session{
+		Token:            s.Token,
+		ExpiresAt:        s.ExpiresAt.Unix(),
+		RefreshToken:     s.RefreshToken,
+		RefreshExpiresAt: s.RefreshExpiresAt.Unix(),
+	}, nil
+}
+
 // GetTonConnectPayload returns a challenge for TON Connect.
 func (h *Handler) GetTonConnectPayload(ctx context.Context) (*oas.GetTonConnectPayloadOK, error) {
 	payload, err := h.tonConnect.GeneratePayload()
@@ -101,9 +275,12 @@ func (h *Handler) SubscribeToAccountEvents(ctx context.Context, req *oas.Subscri
 	if !verified {
 		return BadRequest("failed to verify proof")
 	}
-	userID, err := h.extractUserFn(req.TwaInitData, h.telegramSecret)
+	userID, err := h.authenticate(ctx, req.TwaInitData)
 	if err != nil {
-		return BadRequest(err.Error())
+		return err
+	}
+	if err := h.requireWriteAccess(ctx, userID); err != nil {
+		return err
 	}
 	if err := h.notificator.Subscribe(userID, account); err != nil {
 		return InternalError(err)
@@ -113,9 +290,9 @@ func (h *Handler) SubscribeToAccountEvents(ctx context.Context, req *oas.Subscri
 
 // AccountEventsSubscriptionStatus returns a status of an account-events subscription.
 func (h *Handler) AccountEventsSubscriptionStatus(ctx context.Context, req *oas.AccountEventsSubscriptionStatusReq) (*oas.AccountEventsSubscriptionStatusOK, error) {
-	userID, err := h.extractUserFn(req.TwaInitData, h.telegramSecret)
+	userID, err := h.authenticate(ctx, req.TwaInitData)
 	if err != nil {
-		return nil, BadRequest(err.Error())
+		return nil, err
 	}
 	accountID, err := tongo.ParseAccountID(req.Address)
 	if err != nil {
@@ -125,13 +302,45 @@ func (h *Handler) AccountEventsSubscriptionStatus(ctx context.Context, req *oas.
 	return &oas.AccountEventsSubscriptionStatusOK{Subscribed: subscribed}, nil
 }
 
+// GetAccountEventsSubscriptions returns accounts a user is subscribed to.
+// Init data is taken from a header, so it doesn't end up in access logs and browser history.
+func (h *Handler) GetAccountEventsSubscriptions(ctx context.Context, params oas.GetAccountEventsSubscriptionsParams) (*oas.GetAccountEventsSubscriptionsOK, error) {
+	userID, err := h.authenticate(ctx, params.XTwaInitData)
+	if err != nil {
+		return nil, err
+	}
+	subs, err := h.storage.GetUserAccountEventsSubscriptions(ctx, userID)
+	if err != nil {
+		return nil, InternalError(err)
+	}
+	result := oas.GetAccountEventsSubscriptionsOK{
+		Subscriptions: make([]oas.AccountEventsSubscription, 0, len(subs)),
+	}
+	for _, sub := range subs {
+		result.Subscriptions = append(result.Subscriptions, oas.AccountEventsSubscription{
+			Address:         sub.Account.ToRaw(),
+			FriendlyAddress: sub.Account.ToHuman(true, false),
+			CreatedAt:       sub.CreatedAt.Unix(),
+		})
+	}
+	return &result, nil
+}
+
 // UnsubscribeFromAccountEvents unsubscribes from notifications about events in the TON blockchain for a specific address.
 func (h *Handler) UnsubscribeFromAccountEvents(ctx context.Context, req *oas.UnsubscribeFromAccountEventsReq) error {
-	userID, err := h.extractUserFn(req.TwaInitData, h.telegramSecret)
+	userID, err := h.authenticateOnce(ctx, req.TwaInitData)
 	if err != nil {
-		return BadRequest(err.Error())
+		return err
+	}
+	var account *tongo.AccountID
+	if req.Address.IsSet() {
+		accountID, err := tongo.ParseAccountID(req.Address.Value)
+		if err != nil {
+			return BadRequest(err.Error())
+		}
+		account = &accountID
 	}
-	if err := h.notificator.Unsubscribe(userID); err != nil {
+	if err := h.notificator.Unsubscribe(userID, account); err != nil {
 		return InternalError(err)
 	}
 	return nil
@@ -139,9 +348,12 @@ func (h *Handler) UnsubscribeFromAccountEvents(ctx context.Context, req *oas.Uns
 
 // SubscribeToBridgeEvents subscribes to notifications from the HTTP Bridge regarding a specific smart contract or wallet.
 func (h *Handler) SubscribeToBridgeEvents(ctx context.Context, req *oas.SubscribeToBridgeEventsReq) error {
-	userID, err := h.extractUserFn(req.TwaInitData, h.telegramSecret)
+	userID, err := h.authenticate(ctx, req.TwaInitData)
 	if err != nil {
-		return BadRequest(err.Error())
+		return err
+	}
+	if err := h.requireWriteAccess(ctx, userID); err != nil {
+		return err
 	}
 	if err := h.bridge.Subscribe(userID, core.ClientID(req.ClientID), req.Origin); err != nil {
 		return InternalError(err)
@@ -155,11 +367,35 @@ func (h *Handler) BridgeWebhook(ctx context.Context, req *oas.BridgeWebhookReq,
 	return nil
 }
 
+// GetBridgeSubscriptions returns dApps a user gets bridge notifications from.
+// Init data is taken from a header, so it doesn't end up in access logs and browser history.
+func (h *Handler) GetBridgeSubscriptions(ctx context.Context, params oas.GetBridgeSubscriptionsParams) (*oas.GetBridgeSubscriptionsOK, error) {
+	userID, err := h.authenticate(ctx, params.XTwaInitData)
+	if err != nil {
+		return nil, err
+	}
+	subs, err := h.storage.GetUserBridgeSubscriptions(ctx, userID)
+	if err != nil {
+		return nil, InternalError(err)
+	}
+	result := oas.GetBridgeSubscriptionsOK{
+		Subscriptions: make([]oas.BridgeSubscription, 0, len(subs)),
+	}
+	for _, sub := range subs {
+		result.Subscriptions = append(result.Subscriptions, oas.BridgeSubscription{
+			ClientID:  string(sub.ClientID),
+			Origin:    sub.Origin,
+			CreatedAt: sub.CreatedAt.Unix(),
+		})
+	}
+	return &result, nil
+}
+
 // UnsubscribeFromBridgeEvents unsubscribes from bridge notifications.
 func (h *Handler) UnsubscribeFromBridgeEvents(ctx context.Context, req *oas.UnsubscribeFromBridgeEventsReq) error {
-	userID, err := h.extractUserFn(req.TwaInitData, h.telegramSecret)
+	userID, err := h.authenticateOnce(ctx, req.TwaInitData)
 	if err != nil {
-		return BadRequest(err.Error())
+		return err
 	}
 	var clientID *core.ClientID
 	if req.ClientID.IsSet() {
@@ -171,3 +407,165 @@ func (h *Handler) UnsubscribeFromBridgeEvents(ctx context.Context, req *oas.Unsu
 	}
 	return nil
 }
+
+// GetAccountEventsFilters returns filters of a user's subscription to an account.
+func (h *Handler) GetAccountEventsFilters(ctx context.Context, req *oas.GetAccountEventsFiltersReq) (*oas.SubscriptionFilters, error) {
+	userID, err := h.authenticate(ctx, req.TwaInitData)
+	if err != nil {
+		return nil, err
+	}
+	accountID, err := tongo.ParseAccountID(req.Address)
+	if err != nil {
+		return nil, BadRequest(err.Error())
+	}
+	filters, err := h.storage.GetSubscriptionFilters(ctx, userID, accountID)
+	if errors.Is(err, core.ErrSubscriptionNotFound) {
+		return nil, NotFound(err)
+	}
+	if err != nil {
+		return nil, InternalError(err)
+	}
+	return convertSubscriptionFilters(filters), nil
+}
+
+// UpdateAccountEventsFilters replaces filters of a user's subscription to an account.
+func (h *Handler) UpdateAccountEventsFilters(ctx context.Context, req *oas.UpdateAccountEventsFiltersReq) error {
+	userID, err := h.authenticate(ctx, req.TwaInitData)
+	if err != nil {
+		return err
+	}
+	accountID, err := tongo.ParseAccountID(req.Address)
+	if err != nil {
+		return BadRequest(err.Error())
+	}
+	filters, err := parseSubscriptionFilters(req.Filters)
+	if err != nil {
+		return BadRequest(err.Error())
+	}
+	err = h.storage.SaveSubscriptionFilters(ctx, userID, accountID, filters)
+	if errors.Is(err, core.ErrSubscriptionNotFound) {
+		return NotFound(err)
+	}
+	if err != nil {
+		return InternalError(err)
+	}
+	return nil
+}
+
+func convertSubscriptionFilters(filters core.SubscriptionFilters) *oas.SubscriptionFilters {
+	result := oas.SubscriptionFilters{}
+	for _, actionType := range filters.Actions {
+		result.Actions = append(result.Actions, oas.SubscriptionFiltersActionsItem(actionType))
+	}
+	if filters.MinTonAmount > 0 {
+		result.MinTonAmount = oas.NewOptInt64(filters.MinTonAmount)
+	}
+	for _, master := range filters.JettonAllowlist {
+		result.JettonAllowlist = append(result.JettonAllowlist, master.ToRaw())
+	}
+	for _, master := range filters.JettonDenylist {
+		result.JettonDenylist = append(result.JettonDenylist, master.ToRaw())
+	}
+	if filters.OnlyVerifiedJettons {
+		result.OnlyVerifiedJettons = oas.NewOptBool(true)
+	}
+	return &result
+}
+
+func parseSubscriptionFilters(filters oas.SubscriptionFilters) (core.SubscriptionFilters, error) {
+	result := core.SubscriptionFilters{
+		MinTonAmount:        filters.MinTonAmount.Value,
+		OnlyVerifiedJettons: filters.OnlyVerifiedJettons.Value,
+	}
+	for _, actionType := range filters.Actions {
+		result.Actions = append(result.Actions, core.ActionType(actionType))
+	}
+	for _, address := range filters.JettonAllowlist {
+		master, err := tongo.ParseAccountID(address)
+		if err != nil {
+			return core.SubscriptionFilters{}, err
+		}
+		result.JettonAllowlist = append(result.JettonAllowlist, master)
+	}
+	for _, address := range filters.JettonDenylist {
+		master, err := tongo.ParseAccountID(address)
+		if err != nil {
+			return core.SubscriptionFilters{}, err
+		}
+		result.JettonDenylist = append(result.JettonDenylist, master)
+	}
+	if err := result.Validate(); err != nil {
+		return core.SubscriptionFilters{}, err
+	}
+	return result, nil
+}
+
+// GetNotificationSettings returns notification settings of a user.
+func (h *Handler) GetNotificationSettings(ctx context.Context, req *oas.GetNotificationSettingsReq) (*oas.NotificationSettings, error) {
+	userID, err := h.authenticate(ctx, req.TwaInitData)
+	if err != nil {
+		return nil, err
+	}
+	settings, err := h.storage.GetNotificationSettings(ctx, userID)
+	if err != nil {
+		return nil, InternalError(err)
+	}
+	return convertNotificationSettings(settings), nil
+}
+
+// UpdateNotificationSettings replaces notification settings of a user.
+func (h *Handler) UpdateNotificationSettings(ctx context.Context, req *oas.UpdateNotificationSettingsReq) error {
+	userID, err := h.authenticate(ctx, req.TwaInitData)
+	if err != nil {
+		return err
+	}
+	settings, err := parseNotificationSettings(req.Settings)
+	if err != nil {
+		return BadRequest(err.Error())
+	}
+	if err := h.storage.SaveNotificationSettings(ctx, userID, settings); err != nil {
+		return InternalError(err)
+	}
+	return nil
+}
+
+func convertNotificationSettings(settings core.NotificationSettings) *oas.NotificationSettings {
+	result := oas.NotificationSettings{}
+	if settings.QuietHours != nil {
+		result.QuietHours = oas.NewOptNotificationSettingsQuietHours(oas.NotificationSettingsQuietHours{
+			Start: core.FormatTimeOfDay(settings.QuietHours.Start),
+			End:   core.FormatTimeOfDay(settings.QuietHours.End),
+		})
+	}
+	if len(settings.Timezone) > 0 {
+		result.Timezone = oas.NewOptString(settings.Timezone)
+	}
+	if !settings.MuteUntil.IsZero() {
+		result.MuteUntil = oas.NewOptInt64(settings.MuteUntil.Unix())
+	}
+	return &result
+}
+
+func parseNotificationSettings(settings oas.NotificationSettings) (core.NotificationSettings, error) {
+	result := core.NotificationSettings{
+		Timezone: settings.Timezone.Value,
+	}
+	if settings.QuietHours.IsSet() {
+		start, err := core.ParseTimeOfDay(settings.QuietHours.Value.Start)
+		if err != nil {
+			return core.NotificationSettings{}, err
+		}
+		end, err := core.ParseTimeOfDay(settings.QuietHours.Value.End)
+		if err != nil {
+			return core.NotificationSettings{}, err
+		}
+		result.QuietHours = &core.QuietHours{Start: start, End: end}
+	}
+	if settings.MuteUntil.IsSet() && settings.MuteUntil.Value > 0 {
+		result.MuteUntil = time.Unix(settings.MuteUntil.Value, 0)
+	}
+	if err := result.Validate(); err != nil {
+		return core.NotificationSettings{}, err
+	}
+	return result, nil
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"github.com/tonkeeper/tongo"
@@ -16,6 +17,10 @@ import (
 )
 
 type MockStorage struct {
+	writeAccess          map[telegram.UserID]bool
+	accountSubscriptions []core.AccountEventsSubscription
+	filters              map[ton.AccountID]core.SubscriptionFilters
+	touched              []telegram.UserID
 }
 
 func (m *MockStorage) SubscribeToAccountEvents(ctx context.Context, userID telegram.UserID, account ton.Address) error {
@@ -26,7 +31,52 @@ func (m *MockStorage) GetAccountEventsSubscriptions(ctx context.Context) ([]core
 	return nil, nil
 }
 
-func (m *MockStorage) UnsubscribeAccountEvents(ctx context.Context, userID telegram.UserID) error {
+func (m *MockStorage) GetUserAccountEventsSubscriptions(ctx context.Context, userID telegram.UserID) ([]core.AccountEventsSubscription, error) {
+	var result []core.AccountEventsSubscription
+	for _, sub := range m.accountSubscriptions {
+		if sub.TelegramUserID == userID {
+			result = append(result, sub)
+		}
+	}
+	return result, nil
+}
+
+func (m *MockStorage) isSubscribed(userID telegram.UserID, account ton.AccountID) bool {
+	for _, sub := range m.accountSubscriptions {
+		if sub.TelegramUserID == userID && sub.Account == account {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *MockStorage) GetSubscriptionFilters(ctx context.Context, userID telegram.UserID, account ton.AccountID) (core.SubscriptionFilters, error) {
+	if !m.isSubscribed(userID, account) {
+		return core.SubscriptionFilters{}, core.ErrSubscriptionNotFound
+	}
+	return m.filters[account], nil
+}
+
+func (m *MockStorage) SaveSubscriptionFilters(ctx context.Context, userID telegram.UserID, account ton.AccountID, filters core.SubscriptionFilters) error {
+	if !m.isSubscribed(userID, account) {
+		return core.ErrSubscriptionNotFound
+	}
+	if m.filters == nil {
+		m.filters = map[ton.AccountID]core.SubscriptionFilters{}
+	}
+	m.filters[account] = filters
+	return nil
+}
+
+func (m *MockStorage) GetAccountSubscriptionFilters(ctx context.Context, account ton.AccountID, userIDs []telegram.UserID) (map[telegram.UserID]core.SubscriptionFilters, error) {
+	return nil, nil
+}
+
+func (m *MockStorage) GetUserBridgeSubscriptions(ctx context.Context, userID telegram.UserID) ([]core.BridgeSubscription, error) {
+	return nil, nil
+}
+
+func (m *MockStorage) UnsubscribeAccountEvents(ctx context.Context, userID telegram.UserID, account *ton.AccountID) error {
 	return nil
 }
 
@@ -42,6 +92,59 @@ func (m *MockStorage) GetBridgeSubscriptions(ctx context.Context) ([]core.Bridge
 	return nil, nil
 }
 
+func (m *MockStorage) SaveUnreachableUser(ctx context.Context, userID telegram.UserID, reason string) error {
+	return nil
+}
+
+func (m *MockStorage) SetMuteUntil(ctx context.Context, userID telegram.UserID, until time.Time) error {
+	return nil
+}
+
+func (m *MockStorage) SaveUser(ctx context.Context, user telegram.User) error {
+	return nil
+}
+
+func (m *MockStorage) TouchUser(ctx context.Context, userID telegram.UserID) error {
+	m.touched = append(m.touched, userID)
+	return nil
+}
+
+func (m *MockStorage) GrantWriteAccess(ctx context.Context, userID telegram.UserID) error {
+	return nil
+}
+
+func (m *MockStorage) HasWriteAccess(ctx context.Context, userID telegram.UserID) (bool, error) {
+	return m.writeAccess[userID], nil
+}
+
+func (m *MockStorage) GetStreamPosition(ctx context.Context, stream string) (core.StreamPosition, error) {
+	return core.StreamPosition{}, nil
+}
+
+func (m *MockStorage) SaveStreamPosition(ctx context.Context, stream string, position core.StreamPosition) error {
+	return nil
+}
+
+func (m *MockStorage) DeleteStreamPosition(ctx context.Context, stream string) error {
+	return nil
+}
+
+func (m *MockStorage) SaveStreamGap(ctx context.Context, gap core.StreamGap) error {
+	return nil
+}
+
+func (m *MockStorage) GetLanguageCodes(ctx context.Context, userIDs []telegram.UserID) (map[telegram.UserID]string, error) {
+	return nil, nil
+}
+
+func (m *MockStorage) GetNotificationSettings(ctx context.Context, userID telegram.UserID) (core.NotificationSettings, error) {
+	return core.NotificationSettings{}, nil
+}
+
+func (m *MockStorage) SaveNotificationSettings(ctx context.Context, userID telegram.UserID, settings core.NotificationSettings) error {
+	return nil
+}
+
 var _ core.Storage = (*MockStorage)(nil)
 
 func TestHandler_AccountEventsSubscriptionStatus(t *testing.T) {
@@ -54,7 +157,7 @@ func TestHandler_AccountEventsSubscriptionStatus(t *testing.T) {
 		{
 			name: "subscribed = true",
 			request: &oas.AccountEventsSubscriptionStatusReq{
-				TwaInitData: "1",
+				TwaInitData: oas.NewOptString("1"),
 				Address:     "0:dd61300e0060f80233363b3b4a0f3b27ad03b19cc4bec6ec798aab0b3e479eba",
 			},
 			wantSubscribed: true,
@@ -62,7 +165,7 @@ func TestHandler_AccountEventsSubscriptionStatus(t *testing.T) {
 		{
 			name: "subscribed = false",
 			request: &oas.AccountEventsSubscriptionStatusReq{
-				TwaInitData: "2",
+				TwaInitData: oas.NewOptString("2"),
 				Address:     "0:dd61300e0060f80233363b3b4a0f3b27ad03b19cc4bec6ec798aab0b3e479eba",
 			},
 			wantSubscribed: false,
@@ -70,11 +173,11 @@ func TestHandler_AccountEventsSubscriptionStatus(t *testing.T) {
 		{
 			name: "bad init data - error",
 			request: &oas.AccountEventsSubscriptionStatusReq{
-				TwaInitData: "broken_data",
+				TwaInitData: oas.NewOptString("broken_data"),
 				Address:     "0:dd61300e0060f80233363b3b4a0f3b27ad03b19cc4bec6ec798aab0b3e479eba",
 			},
 			wantSubscribed: false,
-			wantErr:        `code 400: {Error:twa init data err}`,
+			wantErr:        `code 400: {Error:twa init data err Code:{Value: Set:false}}`,
 		},
 	}
 	for _, tt := range tests {
@@ -88,17 +191,16 @@ func TestHandler_AccountEventsSubscriptionStatus(t *testing.T) {
 			require.Nil(t, err)
 
 			h := &Handler{
-				logger: zap.L(),
-				extractUserFn: func(data string, telegramSecret string) (telegram.UserID, error) {
-					require.Equal(t, "secret", telegramSecret)
+				logger:  zap.L(),
+				storage: s,
+				verifyInitDataFn: func(data string) (telegram.InitData, error) {
 					value, err := strconv.Atoi(data)
 					if err != nil {
-						return 0, errors.New("twa init data err")
+						return telegram.InitData{}, errors.New("twa init data err")
 					}
-					return telegram.UserID(value), nil
+					return telegram.InitData{User: telegram.User{ID: telegram.UserID(value)}}, nil
 				},
-				telegramSecret: "secret",
-				notificator:    notificator,
+				notificator: notificator,
 			}
 
 			status, err := h.AccountEventsSubscriptionStatus(context.Background(), tt.request)
@@ -111,3 +213,216 @@ func TestHandler_AccountEventsSubscriptionStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestHandler_GetAccountEventsSubscriptions(t *testing.T) {
+	createdAt := time.Unix(1700000000, 0)
+	s := &MockStorage{
+		accountSubscriptions: []core.AccountEventsSubscription{
+			{TelegramUserID: 1, Account: ton.MustParseAccountID("0:6ccd325a858c379693fae2bcaab1c2906831a4e10a6c3bb44ee8b615bca1d220"), CreatedAt: createdAt},
+			{TelegramUserID: 2, Account: ton.MustParseAccountID("0:dd61300e0060f80233363b3b4a0f3b27ad03b19cc4bec6ec798aab0b3e479eba"), CreatedAt: createdAt},
+		},
+	}
+	h := &Handler{
+		logger:  zap.L(),
+		storage: s,
+		verifyInitDataFn: func(data string) (telegram.InitData, error) {
+			return telegram.InitData{User: telegram.User{ID: 1}}, nil
+		},
+	}
+	want := []oas.AccountEventsSubscription{
+		{
+			Address:         "0:6ccd325a858c379693fae2bcaab1c2906831a4e10a6c3bb44ee8b615bca1d220",
+			FriendlyAddress: "EQBszTJahYw3lpP64ryqscKQaDGk4QpsO7RO6LYVvKHSINS0",
+			CreatedAt:       1700000000,
+		},
+	}
+	_, err := h.GetAccountEventsSubscriptions(context.Background(), oas.GetAccountEventsSubscriptionsParams{})
+	require.EqualError(t, err, "code 401: {Error:session token or twa init data is required Code:{Value: Set:false}}")
+
+	subs, err := h.GetAccountEventsSubscriptions(context.Background(), oas.GetAccountEventsSubscriptionsParams{
+		XTwaInitData: oas.NewOptString("1"),
+	})
+	require.Nil(t, err)
+	require.Equal(t, want, subs.Subscriptions)
+
+	ctx := context.WithValue(context.Background(), sessionUserKey{}, telegram.UserID(1))
+	subs, err = h.GetAccountEventsSubscriptions(ctx, oas.GetAccountEventsSubscriptionsParams{})
+	require.Nil(t, err)
+	require.Equal(t, want, subs.Subscriptions)
+}
+
+func TestHandler_AccountEventsFilters(t *testing.T) {
+	account := ton.MustParseAccountID("0:6ccd325a858c379693fae2bcaab1c2906831a4e10a6c3bb44ee8b615bca1d220")
+	s := &MockStorage{
+		accountSubscriptions: []core.AccountEventsSubscription{
+			{TelegramUserID: 1, Account: account},
+		},
+	}
+	h := &Handler{
+		logger:  zap.L(),
+		storage: s,
+		verifyInitDataFn: func(data string) (telegram.InitData, error) {
+			return telegram.InitData{User: telegram.User{ID: 1}}, nil
+		},
+	}
+	ctx := context.Background()
+	filters := oas.SubscriptionFilters{
+		Actions:             []oas.SubscriptionFiltersActionsItem{oas.SubscriptionFiltersActionsItemTonTransfer},
+		MinTonAmount:        oas.NewOptInt64(100_000_000),
+		JettonDenylist:      []string{"0:b113a994b5024a16719f69139328eb759596c38a25f59028b146fecdc3621dfe"},
+		OnlyVerifiedJettons: oas.NewOptBool(true),
+	}
+	err := h.UpdateAccountEventsFilters(ctx, &oas.UpdateAccountEventsFiltersReq{
+		TwaInitData: oas.NewOptString("1"),
+		Address:     account.ToRaw(),
+		Filters:     filters,
+	})
+	require.Nil(t, err)
+	got, err := h.GetAccountEventsFilters(ctx, &oas.GetAccountEventsFiltersReq{
+		TwaInitData: oas.NewOptString("1"),
+		Address:     "EQBszTJahYw3lpP64ryqscKQaDGk4QpsO7RO6LYVvKHSINS0",
+	})
+	require.Nil(t, err)
+	require.Equal(t, &filters, got)
+
+	err = h.UpdateAccountEventsFilters(ctx, &oas.UpdateAccountEventsFiltersReq{
+		TwaInitData: oas.NewOptString("1"),
+		Address:     "0:dd61300e0060f80233363b3b4a0f3b27ad03b19cc4bec6ec798aab0b3e479eba",
+		Filters:     filters,
+	})
+	require.EqualError(t, err, "code 404: {Error:subscription not found Code:{Value: Set:false}}")
+
+	err = h.UpdateAccountEventsFilters(ctx, &oas.UpdateAccountEventsFiltersReq{
+		TwaInitData: oas.NewOptString("1"),
+		Address:     account.ToRaw(),
+		Filters:     oas.SubscriptionFilters{MinTonAmount: oas.NewOptInt64(-1)},
+	})
+	require.EqualError(t, err, "code 400: {Error:min ton amount must not be negative Code:{Value: Set:false}}")
+}
+
+func Test_parseNotificationSettings(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings oas.NotificationSettings
+		want     core.NotificationSettings
+		wantErr  string
+	}{
+		{
+			name: "empty settings",
+		},
+		{
+			name: "all good",
+			settings: oas.NotificationSettings{
+				QuietHours: oas.NewOptNotificationSettingsQuietHours(oas.NotificationSettingsQuietHours{Start: "23:30", End: "07:00"}),
+				Timezone:   oas.NewOptString("Europe/Berlin"),
+				MuteUntil:  oas.NewOptInt64(1700000000),
+			},
+			want: core.NotificationSettings{
+				QuietHours: &core.QuietHours{Start: 23*60 + 30, End: 7 * 60},
+				Timezone:   "Europe/Berlin",
+				MuteUntil:  time.Unix(1700000000, 0),
+			},
+		},
+		{
+			name: "bad time of day",
+			settings: oas.NotificationSettings{
+				QuietHours: oas.NewOptNotificationSettingsQuietHours(oas.NotificationSettingsQuietHours{Start: "25:00", End: "07:00"}),
+			},
+			wantErr: `invalid time of day "25:00", expected HH:MM`,
+		},
+		{
+			name: "unknown timezone",
+			settings: oas.NotificationSettings{
+				Timezone: oas.NewOptString("Mars/Olympus"),
+			},
+			wantErr: `unknown timezone "Mars/Olympus"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings, err := parseNotificationSettings(tt.settings)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, tt.want, settings)
+		})
+	}
+}
+
+func TestHandler_UnsubscribeFromAccountEvents_replay(t *testing.T) {
+	s := &MockStorage{}
+	notificator, err := core.NewNotificator(zap.L(), s, "")
+	require.Nil(t, err)
+	h := &Handler{
+		logger:      zap.L(),
+		storage:     s,
+		notificator: notificator,
+		verifyInitDataFn: func(data string) (telegram.InitData, error) {
+			return telegram.InitData{User: telegram.User{ID: 1}, ReplayKey: data}, nil
+		},
+		replayGuard:      NewMemoryReplayGuard(),
+		initDataLifetime: time.Hour,
+	}
+	ctx := context.Background()
+
+	err = h.UnsubscribeFromAccountEvents(ctx, &oas.UnsubscribeFromAccountEventsReq{TwaInitData: oas.NewOptString("first")})
+	require.Nil(t, err)
+	err = h.UnsubscribeFromAccountEvents(ctx, &oas.UnsubscribeFromAccountEventsReq{TwaInitData: oas.NewOptString("first")})
+	require.EqualError(t, err, "code 401: {Error:init data has already been used Code:{Value: Set:false}}")
+	err = h.UnsubscribeFromAccountEvents(ctx, &oas.UnsubscribeFromAccountEventsReq{TwaInitData: oas.NewOptString("second")})
+	require.Nil(t, err)
+}
+
+func TestHandler_SubscribeToBridgeEvents_writeAccess(t *testing.T) {
+	s := &MockStorage{
+		writeAccess: map[telegram.UserID]bool{1: true},
+	}
+	bridge, err := core.NewBridge(zap.L(), s, &core.RecordingNotifier{})
+	require.Nil(t, err)
+	h := &Handler{
+		logger:  zap.L(),
+		storage: s,
+		bridge:  bridge,
+		verifyInitDataFn: func(data string) (telegram.InitData, error) {
+			userID, err := strconv.ParseInt(data, 10, 64)
+			if err != nil {
+				return telegram.InitData{}, err
+			}
+			return telegram.InitData{User: telegram.User{ID: telegram.UserID(userID)}}, nil
+		},
+	}
+	ctx := context.Background()
+
+	err = h.SubscribeToBridgeEvents(ctx, &oas.SubscribeToBridgeEventsReq{TwaInitData: oas.NewOptString("1"), ClientID: "1001", Origin: "ton.org"})
+	require.Nil(t, err)
+
+	err = h.SubscribeToBridgeEvents(ctx, &oas.SubscribeToBridgeEventsReq{TwaInitData: oas.NewOptString("2"), ClientID: "1002", Origin: "ton.org"})
+	var statusErr *oas.ErrorStatusCode
+	require.ErrorAs(t, err, &statusErr)
+	require.Equal(t, 403, statusErr.StatusCode)
+	require.Equal(t, oas.NewOptString(ErrorCodeWriteAccessRequired), statusErr.Response.Code)
+	require.Equal(t, []string{"ton.org"}, bridge.Origins(1))
+	require.Empty(t, bridge.Origins(2))
+}
+
+func TestMemoryReplayGuard_UseOnce(t *testing.T) {
+	g := NewMemoryReplayGuard()
+	ctx := context.Background()
+
+	ok, err := g.UseOnce(ctx, "a", time.Hour)
+	require.Nil(t, err)
+	require.True(t, ok)
+	ok, err = g.UseOnce(ctx, "a", time.Hour)
+	require.Nil(t, err)
+	require.False(t, ok)
+
+	// expired keys can be used again.
+	ok, err = g.UseOnce(ctx, "b", -time.Second)
+	require.Nil(t, err)
+	require.True(t, ok)
+	ok, err = g.UseOnce(ctx, "b", time.Hour)
+	require.Nil(t, err)
+	require.True(t, ok)
+}
@@ -17,6 +17,7 @@ import (
 
 	"github.com/ogen-go/ogen/conv"
 	ht "github.com/ogen-go/ogen/http"
+	"github.com/ogen-go/ogen/ogenerrors"
 	"github.com/ogen-go/ogen/otelogen"
 	"github.com/ogen-go/ogen/uri"
 )
@@ -35,12 +36,52 @@ type Invoker interface {
 	//
 	// POST /bridge/webhook/{client_id}
 	BridgeWebhook(ctx context.Context, request *BridgeWebhookReq, params BridgeWebhookParams) error
+	// CreateSession invokes createSession operation.
+	//
+	// Exchange twa init data for a session token. Init data can be exchanged only once. Other operations
+	// accept the token as a bearer token in the Authorization header instead of twa_init_data.
+	//
+	// POST /auth/session
+	CreateSession(ctx context.Context, request *CreateSessionReq) (*Session, error)
+	// GetAccountEventsFilters invokes getAccountEventsFilters operation.
+	//
+	// Get filters deciding which events of an account a user is notified about.
+	//
+	// POST /account-events/filters
+	GetAccountEventsFilters(ctx context.Context, request *GetAccountEventsFiltersReq) (*SubscriptionFilters, error)
+	// GetAccountEventsSubscriptions invokes getAccountEventsSubscriptions operation.
+	//
+	// Get accounts a user is subscribed to. Without a session token, twa init data is passed in a header,
+	//  so it doesn't end up in URLs.
+	//
+	// GET /account-events/subscriptions
+	GetAccountEventsSubscriptions(ctx context.Context, params GetAccountEventsSubscriptionsParams) (*GetAccountEventsSubscriptionsOK, error)
+	// GetBridgeSubscriptions invokes getBridgeSubscriptions operation.
+	//
+	// Get dApps a user gets bridge notifications from. Without a session token, twa init data is passed
+	// in a header, so it doesn't end up in URLs.
+	//
+	// GET /bridge/subscriptions
+	GetBridgeSubscriptions(ctx context.Context, params GetBridgeSubscriptionsParams) (*GetBridgeSubscriptionsOK, error)
+	// GetNotificationSettings invokes getNotificationSettings operation.
+	//
+	// Get notification settings of a user.
+	//
+	// POST /notification-settings
+	GetNotificationSettings(ctx context.Context, request *GetNotificationSettingsReq) (*NotificationSettings, error)
 	// GetTonConnectPayload invokes getTonConnectPayload operation.
 	//
 	// Get a challenge for TON Connect.
 	//
 	// GET /tonconnect/payload
 	GetTonConnectPayload(ctx context.Context) (*GetTonConnectPayloadOK, error)
+	// RefreshSession invokes refreshSession operation.
+	//
+	// Exchange a refresh token for a new session before the refresh token expires. A refresh token can
+	// be exchanged only once, the new session expires when the refreshed one does.
+	//
+	// POST /auth/refresh
+	RefreshSession(ctx context.Context, request *RefreshSessionReq) (*Session, error)
 	// SubscribeToAccountEvents invokes subscribeToAccountEvents operation.
 	//
 	// Subscribe to notifications about events in the TON blockchain for a specific address.
@@ -55,21 +96,37 @@ type Invoker interface {
 	SubscribeToBridgeEvents(ctx context.Context, request *SubscribeToBridgeEventsReq) error
 	// UnsubscribeFromAccountEvents invokes unsubscribeFromAccountEvents operation.
 	//
-	// Unsubscribe from notifications about events in the TON blockchain for a specific address.
+	// Unsubscribe from notifications about events in the TON blockchain for a specific address or, if no
+	// address is given, for all addresses. The same twa_init_data is accepted only once, a session token
+	// can be used until it expires.
 	//
 	// POST /account-events/unsubscribe
 	UnsubscribeFromAccountEvents(ctx context.Context, request *UnsubscribeFromAccountEventsReq) error
 	// UnsubscribeFromBridgeEvents invokes unsubscribeFromBridgeEvents operation.
 	//
-	// Unsubscribe from bridge notifications.
+	// Unsubscribe from bridge notifications. The same twa_init_data is accepted only once, a session
+	// token can be used until it expires.
 	//
 	// POST /bridge/unsubscribe
 	UnsubscribeFromBridgeEvents(ctx context.Context, request *UnsubscribeFromBridgeEventsReq) error
+	// UpdateAccountEventsFilters invokes updateAccountEventsFilters operation.
+	//
+	// Replace filters deciding which events of an account a user is notified about.
+	//
+	// POST /account-events/filters/update
+	UpdateAccountEventsFilters(ctx context.Context, request *UpdateAccountEventsFiltersReq) error
+	// UpdateNotificationSettings invokes updateNotificationSettings operation.
+	//
+	// Update notification settings of a user.
+	//
+	// POST /notification-settings/update
+	UpdateNotificationSettings(ctx context.Context, request *UpdateNotificationSettingsReq) error
 }
 
 // Client implements OAS client.
 type Client struct {
 	serverURL *url.URL
+	sec       SecuritySource
 	baseClient
 }
 type errorHandler interface {
@@ -87,7 +144,7 @@ func trimTrailingSlashes(u *url.URL) {
 }
 
 // NewClient initializes new Client defined by OAS.
-func NewClient(serverURL string, opts ...ClientOption) (*Client, error) {
+func NewClient(serverURL string, sec SecuritySource, opts ...ClientOption) (*Client, error) {
 	u, err := url.Parse(serverURL)
 	if err != nil {
 		return nil, err
@@ -100,6 +157,7 @@ func NewClient(serverURL string, opts ...ClientOption) (*Client, error) {
 	}
 	return &Client{
 		serverURL:  u,
+		sec:        sec,
 		baseClient: c,
 	}, nil
 }
@@ -179,6 +237,40 @@ func (c *Client) sendAccountEventsSubscriptionStatus(ctx context.Context, reques
 		return res, errors.Wrap(err, "encode request")
 	}
 
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+			stage = "Security:BearerAuth"
+			switch err := c.securityBearerAuth(ctx, "AccountEventsSubscriptionStatus", r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"BearerAuth\"")
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{0b00000001},
+				{},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
+	}
+
 	stage = "SendRequest"
 	resp, err := c.cfg.Client.Do(r)
 	if err != nil {
@@ -289,22 +381,23 @@ func (c *Client) sendBridgeWebhook(ctx context.Context, request *BridgeWebhookRe
 	return result, nil
 }
 
-// GetTonConnectPayload invokes getTonConnectPayload operation.
+// CreateSession invokes createSession operation.
 //
-// Get a challenge for TON Connect.
+// Exchange twa init data for a session token. Init data can be exchanged only once. Other operations
+// accept the token as a bearer token in the Authorization header instead of twa_init_data.
 //
-// GET /tonconnect/payload
-func (c *Client) GetTonConnectPayload(ctx context.Context) (*GetTonConnectPayloadOK, error) {
-	res, err := c.sendGetTonConnectPayload(ctx)
+// POST /auth/session
+func (c *Client) CreateSession(ctx context.Context, request *CreateSessionReq) (*Session, error) {
+	res, err := c.sendCreateSession(ctx, request)
 	_ = res
 	return res, err
 }
 
-func (c *Client) sendGetTonConnectPayload(ctx context.Context) (res *GetTonConnectPayloadOK, err error) {
+func (c *Client) sendCreateSession(ctx context.Context, request *CreateSessionReq) (res *Session, err error) {
 	otelAttrs := []attribute.KeyValue{
-		otelogen.OperationID("getTonConnectPayload"),
-		semconv.HTTPMethodKey.String("GET"),
-		semconv.HTTPRouteKey.String("/tonconnect/payload"),
+		otelogen.OperationID("createSession"),
+		semconv.HTTPMethodKey.String("POST"),
+		semconv.HTTPRouteKey.String("/auth/session"),
 	}
 
 	// Run stopwatch.
@@ -319,7 +412,7 @@ func (c *Client) sendGetTonConnectPayload(ctx context.Context) (res *GetTonConne
 	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
 
 	// Start a span for this request.
-	ctx, span := c.cfg.Tracer.Start(ctx, "GetTonConnectPayload",
+	ctx, span := c.cfg.Tracer.Start(ctx, "CreateSession",
 		trace.WithAttributes(otelAttrs...),
 		clientSpanKind,
 	)
@@ -337,14 +430,17 @@ func (c *Client) sendGetTonConnectPayload(ctx context.Context) (res *GetTonConne
 	stage = "BuildURL"
 	u := uri.Clone(c.requestURL(ctx))
 	var pathParts [1]string
-	pathParts[0] = "/tonconnect/payload"
+	pathParts[0] = "/auth/session"
 	uri.AddPathParts(u, pathParts[:]...)
 
 	stage = "EncodeRequest"
-	r, err := ht.NewRequest(ctx, "GET", u)
+	r, err := ht.NewRequest(ctx, "POST", u)
 	if err != nil {
 		return res, errors.Wrap(err, "create request")
 	}
+	if err := encodeCreateSessionRequest(request, r); err != nil {
+		return res, errors.Wrap(err, "encode request")
+	}
 
 	stage = "SendRequest"
 	resp, err := c.cfg.Client.Do(r)
@@ -354,7 +450,7 @@ func (c *Client) sendGetTonConnectPayload(ctx context.Context) (res *GetTonConne
 	defer resp.Body.Close()
 
 	stage = "DecodeResponse"
-	result, err := decodeGetTonConnectPayloadResponse(resp)
+	result, err := decodeCreateSessionResponse(resp)
 	if err != nil {
 		return res, errors.Wrap(err, "decode response")
 	}
@@ -362,22 +458,22 @@ func (c *Client) sendGetTonConnectPayload(ctx context.Context) (res *GetTonConne
 	return result, nil
 }
 
-// SubscribeToAccountEvents invokes subscribeToAccountEvents operation.
+// GetAccountEventsFilters invokes getAccountEventsFilters operation.
 //
-// Subscribe to notifications about events in the TON blockchain for a specific address.
+// Get filters deciding which events of an account a user is notified about.
 //
-// POST /account-events/subscribe
-func (c *Client) SubscribeToAccountEvents(ctx context.Context, request *SubscribeToAccountEventsReq) error {
-	res, err := c.sendSubscribeToAccountEvents(ctx, request)
+// POST /account-events/filters
+func (c *Client) GetAccountEventsFilters(ctx context.Context, request *GetAccountEventsFiltersReq) (*SubscriptionFilters, error) {
+	res, err := c.sendGetAccountEventsFilters(ctx, request)
 	_ = res
-	return err
+	return res, err
 }
 
-func (c *Client) sendSubscribeToAccountEvents(ctx context.Context, request *SubscribeToAccountEventsReq) (res *SubscribeToAccountEventsOK, err error) {
+func (c *Client) sendGetAccountEventsFilters(ctx context.Context, request *GetAccountEventsFiltersReq) (res *SubscriptionFilters, err error) {
 	otelAttrs := []attribute.KeyValue{
-		otelogen.OperationID("subscribeToAccountEvents"),
+		otelogen.OperationID("getAccountEventsFilters"),
 		semconv.HTTPMethodKey.String("POST"),
-		semconv.HTTPRouteKey.String("/account-events/subscribe"),
+		semconv.HTTPRouteKey.String("/account-events/filters"),
 	}
 
 	// Run stopwatch.
@@ -392,7 +488,7 @@ func (c *Client) sendSubscribeToAccountEvents(ctx context.Context, request *Subs
 	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
 
 	// Start a span for this request.
-	ctx, span := c.cfg.Tracer.Start(ctx, "SubscribeToAccountEvents",
+	ctx, span := c.cfg.Tracer.Start(ctx, "GetAccountEventsFilters",
 		trace.WithAttributes(otelAttrs...),
 		clientSpanKind,
 	)
@@ -410,7 +506,7 @@ func (c *Client) sendSubscribeToAccountEvents(ctx context.Context, request *Subs
 	stage = "BuildURL"
 	u := uri.Clone(c.requestURL(ctx))
 	var pathParts [1]string
-	pathParts[0] = "/account-events/subscribe"
+	pathParts[0] = "/account-events/filters"
 	uri.AddPathParts(u, pathParts[:]...)
 
 	stage = "EncodeRequest"
@@ -418,10 +514,44 @@ func (c *Client) sendSubscribeToAccountEvents(ctx context.Context, request *Subs
 	if err != nil {
 		return res, errors.Wrap(err, "create request")
 	}
-	if err := encodeSubscribeToAccountEventsRequest(request, r); err != nil {
+	if err := encodeGetAccountEventsFiltersRequest(request, r); err != nil {
 		return res, errors.Wrap(err, "encode request")
 	}
 
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+			stage = "Security:BearerAuth"
+			switch err := c.securityBearerAuth(ctx, "GetAccountEventsFilters", r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"BearerAuth\"")
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{0b00000001},
+				{},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
+	}
+
 	stage = "SendRequest"
 	resp, err := c.cfg.Client.Do(r)
 	if err != nil {
@@ -430,7 +560,7 @@ func (c *Client) sendSubscribeToAccountEvents(ctx context.Context, request *Subs
 	defer resp.Body.Close()
 
 	stage = "DecodeResponse"
-	result, err := decodeSubscribeToAccountEventsResponse(resp)
+	result, err := decodeGetAccountEventsFiltersResponse(resp)
 	if err != nil {
 		return res, errors.Wrap(err, "decode response")
 	}
@@ -438,22 +568,24 @@ func (c *Client) sendSubscribeToAccountEvents(ctx context.Context, request *Subs
 	return result, nil
 }
 
-// SubscribeToBridgeEvents invokes subscribeToBridgeEvents operation.
+// GetAccountEventsSubscriptions invokes getAccountEventsSubscriptions operation.
 //
-// Subscribe to notifications from the HTTP Bridge regarding a specific smart contract or wallet.
+// Get accounts a user is subscribed to. Without a session token, twa init data is passed in a header,
 //
-// POST /bridge/subscribe
-func (c *Client) SubscribeToBridgeEvents(ctx context.Context, request *SubscribeToBridgeEventsReq) error {
-	res, err := c.sendSubscribeToBridgeEvents(ctx, request)
+//	so it doesn't end up in URLs.
+//
+// GET /account-events/subscriptions
+func (c *Client) GetAccountEventsSubscriptions(ctx context.Context, params GetAccountEventsSubscriptionsParams) (*GetAccountEventsSubscriptionsOK, error) {
+	res, err := c.sendGetAccountEventsSubscriptions(ctx, params)
 	_ = res
-	return err
+	return res, err
 }
 
-func (c *Client) sendSubscribeToBridgeEvents(ctx context.Context, request *SubscribeToBridgeEventsReq) (res *SubscribeToBridgeEventsOK, err error) {
+func (c *Client) sendGetAccountEventsSubscriptions(ctx context.Context, params GetAccountEventsSubscriptionsParams) (res *GetAccountEventsSubscriptionsOK, err error) {
 	otelAttrs := []attribute.KeyValue{
-		otelogen.OperationID("subscribeToBridgeEvents"),
-		semconv.HTTPMethodKey.String("POST"),
-		semconv.HTTPRouteKey.String("/bridge/subscribe"),
+		otelogen.OperationID("getAccountEventsSubscriptions"),
+		semconv.HTTPMethodKey.String("GET"),
+		semconv.HTTPRouteKey.String("/account-events/subscriptions"),
 	}
 
 	// Run stopwatch.
@@ -468,7 +600,7 @@ func (c *Client) sendSubscribeToBridgeEvents(ctx context.Context, request *Subsc
 	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
 
 	// Start a span for this request.
-	ctx, span := c.cfg.Tracer.Start(ctx, "SubscribeToBridgeEvents",
+	ctx, span := c.cfg.Tracer.Start(ctx, "GetAccountEventsSubscriptions",
 		trace.WithAttributes(otelAttrs...),
 		clientSpanKind,
 	)
@@ -486,16 +618,64 @@ func (c *Client) sendSubscribeToBridgeEvents(ctx context.Context, request *Subsc
 	stage = "BuildURL"
 	u := uri.Clone(c.requestURL(ctx))
 	var pathParts [1]string
-	pathParts[0] = "/bridge/subscribe"
+	pathParts[0] = "/account-events/subscriptions"
 	uri.AddPathParts(u, pathParts[:]...)
 
 	stage = "EncodeRequest"
-	r, err := ht.NewRequest(ctx, "POST", u)
+	r, err := ht.NewRequest(ctx, "GET", u)
 	if err != nil {
 		return res, errors.Wrap(err, "create request")
 	}
-	if err := encodeSubscribeToBridgeEventsRequest(request, r); err != nil {
-		return res, errors.Wrap(err, "encode request")
+
+	stage = "EncodeHeaderParams"
+	h := uri.NewHeaderEncoder(r.Header)
+	{
+		cfg := uri.HeaderParameterEncodingConfig{
+			Name:    "X-Twa-Init-Data",
+			Explode: false,
+		}
+		if err := h.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.XTwaInitData.Get(); ok {
+				return e.EncodeValue(conv.StringToString(val))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode header")
+		}
+	}
+
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+			stage = "Security:BearerAuth"
+			switch err := c.securityBearerAuth(ctx, "GetAccountEventsSubscriptions", r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"BearerAuth\"")
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{0b00000001},
+				{},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
 	}
 
 	stage = "SendRequest"
@@ -506,7 +686,7 @@ func (c *Client) sendSubscribeToBridgeEvents(ctx context.Context, request *Subsc
 	defer resp.Body.Close()
 
 	stage = "DecodeResponse"
-	result, err := decodeSubscribeToBridgeEventsResponse(resp)
+	result, err := decodeGetAccountEventsSubscriptionsResponse(resp)
 	if err != nil {
 		return res, errors.Wrap(err, "decode response")
 	}
@@ -514,22 +694,23 @@ func (c *Client) sendSubscribeToBridgeEvents(ctx context.Context, request *Subsc
 	return result, nil
 }
 
-// UnsubscribeFromAccountEvents invokes unsubscribeFromAccountEvents operation.
+// GetBridgeSubscriptions invokes getBridgeSubscriptions operation.
 //
-// Unsubscribe from notifications about events in the TON blockchain for a specific address.
+// Get dApps a user gets bridge notifications from. Without a session token, twa init data is passed
+// in a header, so it doesn't end up in URLs.
 //
-// POST /account-events/unsubscribe
-func (c *Client) UnsubscribeFromAccountEvents(ctx context.Context, request *UnsubscribeFromAccountEventsReq) error {
-	res, err := c.sendUnsubscribeFromAccountEvents(ctx, request)
+// GET /bridge/subscriptions
+func (c *Client) GetBridgeSubscriptions(ctx context.Context, params GetBridgeSubscriptionsParams) (*GetBridgeSubscriptionsOK, error) {
+	res, err := c.sendGetBridgeSubscriptions(ctx, params)
 	_ = res
-	return err
+	return res, err
 }
 
-func (c *Client) sendUnsubscribeFromAccountEvents(ctx context.Context, request *UnsubscribeFromAccountEventsReq) (res *UnsubscribeFromAccountEventsOK, err error) {
+func (c *Client) sendGetBridgeSubscriptions(ctx context.Context, params GetBridgeSubscriptionsParams) (res *GetBridgeSubscriptionsOK, err error) {
 	otelAttrs := []attribute.KeyValue{
-		otelogen.OperationID("unsubscribeFromAccountEvents"),
-		semconv.HTTPMethodKey.String("POST"),
-		semconv.HTTPRouteKey.String("/account-events/unsubscribe"),
+		otelogen.OperationID("getBridgeSubscriptions"),
+		semconv.HTTPMethodKey.String("GET"),
+		semconv.HTTPRouteKey.String("/bridge/subscriptions"),
 	}
 
 	// Run stopwatch.
@@ -544,7 +725,7 @@ func (c *Client) sendUnsubscribeFromAccountEvents(ctx context.Context, request *
 	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
 
 	// Start a span for this request.
-	ctx, span := c.cfg.Tracer.Start(ctx, "UnsubscribeFromAccountEvents",
+	ctx, span := c.cfg.Tracer.Start(ctx, "GetBridgeSubscriptions",
 		trace.WithAttributes(otelAttrs...),
 		clientSpanKind,
 	)
@@ -562,16 +743,64 @@ func (c *Client) sendUnsubscribeFromAccountEvents(ctx context.Context, request *
 	stage = "BuildURL"
 	u := uri.Clone(c.requestURL(ctx))
 	var pathParts [1]string
-	pathParts[0] = "/account-events/unsubscribe"
+	pathParts[0] = "/bridge/subscriptions"
 	uri.AddPathParts(u, pathParts[:]...)
 
 	stage = "EncodeRequest"
-	r, err := ht.NewRequest(ctx, "POST", u)
+	r, err := ht.NewRequest(ctx, "GET", u)
 	if err != nil {
 		return res, errors.Wrap(err, "create request")
 	}
-	if err := encodeUnsubscribeFromAccountEventsRequest(request, r); err != nil {
-		return res, errors.Wrap(err, "encode request")
+
+	stage = "EncodeHeaderParams"
+	h := uri.NewHeaderEncoder(r.Header)
+	{
+		cfg := uri.HeaderParameterEncodingConfig{
+			Name:    "X-Twa-Init-Data",
+			Explode: false,
+		}
+		if err := h.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.XTwaInitData.Get(); ok {
+				return e.EncodeValue(conv.StringToString(val))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode header")
+		}
+	}
+
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+			stage = "Security:BearerAuth"
+			switch err := c.securityBearerAuth(ctx, "GetBridgeSubscriptions", r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"BearerAuth\"")
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{0b00000001},
+				{},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
 	}
 
 	stage = "SendRequest"
@@ -582,7 +811,7 @@ func (c *Client) sendUnsubscribeFromAccountEvents(ctx context.Context, request *
 	defer resp.Body.Close()
 
 	stage = "DecodeResponse"
-	result, err := decodeUnsubscribeFromAccountEventsResponse(resp)
+	result, err := decodeGetBridgeSubscriptionsResponse(resp)
 	if err != nil {
 		return res, errors.Wrap(err, "decode response")
 	}
@@ -590,22 +819,22 @@ func (c *Client) sendUnsubscribeFromAccountEvents(ctx context.Context, request *
 	return result, nil
 }
 
-// UnsubscribeFromBridgeEvents invokes unsubscribeFromBridgeEvents operation.
+// GetNotificationSettings invokes getNotificationSettings operation.
 //
-// Unsubscribe from bridge notifications.
+// Get notification settings of a user.
 //
-// POST /bridge/unsubscribe
-func (c *Client) UnsubscribeFromBridgeEvents(ctx context.Context, request *UnsubscribeFromBridgeEventsReq) error {
-	res, err := c.sendUnsubscribeFromBridgeEvents(ctx, request)
+// POST /notification-settings
+func (c *Client) GetNotificationSettings(ctx context.Context, request *GetNotificationSettingsReq) (*NotificationSettings, error) {
+	res, err := c.sendGetNotificationSettings(ctx, request)
 	_ = res
-	return err
+	return res, err
 }
 
-func (c *Client) sendUnsubscribeFromBridgeEvents(ctx context.Context, request *UnsubscribeFromBridgeEventsReq) (res *UnsubscribeFromBridgeEventsOK, err error) {
+func (c *Client) sendGetNotificationSettings(ctx context.Context, request *GetNotificationSettingsReq) (res *NotificationSettings, err error) {
 	otelAttrs := []attribute.KeyValue{
-		otelogen.OperationID("unsubscribeFromBridgeEvents"),
+		otelogen.OperationID("getNotificationSettings"),
 		semconv.HTTPMethodKey.String("POST"),
-		semconv.HTTPRouteKey.String("/bridge/unsubscribe"),
+		semconv.HTTPRouteKey.String("/notification-settings"),
 	}
 
 	// Run stopwatch.
@@ -620,7 +849,7 @@ func (c *Client) sendUnsubscribeFromBridgeEvents(ctx context.Context, request *U
 	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
 
 	// Start a span for this request.
-	ctx, span := c.cfg.Tracer.Start(ctx, "UnsubscribeFromBridgeEvents",
+	ctx, span := c.cfg.Tracer.Start(ctx, "GetNotificationSettings",
 		trace.WithAttributes(otelAttrs...),
 		clientSpanKind,
 	)
@@ -638,7 +867,7 @@ func (c *Client) sendUnsubscribeFromBridgeEvents(ctx context.Context, request *U
 	stage = "BuildURL"
 	u := uri.Clone(c.requestURL(ctx))
 	var pathParts [1]string
-	pathParts[0] = "/bridge/unsubscribe"
+	pathParts[0] = "/notification-settings"
 	uri.AddPathParts(u, pathParts[:]...)
 
 	stage = "EncodeRequest"
@@ -646,10 +875,44 @@ func (c *Client) sendUnsubscribeFromBridgeEvents(ctx context.Context, request *U
 	if err != nil {
 		return res, errors.Wrap(err, "create request")
 	}
-	if err := encodeUnsubscribeFromBridgeEventsRequest(request, r); err != nil {
+	if err := encodeGetNotificationSettingsRequest(request, r); err != nil {
 		return res, errors.Wrap(err, "encode request")
 	}
 
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+			stage = "Security:BearerAuth"
+			switch err := c.securityBearerAuth(ctx, "GetNotificationSettings", r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"BearerAuth\"")
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{0b00000001},
+				{},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
+	}
+
 	stage = "SendRequest"
 	resp, err := c.cfg.Client.Do(r)
 	if err != nil {
@@ -658,7 +921,829 @@ func (c *Client) sendUnsubscribeFromBridgeEvents(ctx context.Context, request *U
 	defer resp.Body.Close()
 
 	stage = "DecodeResponse"
-	result, err := decodeUnsubscribeFromBridgeEventsResponse(resp)
+	result, err := decodeGetNotificationSettingsResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// GetTonConnectPayload invokes getTonConnectPayload operation.
+//
+// Get a challenge for TON Connect.
+//
+// GET /tonconnect/payload
+func (c *Client) GetTonConnectPayload(ctx context.Context) (*GetTonConnectPayloadOK, error) {
+	res, err := c.sendGetTonConnectPayload(ctx)
+	_ = res
+	return res, err
+}
+
+func (c *Client) sendGetTonConnectPayload(ctx context.Context) (res *GetTonConnectPayloadOK, err error) {
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("getTonConnectPayload"),
+		semconv.HTTPMethodKey.String("GET"),
+		semconv.HTTPRouteKey.String("/tonconnect/payload"),
+	}
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(float64(elapsedDuration)/float64(time.Millisecond)), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, "GetTonConnectPayload",
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/tonconnect/payload"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "GET", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeGetTonConnectPayloadResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// RefreshSession invokes refreshSession operation.
+//
+// Exchange a refresh token for a new session before the refresh token expires. A refresh token can
+// be exchanged only once, the new session expires when the refreshed one does.
+//
+// POST /auth/refresh
+func (c *Client) RefreshSession(ctx context.Context, request *RefreshSessionReq) (*Session, error) {
+	res, err := c.sendRefreshSession(ctx, request)
+	_ = res
+	return res, err
+}
+
+func (c *Client) sendRefreshSession(ctx context.Context, request *RefreshSessionReq) (res *Session, err error) {
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("refreshSession"),
+		semconv.HTTPMethodKey.String("POST"),
+		semconv.HTTPRouteKey.String("/auth/refresh"),
+	}
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(float64(elapsedDuration)/float64(time.Millisecond)), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, "RefreshSession",
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/auth/refresh"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "POST", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+	if err := encodeRefreshSessionRequest(request, r); err != nil {
+		return res, errors.Wrap(err, "encode request")
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeRefreshSessionResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// SubscribeToAccountEvents invokes subscribeToAccountEvents operation.
+//
+// Subscribe to notifications about events in the TON blockchain for a specific address.
+//
+// POST /account-events/subscribe
+func (c *Client) SubscribeToAccountEvents(ctx context.Context, request *SubscribeToAccountEventsReq) error {
+	res, err := c.sendSubscribeToAccountEvents(ctx, request)
+	_ = res
+	return err
+}
+
+func (c *Client) sendSubscribeToAccountEvents(ctx context.Context, request *SubscribeToAccountEventsReq) (res *SubscribeToAccountEventsOK, err error) {
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("subscribeToAccountEvents"),
+		semconv.HTTPMethodKey.String("POST"),
+		semconv.HTTPRouteKey.String("/account-events/subscribe"),
+	}
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(float64(elapsedDuration)/float64(time.Millisecond)), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, "SubscribeToAccountEvents",
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/account-events/subscribe"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "POST", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+	if err := encodeSubscribeToAccountEventsRequest(request, r); err != nil {
+		return res, errors.Wrap(err, "encode request")
+	}
+
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+			stage = "Security:BearerAuth"
+			switch err := c.securityBearerAuth(ctx, "SubscribeToAccountEvents", r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"BearerAuth\"")
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{0b00000001},
+				{},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeSubscribeToAccountEventsResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// SubscribeToBridgeEvents invokes subscribeToBridgeEvents operation.
+//
+// Subscribe to notifications from the HTTP Bridge regarding a specific smart contract or wallet.
+//
+// POST /bridge/subscribe
+func (c *Client) SubscribeToBridgeEvents(ctx context.Context, request *SubscribeToBridgeEventsReq) error {
+	res, err := c.sendSubscribeToBridgeEvents(ctx, request)
+	_ = res
+	return err
+}
+
+func (c *Client) sendSubscribeToBridgeEvents(ctx context.Context, request *SubscribeToBridgeEventsReq) (res *SubscribeToBridgeEventsOK, err error) {
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("subscribeToBridgeEvents"),
+		semconv.HTTPMethodKey.String("POST"),
+		semconv.HTTPRouteKey.String("/bridge/subscribe"),
+	}
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(float64(elapsedDuration)/float64(time.Millisecond)), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, "SubscribeToBridgeEvents",
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/bridge/subscribe"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "POST", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+	if err := encodeSubscribeToBridgeEventsRequest(request, r); err != nil {
+		return res, errors.Wrap(err, "encode request")
+	}
+
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+			stage = "Security:BearerAuth"
+			switch err := c.securityBearerAuth(ctx, "SubscribeToBridgeEvents", r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"BearerAuth\"")
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{0b00000001},
+				{},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeSubscribeToBridgeEventsResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// UnsubscribeFromAccountEvents invokes unsubscribeFromAccountEvents operation.
+//
+// Unsubscribe from notifications about events in the TON blockchain for a specific address or, if no
+// address is given, for all addresses. The same twa_init_data is accepted only once, a session token
+// can be used until it expires.
+//
+// POST /account-events/unsubscribe
+func (c *Client) UnsubscribeFromAccountEvents(ctx context.Context, request *UnsubscribeFromAccountEventsReq) error {
+	res, err := c.sendUnsubscribeFromAccountEvents(ctx, request)
+	_ = res
+	return err
+}
+
+func (c *Client) sendUnsubscribeFromAccountEvents(ctx context.Context, request *UnsubscribeFromAccountEventsReq) (res *UnsubscribeFromAccountEventsOK, err error) {
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("unsubscribeFromAccountEvents"),
+		semconv.HTTPMethodKey.String("POST"),
+		semconv.HTTPRouteKey.String("/account-events/unsubscribe"),
+	}
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(float64(elapsedDuration)/float64(time.Millisecond)), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, "UnsubscribeFromAccountEvents",
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/account-events/unsubscribe"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "POST", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+	if err := encodeUnsubscribeFromAccountEventsRequest(request, r); err != nil {
+		return res, errors.Wrap(err, "encode request")
+	}
+
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+			stage = "Security:BearerAuth"
+			switch err := c.securityBearerAuth(ctx, "UnsubscribeFromAccountEvents", r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"BearerAuth\"")
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{0b00000001},
+				{},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeUnsubscribeFromAccountEventsResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// UnsubscribeFromBridgeEvents invokes unsubscribeFromBridgeEvents operation.
+//
+// Unsubscribe from bridge notifications. The same twa_init_data is accepted only once, a session
+// token can be used until it expires.
+//
+// POST /bridge/unsubscribe
+func (c *Client) UnsubscribeFromBridgeEvents(ctx context.Context, request *UnsubscribeFromBridgeEventsReq) error {
+	res, err := c.sendUnsubscribeFromBridgeEvents(ctx, request)
+	_ = res
+	return err
+}
+
+func (c *Client) sendUnsubscribeFromBridgeEvents(ctx context.Context, request *UnsubscribeFromBridgeEventsReq) (res *UnsubscribeFromBridgeEventsOK, err error) {
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("unsubscribeFromBridgeEvents"),
+		semconv.HTTPMethodKey.String("POST"),
+		semconv.HTTPRouteKey.String("/bridge/unsubscribe"),
+	}
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(float64(elapsedDuration)/float64(time.Millisecond)), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, "UnsubscribeFromBridgeEvents",
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/bridge/unsubscribe"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "POST", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+	if err := encodeUnsubscribeFromBridgeEventsRequest(request, r); err != nil {
+		return res, errors.Wrap(err, "encode request")
+	}
+
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+			stage = "Security:BearerAuth"
+			switch err := c.securityBearerAuth(ctx, "UnsubscribeFromBridgeEvents", r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"BearerAuth\"")
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{0b00000001},
+				{},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeUnsubscribeFromBridgeEventsResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// UpdateAccountEventsFilters invokes updateAccountEventsFilters operation.
+//
+// Replace filters deciding which events of an account a user is notified about.
+//
+// POST /account-events/filters/update
+func (c *Client) UpdateAccountEventsFilters(ctx context.Context, request *UpdateAccountEventsFiltersReq) error {
+	res, err := c.sendUpdateAccountEventsFilters(ctx, request)
+	_ = res
+	return err
+}
+
+func (c *Client) sendUpdateAccountEventsFilters(ctx context.Context, request *UpdateAccountEventsFiltersReq) (res *UpdateAccountEventsFiltersOK, err error) {
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("updateAccountEventsFilters"),
+		semconv.HTTPMethodKey.String("POST"),
+		semconv.HTTPRouteKey.String("/account-events/filters/update"),
+	}
+	// Validate request before sending.
+	if err := func() error {
+		if err := request.Validate(); err != nil {
+			return err
+		}
+		return nil
+	}(); err != nil {
+		return res, errors.Wrap(err, "validate")
+	}
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(float64(elapsedDuration)/float64(time.Millisecond)), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, "UpdateAccountEventsFilters",
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/account-events/filters/update"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "POST", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+	if err := encodeUpdateAccountEventsFiltersRequest(request, r); err != nil {
+		return res, errors.Wrap(err, "encode request")
+	}
+
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+			stage = "Security:BearerAuth"
+			switch err := c.securityBearerAuth(ctx, "UpdateAccountEventsFilters", r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"BearerAuth\"")
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{0b00000001},
+				{},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeUpdateAccountEventsFiltersResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// UpdateNotificationSettings invokes updateNotificationSettings operation.
+//
+// Update notification settings of a user.
+//
+// POST /notification-settings/update
+func (c *Client) UpdateNotificationSettings(ctx context.Context, request *UpdateNotificationSettingsReq) error {
+	res, err := c.sendUpdateNotificationSettings(ctx, request)
+	_ = res
+	return err
+}
+
+func (c *Client) sendUpdateNotificationSettings(ctx context.Context, request *UpdateNotificationSettingsReq) (res *UpdateNotificationSettingsOK, err error) {
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("updateNotificationSettings"),
+		semconv.HTTPMethodKey.String("POST"),
+		semconv.HTTPRouteKey.String("/notification-settings/update"),
+	}
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(float64(elapsedDuration)/float64(time.Millisecond)), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, "UpdateNotificationSettings",
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/notification-settings/update"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "POST", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+	if err := encodeUpdateNotificationSettingsRequest(request, r); err != nil {
+		return res, errors.Wrap(err, "encode request")
+	}
+
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+			stage = "Security:BearerAuth"
+			switch err := c.securityBearerAuth(ctx, "UpdateNotificationSettings", r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"BearerAuth\"")
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{0b00000001},
+				{},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeUpdateNotificationSettingsResponse(resp)
 	if err != nil {
 		return res, errors.Wrap(err, "decode response")
 	}
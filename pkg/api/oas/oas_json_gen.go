@@ -12,6 +12,136 @@ import (
 	"github.com/ogen-go/ogen/validate"
 )
 
+// Encode implements json.Marshaler.
+func (s *AccountEventsSubscription) Encode(e *jx.Encoder) {
+	e.ObjStart()
+	s.encodeFields(e)
+	e.ObjEnd()
+}
+
+// encodeFields encodes fields.
+func (s *AccountEventsSubscription) encodeFields(e *jx.Encoder) {
+	{
+		e.FieldStart("address")
+		e.Str(s.Address)
+	}
+	{
+		e.FieldStart("friendly_address")
+		e.Str(s.FriendlyAddress)
+	}
+	{
+		e.FieldStart("created_at")
+		e.Int64(s.CreatedAt)
+	}
+}
+
+var jsonFieldsNameOfAccountEventsSubscription = [3]string{
+	0: "address",
+	1: "friendly_address",
+	2: "created_at",
+}
+
+// Decode decodes AccountEventsSubscription from json.
+func (s *AccountEventsSubscription) Decode(d *jx.Decoder) error {
+	if s == nil {
+		return errors.New("invalid: unable to decode AccountEventsSubscription to nil")
+	}
+	var requiredBitSet [1]uint8
+
+	if err := d.ObjBytes(func(d *jx.Decoder, k []byte) error {
+		switch string(k) {
+		case "address":
+			requiredBitSet[0] |= 1 << 0
+			if err := func() error {
+				v, err := d.Str()
+				s.Address = string(v)
+				if err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"address\"")
+			}
+		case "friendly_address":
+			requiredBitSet[0] |= 1 << 1
+			if err := func() error {
+				v, err := d.Str()
+				s.FriendlyAddress = string(v)
+				if err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"friendly_address\"")
+			}
+		case "created_at":
+			requiredBitSet[0] |= 1 << 2
+			if err := func() error {
+				v, err := d.Int64()
+				s.CreatedAt = int64(v)
+				if err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"created_at\"")
+			}
+		default:
+			return d.Skip()
+		}
+		return nil
+	}); err != nil {
+		return errors.Wrap(err, "decode AccountEventsSubscription")
+	}
+	// Validate required fields.
+	var failures []validate.FieldError
+	for i, mask := range [1]uint8{
+		0b00000111,
+	} {
+		if result := (requiredBitSet[i] & mask) ^ mask; result != 0 {
+			// Mask only required fields and check equality to mask using XOR.
+			//
+			// If XOR result is not zero, result is not equal to expected, so some fields are missed.
+			// Bits of fields which would be set are actually bits of missed fields.
+			missed := bits.OnesCount8(result)
+			for bitN := 0; bitN < missed; bitN++ {
+				bitIdx := bits.TrailingZeros8(result)
+				fieldIdx := i*8 + bitIdx
+				var name string
+				if fieldIdx < len(jsonFieldsNameOfAccountEventsSubscription) {
+					name = jsonFieldsNameOfAccountEventsSubscription[fieldIdx]
+				} else {
+					name = strconv.Itoa(fieldIdx)
+				}
+				failures = append(failures, validate.FieldError{
+					Name:  name,
+					Error: validate.ErrFieldRequired,
+				})
+				// Reset bit.
+				result &^= 1 << bitIdx
+			}
+		}
+	}
+	if len(failures) > 0 {
+		return &validate.Error{Fields: failures}
+	}
+
+	return nil
+}
+
+// MarshalJSON implements stdjson.Marshaler.
+func (s *AccountEventsSubscription) MarshalJSON() ([]byte, error) {
+	e := jx.Encoder{}
+	s.Encode(&e)
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements stdjson.Unmarshaler.
+func (s *AccountEventsSubscription) UnmarshalJSON(data []byte) error {
+	d := jx.DecodeBytes(data)
+	return s.Decode(d)
+}
+
 // Encode implements json.Marshaler.
 func (s *AccountEventsSubscriptionStatusOK) Encode(e *jx.Encoder) {
 	e.ObjStart()
@@ -118,8 +248,10 @@ func (s *AccountEventsSubscriptionStatusReq) Encode(e *jx.Encoder) {
 // encodeFields encodes fields.
 func (s *AccountEventsSubscriptionStatusReq) encodeFields(e *jx.Encoder) {
 	{
-		e.FieldStart("twa_init_data")
-		e.Str(s.TwaInitData)
+		if s.TwaInitData.Set {
+			e.FieldStart("twa_init_data")
+			s.TwaInitData.Encode(e)
+		}
 	}
 	{
 		e.FieldStart("address")
@@ -142,11 +274,9 @@ func (s *AccountEventsSubscriptionStatusReq) Decode(d *jx.Decoder) error {
 	if err := d.ObjBytes(func(d *jx.Decoder, k []byte) error {
 		switch string(k) {
 		case "twa_init_data":
-			requiredBitSet[0] |= 1 << 0
 			if err := func() error {
-				v, err := d.Str()
-				s.TwaInitData = string(v)
-				if err != nil {
+				s.TwaInitData.Reset()
+				if err := s.TwaInitData.Decode(d); err != nil {
 					return err
 				}
 				return nil
@@ -175,7 +305,7 @@ func (s *AccountEventsSubscriptionStatusReq) Decode(d *jx.Decoder) error {
 	// Validate required fields.
 	var failures []validate.FieldError
 	for i, mask := range [1]uint8{
-		0b00000011,
+		0b00000010,
 	} {
 		if result := (requiredBitSet[i] & mask) ^ mask; result != 0 {
 			// Mask only required fields and check equality to mask using XOR.
@@ -222,73 +352,90 @@ func (s *AccountEventsSubscriptionStatusReq) UnmarshalJSON(data []byte) error {
 }
 
 // Encode implements json.Marshaler.
-func (s *BridgeWebhookReq) Encode(e *jx.Encoder) {
+func (s *BridgeSubscription) Encode(e *jx.Encoder) {
 	e.ObjStart()
 	s.encodeFields(e)
 	e.ObjEnd()
 }
 
 // encodeFields encodes fields.
-func (s *BridgeWebhookReq) encodeFields(e *jx.Encoder) {
+func (s *BridgeSubscription) encodeFields(e *jx.Encoder) {
 	{
-		e.FieldStart("topic")
-		e.Str(s.Topic)
+		e.FieldStart("client_id")
+		e.Str(s.ClientID)
 	}
 	{
-		e.FieldStart("hash")
-		e.Str(s.Hash)
+		e.FieldStart("origin")
+		e.Str(s.Origin)
+	}
+	{
+		e.FieldStart("created_at")
+		e.Int64(s.CreatedAt)
 	}
 }
 
-var jsonFieldsNameOfBridgeWebhookReq = [2]string{
-	0: "topic",
-	1: "hash",
+var jsonFieldsNameOfBridgeSubscription = [3]string{
+	0: "client_id",
+	1: "origin",
+	2: "created_at",
 }
 
-// Decode decodes BridgeWebhookReq from json.
-func (s *BridgeWebhookReq) Decode(d *jx.Decoder) error {
+// Decode decodes BridgeSubscription from json.
+func (s *BridgeSubscription) Decode(d *jx.Decoder) error {
 	if s == nil {
-		return errors.New("invalid: unable to decode BridgeWebhookReq to nil")
+		return errors.New("invalid: unable to decode BridgeSubscription to nil")
 	}
 	var requiredBitSet [1]uint8
 
 	if err := d.ObjBytes(func(d *jx.Decoder, k []byte) error {
 		switch string(k) {
-		case "topic":
+		case "client_id":
 			requiredBitSet[0] |= 1 << 0
 			if err := func() error {
 				v, err := d.Str()
-				s.Topic = string(v)
+				s.ClientID = string(v)
 				if err != nil {
 					return err
 				}
 				return nil
 			}(); err != nil {
-				return errors.Wrap(err, "decode field \"topic\"")
+				return errors.Wrap(err, "decode field \"client_id\"")
 			}
-		case "hash":
+		case "origin":
 			requiredBitSet[0] |= 1 << 1
 			if err := func() error {
 				v, err := d.Str()
-				s.Hash = string(v)
+				s.Origin = string(v)
 				if err != nil {
 					return err
 				}
 				return nil
 			}(); err != nil {
-				return errors.Wrap(err, "decode field \"hash\"")
+				return errors.Wrap(err, "decode field \"origin\"")
+			}
+		case "created_at":
+			requiredBitSet[0] |= 1 << 2
+			if err := func() error {
+				v, err := d.Int64()
+				s.CreatedAt = int64(v)
+				if err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"created_at\"")
 			}
 		default:
 			return d.Skip()
 		}
 		return nil
 	}); err != nil {
-		return errors.Wrap(err, "decode BridgeWebhookReq")
+		return errors.Wrap(err, "decode BridgeSubscription")
 	}
 	// Validate required fields.
 	var failures []validate.FieldError
 	for i, mask := range [1]uint8{
-		0b00000011,
+		0b00000111,
 	} {
 		if result := (requiredBitSet[i] & mask) ^ mask; result != 0 {
 			// Mask only required fields and check equality to mask using XOR.
@@ -300,8 +447,8 @@ func (s *BridgeWebhookReq) Decode(d *jx.Decoder) error {
 				bitIdx := bits.TrailingZeros8(result)
 				fieldIdx := i*8 + bitIdx
 				var name string
-				if fieldIdx < len(jsonFieldsNameOfBridgeWebhookReq) {
-					name = jsonFieldsNameOfBridgeWebhookReq[fieldIdx]
+				if fieldIdx < len(jsonFieldsNameOfBridgeSubscription) {
+					name = jsonFieldsNameOfBridgeSubscription[fieldIdx]
 				} else {
 					name = strconv.Itoa(fieldIdx)
 				}
@@ -322,69 +469,86 @@ func (s *BridgeWebhookReq) Decode(d *jx.Decoder) error {
 }
 
 // MarshalJSON implements stdjson.Marshaler.
-func (s *BridgeWebhookReq) MarshalJSON() ([]byte, error) {
+func (s *BridgeSubscription) MarshalJSON() ([]byte, error) {
 	e := jx.Encoder{}
 	s.Encode(&e)
 	return e.Bytes(), nil
 }
 
 // UnmarshalJSON implements stdjson.Unmarshaler.
-func (s *BridgeWebhookReq) UnmarshalJSON(data []byte) error {
+func (s *BridgeSubscription) UnmarshalJSON(data []byte) error {
 	d := jx.DecodeBytes(data)
 	return s.Decode(d)
 }
 
 // Encode implements json.Marshaler.
-func (s *Error) Encode(e *jx.Encoder) {
+func (s *BridgeWebhookReq) Encode(e *jx.Encoder) {
 	e.ObjStart()
 	s.encodeFields(e)
 	e.ObjEnd()
 }
 
 // encodeFields encodes fields.
-func (s *Error) encodeFields(e *jx.Encoder) {
+func (s *BridgeWebhookReq) encodeFields(e *jx.Encoder) {
 	{
-		e.FieldStart("error")
-		e.Str(s.Error)
+		e.FieldStart("topic")
+		e.Str(s.Topic)
+	}
+	{
+		e.FieldStart("hash")
+		e.Str(s.Hash)
 	}
 }
 
-var jsonFieldsNameOfError = [1]string{
-	0: "error",
+var jsonFieldsNameOfBridgeWebhookReq = [2]string{
+	0: "topic",
+	1: "hash",
 }
 
-// Decode decodes Error from json.
-func (s *Error) Decode(d *jx.Decoder) error {
+// Decode decodes BridgeWebhookReq from json.
+func (s *BridgeWebhookReq) Decode(d *jx.Decoder) error {
 	if s == nil {
-		return errors.New("invalid: unable to decode Error to nil")
+		return errors.New("invalid: unable to decode BridgeWebhookReq to nil")
 	}
 	var requiredBitSet [1]uint8
 
 	if err := d.ObjBytes(func(d *jx.Decoder, k []byte) error {
 		switch string(k) {
-		case "error":
+		case "topic":
 			requiredBitSet[0] |= 1 << 0
 			if err := func() error {
 				v, err := d.Str()
-				s.Error = string(v)
+				s.Topic = string(v)
 				if err != nil {
 					return err
 				}
 				return nil
 			}(); err != nil {
-				return errors.Wrap(err, "decode field \"error\"")
+				return errors.Wrap(err, "decode field \"topic\"")
+			}
+		case "hash":
+			requiredBitSet[0] |= 1 << 1
+			if err := func() error {
+				v, err := d.Str()
+				s.Hash = string(v)
+				if err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"hash\"")
 			}
 		default:
 			return d.Skip()
 		}
 		return nil
 	}); err != nil {
-		return errors.Wrap(err, "decode Error")
+		return errors.Wrap(err, "decode BridgeWebhookReq")
 	}
 	// Validate required fields.
 	var failures []validate.FieldError
 	for i, mask := range [1]uint8{
-		0b00000001,
+		0b00000011,
 	} {
 		if result := (requiredBitSet[i] & mask) ^ mask; result != 0 {
 			// Mask only required fields and check equality to mask using XOR.
@@ -396,8 +560,8 @@ func (s *Error) Decode(d *jx.Decoder) error {
 				bitIdx := bits.TrailingZeros8(result)
 				fieldIdx := i*8 + bitIdx
 				var name string
-				if fieldIdx < len(jsonFieldsNameOfError) {
-					name = jsonFieldsNameOfError[fieldIdx]
+				if fieldIdx < len(jsonFieldsNameOfBridgeWebhookReq) {
+					name = jsonFieldsNameOfBridgeWebhookReq[fieldIdx]
 				} else {
 					name = strconv.Itoa(fieldIdx)
 				}
@@ -418,64 +582,64 @@ func (s *Error) Decode(d *jx.Decoder) error {
 }
 
 // MarshalJSON implements stdjson.Marshaler.
-func (s *Error) MarshalJSON() ([]byte, error) {
+func (s *BridgeWebhookReq) MarshalJSON() ([]byte, error) {
 	e := jx.Encoder{}
 	s.Encode(&e)
 	return e.Bytes(), nil
 }
 
 // UnmarshalJSON implements stdjson.Unmarshaler.
-func (s *Error) UnmarshalJSON(data []byte) error {
+func (s *BridgeWebhookReq) UnmarshalJSON(data []byte) error {
 	d := jx.DecodeBytes(data)
 	return s.Decode(d)
 }
 
 // Encode implements json.Marshaler.
-func (s *GetTonConnectPayloadOK) Encode(e *jx.Encoder) {
+func (s *CreateSessionReq) Encode(e *jx.Encoder) {
 	e.ObjStart()
 	s.encodeFields(e)
 	e.ObjEnd()
 }
 
 // encodeFields encodes fields.
-func (s *GetTonConnectPayloadOK) encodeFields(e *jx.Encoder) {
+func (s *CreateSessionReq) encodeFields(e *jx.Encoder) {
 	{
-		e.FieldStart("payload")
-		e.Str(s.Payload)
+		e.FieldStart("twa_init_data")
+		e.Str(s.TwaInitData)
 	}
 }
 
-var jsonFieldsNameOfGetTonConnectPayloadOK = [1]string{
-	0: "payload",
+var jsonFieldsNameOfCreateSessionReq = [1]string{
+	0: "twa_init_data",
 }
 
-// Decode decodes GetTonConnectPayloadOK from json.
-func (s *GetTonConnectPayloadOK) Decode(d *jx.Decoder) error {
+// Decode decodes CreateSessionReq from json.
+func (s *CreateSessionReq) Decode(d *jx.Decoder) error {
 	if s == nil {
-		return errors.New("invalid: unable to decode GetTonConnectPayloadOK to nil")
+		return errors.New("invalid: unable to decode CreateSessionReq to nil")
 	}
 	var requiredBitSet [1]uint8
 
 	if err := d.ObjBytes(func(d *jx.Decoder, k []byte) error {
 		switch string(k) {
-		case "payload":
+		case "twa_init_data":
 			requiredBitSet[0] |= 1 << 0
 			if err := func() error {
 				v, err := d.Str()
-				s.Payload = string(v)
+				s.TwaInitData = string(v)
 				if err != nil {
 					return err
 				}
 				return nil
 			}(); err != nil {
-				return errors.Wrap(err, "decode field \"payload\"")
+				return errors.Wrap(err, "decode field \"twa_init_data\"")
 			}
 		default:
 			return d.Skip()
 		}
 		return nil
 	}); err != nil {
-		return errors.Wrap(err, "decode GetTonConnectPayloadOK")
+		return errors.Wrap(err, "decode CreateSessionReq")
 	}
 	// Validate required fields.
 	var failures []validate.FieldError
@@ -492,8 +656,8 @@ func (s *GetTonConnectPayloadOK) Decode(d *jx.Decoder) error {
 				bitIdx := bits.TrailingZeros8(result)
 				fieldIdx := i*8 + bitIdx
 				var name string
-				if fieldIdx < len(jsonFieldsNameOfGetTonConnectPayloadOK) {
-					name = jsonFieldsNameOfGetTonConnectPayloadOK[fieldIdx]
+				if fieldIdx < len(jsonFieldsNameOfCreateSessionReq) {
+					name = jsonFieldsNameOfCreateSessionReq[fieldIdx]
 				} else {
 					name = strconv.Itoa(fieldIdx)
 				}
@@ -514,171 +678,1729 @@ func (s *GetTonConnectPayloadOK) Decode(d *jx.Decoder) error {
 }
 
 // MarshalJSON implements stdjson.Marshaler.
-func (s *GetTonConnectPayloadOK) MarshalJSON() ([]byte, error) {
+func (s *CreateSessionReq) MarshalJSON() ([]byte, error) {
 	e := jx.Encoder{}
 	s.Encode(&e)
 	return e.Bytes(), nil
 }
 
 // UnmarshalJSON implements stdjson.Unmarshaler.
-func (s *GetTonConnectPayloadOK) UnmarshalJSON(data []byte) error {
+func (s *CreateSessionReq) UnmarshalJSON(data []byte) error {
 	d := jx.DecodeBytes(data)
 	return s.Decode(d)
 }
 
-// Encode encodes string as json.
-func (o OptString) Encode(e *jx.Encoder) {
-	if !o.Set {
-		return
-	}
-	e.Str(string(o.Value))
+// Encode implements json.Marshaler.
+func (s *Error) Encode(e *jx.Encoder) {
+	e.ObjStart()
+	s.encodeFields(e)
+	e.ObjEnd()
 }
 
-// Decode decodes string from json.
-func (o *OptString) Decode(d *jx.Decoder) error {
-	if o == nil {
-		return errors.New("invalid: unable to decode OptString to nil")
+// encodeFields encodes fields.
+func (s *Error) encodeFields(e *jx.Encoder) {
+	{
+		e.FieldStart("error")
+		e.Str(s.Error)
+	}
+	{
+		if s.Code.Set {
+			e.FieldStart("code")
+			s.Code.Encode(e)
+		}
+	}
+}
+
+var jsonFieldsNameOfError = [2]string{
+	0: "error",
+	1: "code",
+}
+
+// Decode decodes Error from json.
+func (s *Error) Decode(d *jx.Decoder) error {
+	if s == nil {
+		return errors.New("invalid: unable to decode Error to nil")
+	}
+	var requiredBitSet [1]uint8
+
+	if err := d.ObjBytes(func(d *jx.Decoder, k []byte) error {
+		switch string(k) {
+		case "error":
+			requiredBitSet[0] |= 1 << 0
+			if err := func() error {
+				v, err := d.Str()
+				s.Error = string(v)
+				if err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"error\"")
+			}
+		case "code":
+			if err := func() error {
+				s.Code.Reset()
+				if err := s.Code.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"code\"")
+			}
+		default:
+			return d.Skip()
+		}
+		return nil
+	}); err != nil {
+		return errors.Wrap(err, "decode Error")
+	}
+	// Validate required fields.
+	var failures []validate.FieldError
+	for i, mask := range [1]uint8{
+		0b00000001,
+	} {
+		if result := (requiredBitSet[i] & mask) ^ mask; result != 0 {
+			// Mask only required fields and check equality to mask using XOR.
+			//
+			// If XOR result is not zero, result is not equal to expected, so some fields are missed.
+			// Bits of fields which would be set are actually bits of missed fields.
+			missed := bits.OnesCount8(result)
+			for bitN := 0; bitN < missed; bitN++ {
+				bitIdx := bits.TrailingZeros8(result)
+				fieldIdx := i*8 + bitIdx
+				var name string
+				if fieldIdx < len(jsonFieldsNameOfError) {
+					name = jsonFieldsNameOfError[fieldIdx]
+				} else {
+					name = strconv.Itoa(fieldIdx)
+				}
+				failures = append(failures, validate.FieldError{
+					Name:  name,
+					Error: validate.ErrFieldRequired,
+				})
+				// Reset bit.
+				result &^= 1 << bitIdx
+			}
+		}
+	}
+	if len(failures) > 0 {
+		return &validate.Error{Fields: failures}
+	}
+
+	return nil
+}
+
+// MarshalJSON implements stdjson.Marshaler.
+func (s *Error) MarshalJSON() ([]byte, error) {
+	e := jx.Encoder{}
+	s.Encode(&e)
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements stdjson.Unmarshaler.
+func (s *Error) UnmarshalJSON(data []byte) error {
+	d := jx.DecodeBytes(data)
+	return s.Decode(d)
+}
+
+// Encode implements json.Marshaler.
+func (s *GetAccountEventsFiltersReq) Encode(e *jx.Encoder) {
+	e.ObjStart()
+	s.encodeFields(e)
+	e.ObjEnd()
+}
+
+// encodeFields encodes fields.
+func (s *GetAccountEventsFiltersReq) encodeFields(e *jx.Encoder) {
+	{
+		if s.TwaInitData.Set {
+			e.FieldStart("twa_init_data")
+			s.TwaInitData.Encode(e)
+		}
+	}
+	{
+		e.FieldStart("address")
+		e.Str(s.Address)
+	}
+}
+
+var jsonFieldsNameOfGetAccountEventsFiltersReq = [2]string{
+	0: "twa_init_data",
+	1: "address",
+}
+
+// Decode decodes GetAccountEventsFiltersReq from json.
+func (s *GetAccountEventsFiltersReq) Decode(d *jx.Decoder) error {
+	if s == nil {
+		return errors.New("invalid: unable to decode GetAccountEventsFiltersReq to nil")
+	}
+	var requiredBitSet [1]uint8
+
+	if err := d.ObjBytes(func(d *jx.Decoder, k []byte) error {
+		switch string(k) {
+		case "twa_init_data":
+			if err := func() error {
+				s.TwaInitData.Reset()
+				if err := s.TwaInitData.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"twa_init_data\"")
+			}
+		case "address":
+			requiredBitSet[0] |= 1 << 1
+			if err := func() error {
+				v, err := d.Str()
+				s.Address = string(v)
+				if err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"address\"")
+			}
+		default:
+			return d.Skip()
+		}
+		return nil
+	}); err != nil {
+		return errors.Wrap(err, "decode GetAccountEventsFiltersReq")
+	}
+	// Validate required fields.
+	var failures []validate.FieldError
+	for i, mask := range [1]uint8{
+		0b00000010,
+	} {
+		if result := (requiredBitSet[i] & mask) ^ mask; result != 0 {
+			// Mask only required fields and check equality to mask using XOR.
+			//
+			// If XOR result is not zero, result is not equal to expected, so some fields are missed.
+			// Bits of fields which would be set are actually bits of missed fields.
+			missed := bits.OnesCount8(result)
+			for bitN := 0; bitN < missed; bitN++ {
+				bitIdx := bits.TrailingZeros8(result)
+				fieldIdx := i*8 + bitIdx
+				var name string
+				if fieldIdx < len(jsonFieldsNameOfGetAccountEventsFiltersReq) {
+					name = jsonFieldsNameOfGetAccountEventsFiltersReq[fieldIdx]
+				} else {
+					name = strconv.Itoa(fieldIdx)
+				}
+				failures = append(failures, validate.FieldError{
+					Name:  name,
+					Error: validate.ErrFieldRequired,
+				})
+				// Reset bit.
+				result &^= 1 << bitIdx
+			}
+		}
+	}
+	if len(failures) > 0 {
+		return &validate.Error{Fields: failures}
+	}
+
+	return nil
+}
+
+// MarshalJSON implements stdjson.Marshaler.
+func (s *GetAccountEventsFiltersReq) MarshalJSON() ([]byte, error) {
+	e := jx.Encoder{}
+	s.Encode(&e)
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements stdjson.Unmarshaler.
+func (s *GetAccountEventsFiltersReq) UnmarshalJSON(data []byte) error {
+	d := jx.DecodeBytes(data)
+	return s.Decode(d)
+}
+
+// Encode implements json.Marshaler.
+func (s *GetAccountEventsSubscriptionsOK) Encode(e *jx.Encoder) {
+	e.ObjStart()
+	s.encodeFields(e)
+	e.ObjEnd()
+}
+
+// encodeFields encodes fields.
+func (s *GetAccountEventsSubscriptionsOK) encodeFields(e *jx.Encoder) {
+	{
+		e.FieldStart("subscriptions")
+		e.ArrStart()
+		for _, elem := range s.Subscriptions {
+			elem.Encode(e)
+		}
+		e.ArrEnd()
+	}
+}
+
+var jsonFieldsNameOfGetAccountEventsSubscriptionsOK = [1]string{
+	0: "subscriptions",
+}
+
+// Decode decodes GetAccountEventsSubscriptionsOK from json.
+func (s *GetAccountEventsSubscriptionsOK) Decode(d *jx.Decoder) error {
+	if s == nil {
+		return errors.New("invalid: unable to decode GetAccountEventsSubscriptionsOK to nil")
+	}
+	var requiredBitSet [1]uint8
+
+	if err := d.ObjBytes(func(d *jx.Decoder, k []byte) error {
+		switch string(k) {
+		case "subscriptions":
+			requiredBitSet[0] |= 1 << 0
+			if err := func() error {
+				s.Subscriptions = make([]AccountEventsSubscription, 0)
+				if err := d.Arr(func(d *jx.Decoder) error {
+					var elem AccountEventsSubscription
+					if err := elem.Decode(d); err != nil {
+						return err
+					}
+					s.Subscriptions = append(s.Subscriptions, elem)
+					return nil
+				}); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"subscriptions\"")
+			}
+		default:
+			return d.Skip()
+		}
+		return nil
+	}); err != nil {
+		return errors.Wrap(err, "decode GetAccountEventsSubscriptionsOK")
+	}
+	// Validate required fields.
+	var failures []validate.FieldError
+	for i, mask := range [1]uint8{
+		0b00000001,
+	} {
+		if result := (requiredBitSet[i] & mask) ^ mask; result != 0 {
+			// Mask only required fields and check equality to mask using XOR.
+			//
+			// If XOR result is not zero, result is not equal to expected, so some fields are missed.
+			// Bits of fields which would be set are actually bits of missed fields.
+			missed := bits.OnesCount8(result)
+			for bitN := 0; bitN < missed; bitN++ {
+				bitIdx := bits.TrailingZeros8(result)
+				fieldIdx := i*8 + bitIdx
+				var name string
+				if fieldIdx < len(jsonFieldsNameOfGetAccountEventsSubscriptionsOK) {
+					name = jsonFieldsNameOfGetAccountEventsSubscriptionsOK[fieldIdx]
+				} else {
+					name = strconv.Itoa(fieldIdx)
+				}
+				failures = append(failures, validate.FieldError{
+					Name:  name,
+					Error: validate.ErrFieldRequired,
+				})
+				// Reset bit.
+				result &^= 1 << bitIdx
+			}
+		}
+	}
+	if len(failures) > 0 {
+		return &validate.Error{Fields: failures}
+	}
+
+	return nil
+}
+
+// MarshalJSON implements stdjson.Marshaler.
+func (s *GetAccountEventsSubscriptionsOK) MarshalJSON() ([]byte, error) {
+	e := jx.Encoder{}
+	s.Encode(&e)
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements stdjson.Unmarshaler.
+func (s *GetAccountEventsSubscriptionsOK) UnmarshalJSON(data []byte) error {
+	d := jx.DecodeBytes(data)
+	return s.Decode(d)
+}
+
+// Encode implements json.Marshaler.
+func (s *GetBridgeSubscriptionsOK) Encode(e *jx.Encoder) {
+	e.ObjStart()
+	s.encodeFields(e)
+	e.ObjEnd()
+}
+
+// encodeFields encodes fields.
+func (s *GetBridgeSubscriptionsOK) encodeFields(e *jx.Encoder) {
+	{
+		e.FieldStart("subscriptions")
+		e.ArrStart()
+		for _, elem := range s.Subscriptions {
+			elem.Encode(e)
+		}
+		e.ArrEnd()
+	}
+}
+
+var jsonFieldsNameOfGetBridgeSubscriptionsOK = [1]string{
+	0: "subscriptions",
+}
+
+// Decode decodes GetBridgeSubscriptionsOK from json.
+func (s *GetBridgeSubscriptionsOK) Decode(d *jx.Decoder) error {
+	if s == nil {
+		return errors.New("invalid: unable to decode GetBridgeSubscriptionsOK to nil")
+	}
+	var requiredBitSet [1]uint8
+
+	if err := d.ObjBytes(func(d *jx.Decoder, k []byte) error {
+		switch string(k) {
+		case "subscriptions":
+			requiredBitSet[0] |= 1 << 0
+			if err := func() error {
+				s.Subscriptions = make([]BridgeSubscription, 0)
+				if err := d.Arr(func(d *jx.Decoder) error {
+					var elem BridgeSubscription
+					if err := elem.Decode(d); err != nil {
+						return err
+					}
+					s.Subscriptions = append(s.Subscriptions, elem)
+					return nil
+				}); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"subscriptions\"")
+			}
+		default:
+			return d.Skip()
+		}
+		return nil
+	}); err != nil {
+		return errors.Wrap(err, "decode GetBridgeSubscriptionsOK")
+	}
+	// Validate required fields.
+	var failures []validate.FieldError
+	for i, mask := range [1]uint8{
+		0b00000001,
+	} {
+		if result := (requiredBitSet[i] & mask) ^ mask; result != 0 {
+			// Mask only required fields and check equality to mask using XOR.
+			//
+			// If XOR result is not zero, result is not equal to expected, so some fields are missed.
+			// Bits of fields which would be set are actually bits of missed fields.
+			missed := bits.OnesCount8(result)
+			for bitN := 0; bitN < missed; bitN++ {
+				bitIdx := bits.TrailingZeros8(result)
+				fieldIdx := i*8 + bitIdx
+				var name string
+				if fieldIdx < len(jsonFieldsNameOfGetBridgeSubscriptionsOK) {
+					name = jsonFieldsNameOfGetBridgeSubscriptionsOK[fieldIdx]
+				} else {
+					name = strconv.Itoa(fieldIdx)
+				}
+				failures = append(failures, validate.FieldError{
+					Name:  name,
+					Error: validate.ErrFieldRequired,
+				})
+				// Reset bit.
+				result &^= 1 << bitIdx
+			}
+		}
+	}
+	if len(failures) > 0 {
+		return &validate.Error{Fields: failures}
+	}
+
+	return nil
+}
+
+// MarshalJSON implements stdjson.Marshaler.
+func (s *GetBridgeSubscriptionsOK) MarshalJSON() ([]byte, error) {
+	e := jx.Encoder{}
+	s.Encode(&e)
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements stdjson.Unmarshaler.
+func (s *GetBridgeSubscriptionsOK) UnmarshalJSON(data []byte) error {
+	d := jx.DecodeBytes(data)
+	return s.Decode(d)
+}
+
+// Encode implements json.Marshaler.
+func (s *GetNotificationSettingsReq) Encode(e *jx.Encoder) {
+	e.ObjStart()
+	s.encodeFields(e)
+	e.ObjEnd()
+}
+
+// encodeFields encodes fields.
+func (s *GetNotificationSettingsReq) encodeFields(e *jx.Encoder) {
+	{
+		if s.TwaInitData.Set {
+			e.FieldStart("twa_init_data")
+			s.TwaInitData.Encode(e)
+		}
+	}
+}
+
+var jsonFieldsNameOfGetNotificationSettingsReq = [1]string{
+	0: "twa_init_data",
+}
+
+// Decode decodes GetNotificationSettingsReq from json.
+func (s *GetNotificationSettingsReq) Decode(d *jx.Decoder) error {
+	if s == nil {
+		return errors.New("invalid: unable to decode GetNotificationSettingsReq to nil")
+	}
+
+	if err := d.ObjBytes(func(d *jx.Decoder, k []byte) error {
+		switch string(k) {
+		case "twa_init_data":
+			if err := func() error {
+				s.TwaInitData.Reset()
+				if err := s.TwaInitData.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"twa_init_data\"")
+			}
+		default:
+			return d.Skip()
+		}
+		return nil
+	}); err != nil {
+		return errors.Wrap(err, "decode GetNotificationSettingsReq")
+	}
+
+	return nil
+}
+
+// MarshalJSON implements stdjson.Marshaler.
+func (s *GetNotificationSettingsReq) MarshalJSON() ([]byte, error) {
+	e := jx.Encoder{}
+	s.Encode(&e)
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements stdjson.Unmarshaler.
+func (s *GetNotificationSettingsReq) UnmarshalJSON(data []byte) error {
+	d := jx.DecodeBytes(data)
+	return s.Decode(d)
+}
+
+// Encode implements json.Marshaler.
+func (s *GetTonConnectPayloadOK) Encode(e *jx.Encoder) {
+	e.ObjStart()
+	s.encodeFields(e)
+	e.ObjEnd()
+}
+
+// encodeFields encodes fields.
+func (s *GetTonConnectPayloadOK) encodeFields(e *jx.Encoder) {
+	{
+		e.FieldStart("payload")
+		e.Str(s.Payload)
+	}
+}
+
+var jsonFieldsNameOfGetTonConnectPayloadOK = [1]string{
+	0: "payload",
+}
+
+// Decode decodes GetTonConnectPayloadOK from json.
+func (s *GetTonConnectPayloadOK) Decode(d *jx.Decoder) error {
+	if s == nil {
+		return errors.New("invalid: unable to decode GetTonConnectPayloadOK to nil")
+	}
+	var requiredBitSet [1]uint8
+
+	if err := d.ObjBytes(func(d *jx.Decoder, k []byte) error {
+		switch string(k) {
+		case "payload":
+			requiredBitSet[0] |= 1 << 0
+			if err := func() error {
+				v, err := d.Str()
+				s.Payload = string(v)
+				if err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"payload\"")
+			}
+		default:
+			return d.Skip()
+		}
+		return nil
+	}); err != nil {
+		return errors.Wrap(err, "decode GetTonConnectPayloadOK")
+	}
+	// Validate required fields.
+	var failures []validate.FieldError
+	for i, mask := range [1]uint8{
+		0b00000001,
+	} {
+		if result := (requiredBitSet[i] & mask) ^ mask; result != 0 {
+			// Mask only required fields and check equality to mask using XOR.
+			//
+			// If XOR result is not zero, result is not equal to expected, so some fields are missed.
+			// Bits of fields which would be set are actually bits of missed fields.
+			missed := bits.OnesCount8(result)
+			for bitN := 0; bitN < missed; bitN++ {
+				bitIdx := bits.TrailingZeros8(result)
+				fieldIdx := i*8 + bitIdx
+				var name string
+				if fieldIdx < len(jsonFieldsNameOfGetTonConnectPayloadOK) {
+					name = jsonFieldsNameOfGetTonConnectPayloadOK[fieldIdx]
+				} else {
+					name = strconv.Itoa(fieldIdx)
+				}
+				failures = append(failures, validate.FieldError{
+					Name:  name,
+					Error: validate.ErrFieldRequired,
+				})
+				// Reset bit.
+				result &^= 1 << bitIdx
+			}
+		}
+	}
+	if len(failures) > 0 {
+		return &validate.Error{Fields: failures}
+	}
+
+	return nil
+}
+
+// MarshalJSON implements stdjson.Marshaler.
+func (s *GetTonConnectPayloadOK) MarshalJSON() ([]byte, error) {
+	e := jx.Encoder{}
+	s.Encode(&e)
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements stdjson.Unmarshaler.
+func (s *GetTonConnectPayloadOK) UnmarshalJSON(data []byte) error {
+	d := jx.DecodeBytes(data)
+	return s.Decode(d)
+}
+
+// Encode implements json.Marshaler.
+func (s *NotificationSettings) Encode(e *jx.Encoder) {
+	e.ObjStart()
+	s.encodeFields(e)
+	e.ObjEnd()
+}
+
+// encodeFields encodes fields.
+func (s *NotificationSettings) encodeFields(e *jx.Encoder) {
+	{
+		if s.QuietHours.Set {
+			e.FieldStart("quiet_hours")
+			s.QuietHours.Encode(e)
+		}
+	}
+	{
+		if s.Timezone.Set {
+			e.FieldStart("timezone")
+			s.Timezone.Encode(e)
+		}
+	}
+	{
+		if s.MuteUntil.Set {
+			e.FieldStart("mute_until")
+			s.MuteUntil.Encode(e)
+		}
+	}
+}
+
+var jsonFieldsNameOfNotificationSettings = [3]string{
+	0: "quiet_hours",
+	1: "timezone",
+	2: "mute_until",
+}
+
+// Decode decodes NotificationSettings from json.
+func (s *NotificationSettings) Decode(d *jx.Decoder) error {
+	if s == nil {
+		return errors.New("invalid: unable to decode NotificationSettings to nil")
+	}
+
+	if err := d.ObjBytes(func(d *jx.Decoder, k []byte) error {
+		switch string(k) {
+		case "quiet_hours":
+			if err := func() error {
+				s.QuietHours.Reset()
+				if err := s.QuietHours.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"quiet_hours\"")
+			}
+		case "timezone":
+			if err := func() error {
+				s.Timezone.Reset()
+				if err := s.Timezone.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"timezone\"")
+			}
+		case "mute_until":
+			if err := func() error {
+				s.MuteUntil.Reset()
+				if err := s.MuteUntil.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"mute_until\"")
+			}
+		default:
+			return d.Skip()
+		}
+		return nil
+	}); err != nil {
+		return errors.Wrap(err, "decode NotificationSettings")
+	}
+
+	return nil
+}
+
+// MarshalJSON implements stdjson.Marshaler.
+func (s *NotificationSettings) MarshalJSON() ([]byte, error) {
+	e := jx.Encoder{}
+	s.Encode(&e)
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements stdjson.Unmarshaler.
+func (s *NotificationSettings) UnmarshalJSON(data []byte) error {
+	d := jx.DecodeBytes(data)
+	return s.Decode(d)
+}
+
+// Encode implements json.Marshaler.
+func (s *NotificationSettingsQuietHours) Encode(e *jx.Encoder) {
+	e.ObjStart()
+	s.encodeFields(e)
+	e.ObjEnd()
+}
+
+// encodeFields encodes fields.
+func (s *NotificationSettingsQuietHours) encodeFields(e *jx.Encoder) {
+	{
+		e.FieldStart("start")
+		e.Str(s.Start)
+	}
+	{
+		e.FieldStart("end")
+		e.Str(s.End)
+	}
+}
+
+var jsonFieldsNameOfNotificationSettingsQuietHours = [2]string{
+	0: "start",
+	1: "end",
+}
+
+// Decode decodes NotificationSettingsQuietHours from json.
+func (s *NotificationSettingsQuietHours) Decode(d *jx.Decoder) error {
+	if s == nil {
+		return errors.New("invalid: unable to decode NotificationSettingsQuietHours to nil")
+	}
+	var requiredBitSet [1]uint8
+
+	if err := d.ObjBytes(func(d *jx.Decoder, k []byte) error {
+		switch string(k) {
+		case "start":
+			requiredBitSet[0] |= 1 << 0
+			if err := func() error {
+				v, err := d.Str()
+				s.Start = string(v)
+				if err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"start\"")
+			}
+		case "end":
+			requiredBitSet[0] |= 1 << 1
+			if err := func() error {
+				v, err := d.Str()
+				s.End = string(v)
+				if err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"end\"")
+			}
+		default:
+			return d.Skip()
+		}
+		return nil
+	}); err != nil {
+		return errors.Wrap(err, "decode NotificationSettingsQuietHours")
+	}
+	// Validate required fields.
+	var failures []validate.FieldError
+	for i, mask := range [1]uint8{
+		0b00000011,
+	} {
+		if result := (requiredBitSet[i] & mask) ^ mask; result != 0 {
+			// Mask only required fields and check equality to mask using XOR.
+			//
+			// If XOR result is not zero, result is not equal to expected, so some fields are missed.
+			// Bits of fields which would be set are actually bits of missed fields.
+			missed := bits.OnesCount8(result)
+			for bitN := 0; bitN < missed; bitN++ {
+				bitIdx := bits.TrailingZeros8(result)
+				fieldIdx := i*8 + bitIdx
+				var name string
+				if fieldIdx < len(jsonFieldsNameOfNotificationSettingsQuietHours) {
+					name = jsonFieldsNameOfNotificationSettingsQuietHours[fieldIdx]
+				} else {
+					name = strconv.Itoa(fieldIdx)
+				}
+				failures = append(failures, validate.FieldError{
+					Name:  name,
+					Error: validate.ErrFieldRequired,
+				})
+				// Reset bit.
+				result &^= 1 << bitIdx
+			}
+		}
+	}
+	if len(failures) > 0 {
+		return &validate.Error{Fields: failures}
+	}
+
+	return nil
+}
+
+// MarshalJSON implements stdjson.Marshaler.
+func (s *NotificationSettingsQuietHours) MarshalJSON() ([]byte, error) {
+	e := jx.Encoder{}
+	s.Encode(&e)
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements stdjson.Unmarshaler.
+func (s *NotificationSettingsQuietHours) UnmarshalJSON(data []byte) error {
+	d := jx.DecodeBytes(data)
+	return s.Decode(d)
+}
+
+// Encode encodes bool as json.
+func (o OptBool) Encode(e *jx.Encoder) {
+	if !o.Set {
+		return
+	}
+	e.Bool(bool(o.Value))
+}
+
+// Decode decodes bool from json.
+func (o *OptBool) Decode(d *jx.Decoder) error {
+	if o == nil {
+		return errors.New("invalid: unable to decode OptBool to nil")
+	}
+	o.Set = true
+	v, err := d.Bool()
+	if err != nil {
+		return err
+	}
+	o.Value = bool(v)
+	return nil
+}
+
+// MarshalJSON implements stdjson.Marshaler.
+func (s OptBool) MarshalJSON() ([]byte, error) {
+	e := jx.Encoder{}
+	s.Encode(&e)
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements stdjson.Unmarshaler.
+func (s *OptBool) UnmarshalJSON(data []byte) error {
+	d := jx.DecodeBytes(data)
+	return s.Decode(d)
+}
+
+// Encode encodes int64 as json.
+func (o OptInt64) Encode(e *jx.Encoder) {
+	if !o.Set {
+		return
+	}
+	e.Int64(int64(o.Value))
+}
+
+// Decode decodes int64 from json.
+func (o *OptInt64) Decode(d *jx.Decoder) error {
+	if o == nil {
+		return errors.New("invalid: unable to decode OptInt64 to nil")
+	}
+	o.Set = true
+	v, err := d.Int64()
+	if err != nil {
+		return err
+	}
+	o.Value = int64(v)
+	return nil
+}
+
+// MarshalJSON implements stdjson.Marshaler.
+func (s OptInt64) MarshalJSON() ([]byte, error) {
+	e := jx.Encoder{}
+	s.Encode(&e)
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements stdjson.Unmarshaler.
+func (s *OptInt64) UnmarshalJSON(data []byte) error {
+	d := jx.DecodeBytes(data)
+	return s.Decode(d)
+}
+
+// Encode encodes NotificationSettingsQuietHours as json.
+func (o OptNotificationSettingsQuietHours) Encode(e *jx.Encoder) {
+	if !o.Set {
+		return
+	}
+	o.Value.Encode(e)
+}
+
+// Decode decodes NotificationSettingsQuietHours from json.
+func (o *OptNotificationSettingsQuietHours) Decode(d *jx.Decoder) error {
+	if o == nil {
+		return errors.New("invalid: unable to decode OptNotificationSettingsQuietHours to nil")
+	}
+	o.Set = true
+	if err := o.Value.Decode(d); err != nil {
+		return err
+	}
+	return nil
+}
+
+// MarshalJSON implements stdjson.Marshaler.
+func (s OptNotificationSettingsQuietHours) MarshalJSON() ([]byte, error) {
+	e := jx.Encoder{}
+	s.Encode(&e)
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements stdjson.Unmarshaler.
+func (s *OptNotificationSettingsQuietHours) UnmarshalJSON(data []byte) error {
+	d := jx.DecodeBytes(data)
+	return s.Decode(d)
+}
+
+// Encode encodes string as json.
+func (o OptString) Encode(e *jx.Encoder) {
+	if !o.Set {
+		return
+	}
+	e.Str(string(o.Value))
+}
+
+// Decode decodes string from json.
+func (o *OptString) Decode(d *jx.Decoder) error {
+	if o == nil {
+		return errors.New("invalid: unable to decode OptString to nil")
+	}
+	o.Set = true
+	v, err := d.Str()
+	if err != nil {
+		return err
+	}
+	o.Value = string(v)
+	return nil
+}
+
+// MarshalJSON implements stdjson.Marshaler.
+func (s OptString) MarshalJSON() ([]byte, error) {
+	e := jx.Encoder{}
+	s.Encode(&e)
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements stdjson.Unmarshaler.
+func (s *OptString) UnmarshalJSON(data []byte) error {
+	d := jx.DecodeBytes(data)
+	return s.Decode(d)
+}
+
+// Encode encodes uint32 as json.
+func (o OptUint32) Encode(e *jx.Encoder) {
+	if !o.Set {
+		return
+	}
+	e.UInt32(uint32(o.Value))
+}
+
+// Decode decodes uint32 from json.
+func (o *OptUint32) Decode(d *jx.Decoder) error {
+	if o == nil {
+		return errors.New("invalid: unable to decode OptUint32 to nil")
 	}
 	o.Set = true
-	v, err := d.Str()
+	v, err := d.UInt32()
 	if err != nil {
 		return err
 	}
-	o.Value = string(v)
+	o.Value = uint32(v)
+	return nil
+}
+
+// MarshalJSON implements stdjson.Marshaler.
+func (s OptUint32) MarshalJSON() ([]byte, error) {
+	e := jx.Encoder{}
+	s.Encode(&e)
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements stdjson.Unmarshaler.
+func (s *OptUint32) UnmarshalJSON(data []byte) error {
+	d := jx.DecodeBytes(data)
+	return s.Decode(d)
+}
+
+// Encode implements json.Marshaler.
+func (s *RefreshSessionReq) Encode(e *jx.Encoder) {
+	e.ObjStart()
+	s.encodeFields(e)
+	e.ObjEnd()
+}
+
+// encodeFields encodes fields.
+func (s *RefreshSessionReq) encodeFields(e *jx.Encoder) {
+	{
+		e.FieldStart("refresh_token")
+		e.Str(s.RefreshToken)
+	}
+}
+
+var jsonFieldsNameOfRefreshSessionReq = [1]string{
+	0: "refresh_token",
+}
+
+// Decode decodes RefreshSessionReq from json.
+func (s *RefreshSessionReq) Decode(d *jx.Decoder) error {
+	if s == nil {
+		return errors.New("invalid: unable to decode RefreshSessionReq to nil")
+	}
+	var requiredBitSet [1]uint8
+
+	if err := d.ObjBytes(func(d *jx.Decoder, k []byte) error {
+		switch string(k) {
+		case "refresh_token":
+			requiredBitSet[0] |= 1 << 0
+			if err := func() error {
+				v, err := d.Str()
+				s.RefreshToken = string(v)
+				if err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"refresh_token\"")
+			}
+		default:
+			return d.Skip()
+		}
+		return nil
+	}); err != nil {
+		return errors.Wrap(err, "decode RefreshSessionReq")
+	}
+	// Validate required fields.
+	var failures []validate.FieldError
+	for i, mask := range [1]uint8{
+		0b00000001,
+	} {
+		if result := (requiredBitSet[i] & mask) ^ mask; result != 0 {
+			// Mask only required fields and check equality to mask using XOR.
+			//
+			// If XOR result is not zero, result is not equal to expected, so some fields are missed.
+			// Bits of fields which would be set are actually bits of missed fields.
+			missed := bits.OnesCount8(result)
+			for bitN := 0; bitN < missed; bitN++ {
+				bitIdx := bits.TrailingZeros8(result)
+				fieldIdx := i*8 + bitIdx
+				var name string
+				if fieldIdx < len(jsonFieldsNameOfRefreshSessionReq) {
+					name = jsonFieldsNameOfRefreshSessionReq[fieldIdx]
+				} else {
+					name = strconv.Itoa(fieldIdx)
+				}
+				failures = append(failures, validate.FieldError{
+					Name:  name,
+					Error: validate.ErrFieldRequired,
+				})
+				// Reset bit.
+				result &^= 1 << bitIdx
+			}
+		}
+	}
+	if len(failures) > 0 {
+		return &validate.Error{Fields: failures}
+	}
+
+	return nil
+}
+
+// MarshalJSON implements stdjson.Marshaler.
+func (s *RefreshSessionReq) MarshalJSON() ([]byte, error) {
+	e := jx.Encoder{}
+	s.Encode(&e)
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements stdjson.Unmarshaler.
+func (s *RefreshSessionReq) UnmarshalJSON(data []byte) error {
+	d := jx.DecodeBytes(data)
+	return s.Decode(d)
+}
+
+// Encode implements json.Marshaler.
+func (s *Session) Encode(e *jx.Encoder) {
+	e.ObjStart()
+	s.encodeFields(e)
+	e.ObjEnd()
+}
+
+// encodeFields encodes fields.
+func (s *Session) encodeFields(e *jx.Encoder) {
+	{
+		e.FieldStart("token")
+		e.Str(s.Token)
+	}
+	{
+		e.FieldStart("expires_at")
+		e.Int64(s.ExpiresAt)
+	}
+	{
+		e.FieldStart("refresh_token")
+		e.Str(s.RefreshToken)
+	}
+	{
+		e.FieldStart("refresh_expires_at")
+		e.Int64(s.RefreshExpiresAt)
+	}
+}
+
+var jsonFieldsNameOfSession = [4]string{
+	0: "token",
+	1: "expires_at",
+	2: "refresh_token",
+	3: "refresh_expires_at",
+}
+
+// Decode decodes Session from json.
+func (s *Session) Decode(d *jx.Decoder) error {
+	if s == nil {
+		return errors.New("invalid: unable to decode Session to nil")
+	}
+	var requiredBitSet [1]uint8
+
+	if err := d.ObjBytes(func(d *jx.Decoder, k []byte) error {
+		switch string(k) {
+		case "token":
+			requiredBitSet[0] |= 1 << 0
+			if err := func() error {
+				v, err := d.Str()
+				s.Token = string(v)
+				if err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"token\"")
+			}
+		case "expires_at":
+			requiredBitSet[0] |= 1 << 1
+			if err := func() error {
+				v, err := d.Int64()
+				s.ExpiresAt = int64(v)
+				if err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"expires_at\"")
+			}
+		case "refresh_token":
+			requiredBitSet[0] |= 1 << 2
+			if err := func() error {
+				v, err := d.Str()
+				s.RefreshToken = string(v)
+				if err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"refresh_token\"")
+			}
+		case "refresh_expires_at":
+			requiredBitSet[0] |= 1 << 3
+			if err := func() error {
+				v, err := d.Int64()
+				s.RefreshExpiresAt = int64(v)
+				if err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"refresh_expires_at\"")
+			}
+		default:
+			return d.Skip()
+		}
+		return nil
+	}); err != nil {
+		return errors.Wrap(err, "decode Session")
+	}
+	// Validate required fields.
+	var failures []validate.FieldError
+	for i, mask := range [1]uint8{
+		0b00001111,
+	} {
+		if result := (requiredBitSet[i] & mask) ^ mask; result != 0 {
+			// Mask only required fields and check equality to mask using XOR.
+			//
+			// If XOR result is not zero, result is not equal to expected, so some fields are missed.
+			// Bits of fields which would be set are actually bits of missed fields.
+			missed := bits.OnesCount8(result)
+			for bitN := 0; bitN < missed; bitN++ {
+				bitIdx := bits.TrailingZeros8(result)
+				fieldIdx := i*8 + bitIdx
+				var name string
+				if fieldIdx < len(jsonFieldsNameOfSession) {
+					name = jsonFieldsNameOfSession[fieldIdx]
+				} else {
+					name = strconv.Itoa(fieldIdx)
+				}
+				failures = append(failures, validate.FieldError{
+					Name:  name,
+					Error: validate.ErrFieldRequired,
+				})
+				// Reset bit.
+				result &^= 1 << bitIdx
+			}
+		}
+	}
+	if len(failures) > 0 {
+		return &validate.Error{Fields: failures}
+	}
+
+	return nil
+}
+
+// MarshalJSON implements stdjson.Marshaler.
+func (s *Session) MarshalJSON() ([]byte, error) {
+	e := jx.Encoder{}
+	s.Encode(&e)
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements stdjson.Unmarshaler.
+func (s *Session) UnmarshalJSON(data []byte) error {
+	d := jx.DecodeBytes(data)
+	return s.Decode(d)
+}
+
+// Encode implements json.Marshaler.
+func (s *SubscribeToAccountEventsReq) Encode(e *jx.Encoder) {
+	e.ObjStart()
+	s.encodeFields(e)
+	e.ObjEnd()
+}
+
+// encodeFields encodes fields.
+func (s *SubscribeToAccountEventsReq) encodeFields(e *jx.Encoder) {
+	{
+		if s.TwaInitData.Set {
+			e.FieldStart("twa_init_data")
+			s.TwaInitData.Encode(e)
+		}
+	}
+	{
+		e.FieldStart("address")
+		e.Str(s.Address)
+	}
+	{
+		e.FieldStart("proof")
+		s.Proof.Encode(e)
+	}
+}
+
+var jsonFieldsNameOfSubscribeToAccountEventsReq = [3]string{
+	0: "twa_init_data",
+	1: "address",
+	2: "proof",
+}
+
+// Decode decodes SubscribeToAccountEventsReq from json.
+func (s *SubscribeToAccountEventsReq) Decode(d *jx.Decoder) error {
+	if s == nil {
+		return errors.New("invalid: unable to decode SubscribeToAccountEventsReq to nil")
+	}
+	var requiredBitSet [1]uint8
+
+	if err := d.ObjBytes(func(d *jx.Decoder, k []byte) error {
+		switch string(k) {
+		case "twa_init_data":
+			if err := func() error {
+				s.TwaInitData.Reset()
+				if err := s.TwaInitData.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"twa_init_data\"")
+			}
+		case "address":
+			requiredBitSet[0] |= 1 << 1
+			if err := func() error {
+				v, err := d.Str()
+				s.Address = string(v)
+				if err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"address\"")
+			}
+		case "proof":
+			requiredBitSet[0] |= 1 << 2
+			if err := func() error {
+				if err := s.Proof.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"proof\"")
+			}
+		default:
+			return d.Skip()
+		}
+		return nil
+	}); err != nil {
+		return errors.Wrap(err, "decode SubscribeToAccountEventsReq")
+	}
+	// Validate required fields.
+	var failures []validate.FieldError
+	for i, mask := range [1]uint8{
+		0b00000110,
+	} {
+		if result := (requiredBitSet[i] & mask) ^ mask; result != 0 {
+			// Mask only required fields and check equality to mask using XOR.
+			//
+			// If XOR result is not zero, result is not equal to expected, so some fields are missed.
+			// Bits of fields which would be set are actually bits of missed fields.
+			missed := bits.OnesCount8(result)
+			for bitN := 0; bitN < missed; bitN++ {
+				bitIdx := bits.TrailingZeros8(result)
+				fieldIdx := i*8 + bitIdx
+				var name string
+				if fieldIdx < len(jsonFieldsNameOfSubscribeToAccountEventsReq) {
+					name = jsonFieldsNameOfSubscribeToAccountEventsReq[fieldIdx]
+				} else {
+					name = strconv.Itoa(fieldIdx)
+				}
+				failures = append(failures, validate.FieldError{
+					Name:  name,
+					Error: validate.ErrFieldRequired,
+				})
+				// Reset bit.
+				result &^= 1 << bitIdx
+			}
+		}
+	}
+	if len(failures) > 0 {
+		return &validate.Error{Fields: failures}
+	}
+
 	return nil
 }
 
 // MarshalJSON implements stdjson.Marshaler.
-func (s OptString) MarshalJSON() ([]byte, error) {
+func (s *SubscribeToAccountEventsReq) MarshalJSON() ([]byte, error) {
+	e := jx.Encoder{}
+	s.Encode(&e)
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements stdjson.Unmarshaler.
+func (s *SubscribeToAccountEventsReq) UnmarshalJSON(data []byte) error {
+	d := jx.DecodeBytes(data)
+	return s.Decode(d)
+}
+
+// Encode implements json.Marshaler.
+func (s *SubscribeToAccountEventsReqProof) Encode(e *jx.Encoder) {
+	e.ObjStart()
+	s.encodeFields(e)
+	e.ObjEnd()
+}
+
+// encodeFields encodes fields.
+func (s *SubscribeToAccountEventsReqProof) encodeFields(e *jx.Encoder) {
+	{
+		e.FieldStart("timestamp")
+		e.Int64(s.Timestamp)
+	}
+	{
+		e.FieldStart("domain")
+		s.Domain.Encode(e)
+	}
+	{
+		e.FieldStart("signature")
+		e.Str(s.Signature)
+	}
+	{
+		e.FieldStart("payload")
+		e.Str(s.Payload)
+	}
+	{
+		if s.StateInit.Set {
+			e.FieldStart("state_init")
+			s.StateInit.Encode(e)
+		}
+	}
+}
+
+var jsonFieldsNameOfSubscribeToAccountEventsReqProof = [5]string{
+	0: "timestamp",
+	1: "domain",
+	2: "signature",
+	3: "payload",
+	4: "state_init",
+}
+
+// Decode decodes SubscribeToAccountEventsReqProof from json.
+func (s *SubscribeToAccountEventsReqProof) Decode(d *jx.Decoder) error {
+	if s == nil {
+		return errors.New("invalid: unable to decode SubscribeToAccountEventsReqProof to nil")
+	}
+	var requiredBitSet [1]uint8
+
+	if err := d.ObjBytes(func(d *jx.Decoder, k []byte) error {
+		switch string(k) {
+		case "timestamp":
+			requiredBitSet[0] |= 1 << 0
+			if err := func() error {
+				v, err := d.Int64()
+				s.Timestamp = int64(v)
+				if err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"timestamp\"")
+			}
+		case "domain":
+			requiredBitSet[0] |= 1 << 1
+			if err := func() error {
+				if err := s.Domain.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"domain\"")
+			}
+		case "signature":
+			requiredBitSet[0] |= 1 << 2
+			if err := func() error {
+				v, err := d.Str()
+				s.Signature = string(v)
+				if err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"signature\"")
+			}
+		case "payload":
+			requiredBitSet[0] |= 1 << 3
+			if err := func() error {
+				v, err := d.Str()
+				s.Payload = string(v)
+				if err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"payload\"")
+			}
+		case "state_init":
+			if err := func() error {
+				s.StateInit.Reset()
+				if err := s.StateInit.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"state_init\"")
+			}
+		default:
+			return d.Skip()
+		}
+		return nil
+	}); err != nil {
+		return errors.Wrap(err, "decode SubscribeToAccountEventsReqProof")
+	}
+	// Validate required fields.
+	var failures []validate.FieldError
+	for i, mask := range [1]uint8{
+		0b00001111,
+	} {
+		if result := (requiredBitSet[i] & mask) ^ mask; result != 0 {
+			// Mask only required fields and check equality to mask using XOR.
+			//
+			// If XOR result is not zero, result is not equal to expected, so some fields are missed.
+			// Bits of fields which would be set are actually bits of missed fields.
+			missed := bits.OnesCount8(result)
+			for bitN := 0; bitN < missed; bitN++ {
+				bitIdx := bits.TrailingZeros8(result)
+				fieldIdx := i*8 + bitIdx
+				var name string
+				if fieldIdx < len(jsonFieldsNameOfSubscribeToAccountEventsReqProof) {
+					name = jsonFieldsNameOfSubscribeToAccountEventsReqProof[fieldIdx]
+				} else {
+					name = strconv.Itoa(fieldIdx)
+				}
+				failures = append(failures, validate.FieldError{
+					Name:  name,
+					Error: validate.ErrFieldRequired,
+				})
+				// Reset bit.
+				result &^= 1 << bitIdx
+			}
+		}
+	}
+	if len(failures) > 0 {
+		return &validate.Error{Fields: failures}
+	}
+
+	return nil
+}
+
+// MarshalJSON implements stdjson.Marshaler.
+func (s *SubscribeToAccountEventsReqProof) MarshalJSON() ([]byte, error) {
 	e := jx.Encoder{}
 	s.Encode(&e)
 	return e.Bytes(), nil
 }
 
 // UnmarshalJSON implements stdjson.Unmarshaler.
-func (s *OptString) UnmarshalJSON(data []byte) error {
+func (s *SubscribeToAccountEventsReqProof) UnmarshalJSON(data []byte) error {
 	d := jx.DecodeBytes(data)
 	return s.Decode(d)
 }
 
-// Encode encodes uint32 as json.
-func (o OptUint32) Encode(e *jx.Encoder) {
-	if !o.Set {
-		return
+// Encode implements json.Marshaler.
+func (s *SubscribeToAccountEventsReqProofDomain) Encode(e *jx.Encoder) {
+	e.ObjStart()
+	s.encodeFields(e)
+	e.ObjEnd()
+}
+
+// encodeFields encodes fields.
+func (s *SubscribeToAccountEventsReqProofDomain) encodeFields(e *jx.Encoder) {
+	{
+		if s.LengthBytes.Set {
+			e.FieldStart("length_bytes")
+			s.LengthBytes.Encode(e)
+		}
+	}
+	{
+		e.FieldStart("value")
+		e.Str(s.Value)
 	}
-	e.UInt32(uint32(o.Value))
 }
 
-// Decode decodes uint32 from json.
-func (o *OptUint32) Decode(d *jx.Decoder) error {
-	if o == nil {
-		return errors.New("invalid: unable to decode OptUint32 to nil")
+var jsonFieldsNameOfSubscribeToAccountEventsReqProofDomain = [2]string{
+	0: "length_bytes",
+	1: "value",
+}
+
+// Decode decodes SubscribeToAccountEventsReqProofDomain from json.
+func (s *SubscribeToAccountEventsReqProofDomain) Decode(d *jx.Decoder) error {
+	if s == nil {
+		return errors.New("invalid: unable to decode SubscribeToAccountEventsReqProofDomain to nil")
 	}
-	o.Set = true
-	v, err := d.UInt32()
-	if err != nil {
-		return err
+	var requiredBitSet [1]uint8
+
+	if err := d.ObjBytes(func(d *jx.Decoder, k []byte) error {
+		switch string(k) {
+		case "length_bytes":
+			if err := func() error {
+				s.LengthBytes.Reset()
+				if err := s.LengthBytes.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"length_bytes\"")
+			}
+		case "value":
+			requiredBitSet[0] |= 1 << 1
+			if err := func() error {
+				v, err := d.Str()
+				s.Value = string(v)
+				if err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"value\"")
+			}
+		default:
+			return d.Skip()
+		}
+		return nil
+	}); err != nil {
+		return errors.Wrap(err, "decode SubscribeToAccountEventsReqProofDomain")
 	}
-	o.Value = uint32(v)
+	// Validate required fields.
+	var failures []validate.FieldError
+	for i, mask := range [1]uint8{
+		0b00000010,
+	} {
+		if result := (requiredBitSet[i] & mask) ^ mask; result != 0 {
+			// Mask only required fields and check equality to mask using XOR.
+			//
+			// If XOR result is not zero, result is not equal to expected, so some fields are missed.
+			// Bits of fields which would be set are actually bits of missed fields.
+			missed := bits.OnesCount8(result)
+			for bitN := 0; bitN < missed; bitN++ {
+				bitIdx := bits.TrailingZeros8(result)
+				fieldIdx := i*8 + bitIdx
+				var name string
+				if fieldIdx < len(jsonFieldsNameOfSubscribeToAccountEventsReqProofDomain) {
+					name = jsonFieldsNameOfSubscribeToAccountEventsReqProofDomain[fieldIdx]
+				} else {
+					name = strconv.Itoa(fieldIdx)
+				}
+				failures = append(failures, validate.FieldError{
+					Name:  name,
+					Error: validate.ErrFieldRequired,
+				})
+				// Reset bit.
+				result &^= 1 << bitIdx
+			}
+		}
+	}
+	if len(failures) > 0 {
+		return &validate.Error{Fields: failures}
+	}
+
 	return nil
 }
 
 // MarshalJSON implements stdjson.Marshaler.
-func (s OptUint32) MarshalJSON() ([]byte, error) {
+func (s *SubscribeToAccountEventsReqProofDomain) MarshalJSON() ([]byte, error) {
 	e := jx.Encoder{}
 	s.Encode(&e)
 	return e.Bytes(), nil
 }
 
 // UnmarshalJSON implements stdjson.Unmarshaler.
-func (s *OptUint32) UnmarshalJSON(data []byte) error {
+func (s *SubscribeToAccountEventsReqProofDomain) UnmarshalJSON(data []byte) error {
 	d := jx.DecodeBytes(data)
 	return s.Decode(d)
 }
 
 // Encode implements json.Marshaler.
-func (s *SubscribeToAccountEventsReq) Encode(e *jx.Encoder) {
+func (s *SubscribeToBridgeEventsReq) Encode(e *jx.Encoder) {
 	e.ObjStart()
 	s.encodeFields(e)
 	e.ObjEnd()
 }
 
 // encodeFields encodes fields.
-func (s *SubscribeToAccountEventsReq) encodeFields(e *jx.Encoder) {
+func (s *SubscribeToBridgeEventsReq) encodeFields(e *jx.Encoder) {
 	{
-		e.FieldStart("twa_init_data")
-		e.Str(s.TwaInitData)
+		if s.TwaInitData.Set {
+			e.FieldStart("twa_init_data")
+			s.TwaInitData.Encode(e)
+		}
 	}
 	{
-		e.FieldStart("address")
-		e.Str(s.Address)
+		e.FieldStart("client_id")
+		e.Str(s.ClientID)
 	}
 	{
-		e.FieldStart("proof")
-		s.Proof.Encode(e)
+		e.FieldStart("origin")
+		e.Str(s.Origin)
 	}
 }
 
-var jsonFieldsNameOfSubscribeToAccountEventsReq = [3]string{
+var jsonFieldsNameOfSubscribeToBridgeEventsReq = [3]string{
 	0: "twa_init_data",
-	1: "address",
-	2: "proof",
+	1: "client_id",
+	2: "origin",
 }
 
-// Decode decodes SubscribeToAccountEventsReq from json.
-func (s *SubscribeToAccountEventsReq) Decode(d *jx.Decoder) error {
+// Decode decodes SubscribeToBridgeEventsReq from json.
+func (s *SubscribeToBridgeEventsReq) Decode(d *jx.Decoder) error {
 	if s == nil {
-		return errors.New("invalid: unable to decode SubscribeToAccountEventsReq to nil")
+		return errors.New("invalid: unable to decode SubscribeToBridgeEventsReq to nil")
 	}
 	var requiredBitSet [1]uint8
 
 	if err := d.ObjBytes(func(d *jx.Decoder, k []byte) error {
 		switch string(k) {
 		case "twa_init_data":
-			requiredBitSet[0] |= 1 << 0
 			if err := func() error {
-				v, err := d.Str()
-				s.TwaInitData = string(v)
-				if err != nil {
+				s.TwaInitData.Reset()
+				if err := s.TwaInitData.Decode(d); err != nil {
 					return err
 				}
 				return nil
 			}(); err != nil {
 				return errors.Wrap(err, "decode field \"twa_init_data\"")
 			}
-		case "address":
+		case "client_id":
 			requiredBitSet[0] |= 1 << 1
 			if err := func() error {
 				v, err := d.Str()
-				s.Address = string(v)
+				s.ClientID = string(v)
 				if err != nil {
 					return err
 				}
 				return nil
 			}(); err != nil {
-				return errors.Wrap(err, "decode field \"address\"")
+				return errors.Wrap(err, "decode field \"client_id\"")
 			}
-		case "proof":
+		case "origin":
 			requiredBitSet[0] |= 1 << 2
 			if err := func() error {
-				if err := s.Proof.Decode(d); err != nil {
+				v, err := d.Str()
+				s.Origin = string(v)
+				if err != nil {
 					return err
 				}
 				return nil
 			}(); err != nil {
-				return errors.Wrap(err, "decode field \"proof\"")
+				return errors.Wrap(err, "decode field \"origin\"")
 			}
 		default:
 			return d.Skip()
 		}
 		return nil
 	}); err != nil {
-		return errors.Wrap(err, "decode SubscribeToAccountEventsReq")
+		return errors.Wrap(err, "decode SubscribeToBridgeEventsReq")
 	}
 	// Validate required fields.
 	var failures []validate.FieldError
 	for i, mask := range [1]uint8{
-		0b00000111,
+		0b00000110,
 	} {
 		if result := (requiredBitSet[i] & mask) ^ mask; result != 0 {
 			// Mask only required fields and check equality to mask using XOR.
@@ -690,8 +2412,8 @@ func (s *SubscribeToAccountEventsReq) Decode(d *jx.Decoder) error {
 				bitIdx := bits.TrailingZeros8(result)
 				fieldIdx := i*8 + bitIdx
 				var name string
-				if fieldIdx < len(jsonFieldsNameOfSubscribeToAccountEventsReq) {
-					name = jsonFieldsNameOfSubscribeToAccountEventsReq[fieldIdx]
+				if fieldIdx < len(jsonFieldsNameOfSubscribeToBridgeEventsReq) {
+					name = jsonFieldsNameOfSubscribeToBridgeEventsReq[fieldIdx]
 				} else {
 					name = strconv.Itoa(fieldIdx)
 				}
@@ -712,337 +2434,354 @@ func (s *SubscribeToAccountEventsReq) Decode(d *jx.Decoder) error {
 }
 
 // MarshalJSON implements stdjson.Marshaler.
-func (s *SubscribeToAccountEventsReq) MarshalJSON() ([]byte, error) {
+func (s *SubscribeToBridgeEventsReq) MarshalJSON() ([]byte, error) {
 	e := jx.Encoder{}
 	s.Encode(&e)
 	return e.Bytes(), nil
 }
 
 // UnmarshalJSON implements stdjson.Unmarshaler.
-func (s *SubscribeToAccountEventsReq) UnmarshalJSON(data []byte) error {
+func (s *SubscribeToBridgeEventsReq) UnmarshalJSON(data []byte) error {
 	d := jx.DecodeBytes(data)
 	return s.Decode(d)
 }
 
 // Encode implements json.Marshaler.
-func (s *SubscribeToAccountEventsReqProof) Encode(e *jx.Encoder) {
+func (s *SubscriptionFilters) Encode(e *jx.Encoder) {
 	e.ObjStart()
 	s.encodeFields(e)
 	e.ObjEnd()
 }
 
 // encodeFields encodes fields.
-func (s *SubscribeToAccountEventsReqProof) encodeFields(e *jx.Encoder) {
+func (s *SubscriptionFilters) encodeFields(e *jx.Encoder) {
 	{
-		e.FieldStart("timestamp")
-		e.Int64(s.Timestamp)
+		if s.Actions != nil {
+			e.FieldStart("actions")
+			e.ArrStart()
+			for _, elem := range s.Actions {
+				elem.Encode(e)
+			}
+			e.ArrEnd()
+		}
 	}
 	{
-		e.FieldStart("domain")
-		s.Domain.Encode(e)
+		if s.MinTonAmount.Set {
+			e.FieldStart("min_ton_amount")
+			s.MinTonAmount.Encode(e)
+		}
 	}
 	{
-		e.FieldStart("signature")
-		e.Str(s.Signature)
+		if s.JettonAllowlist != nil {
+			e.FieldStart("jetton_allowlist")
+			e.ArrStart()
+			for _, elem := range s.JettonAllowlist {
+				e.Str(elem)
+			}
+			e.ArrEnd()
+		}
 	}
 	{
-		e.FieldStart("payload")
-		e.Str(s.Payload)
+		if s.JettonDenylist != nil {
+			e.FieldStart("jetton_denylist")
+			e.ArrStart()
+			for _, elem := range s.JettonDenylist {
+				e.Str(elem)
+			}
+			e.ArrEnd()
+		}
 	}
 	{
-		if s.StateInit.Set {
-			e.FieldStart("state_init")
-			s.StateInit.Encode(e)
+		if s.OnlyVerifiedJettons.Set {
+			e.FieldStart("only_verified_jettons")
+			s.OnlyVerifiedJettons.Encode(e)
 		}
 	}
 }
 
-var jsonFieldsNameOfSubscribeToAccountEventsReqProof = [5]string{
-	0: "timestamp",
-	1: "domain",
-	2: "signature",
-	3: "payload",
-	4: "state_init",
+var jsonFieldsNameOfSubscriptionFilters = [5]string{
+	0: "actions",
+	1: "min_ton_amount",
+	2: "jetton_allowlist",
+	3: "jetton_denylist",
+	4: "only_verified_jettons",
 }
 
-// Decode decodes SubscribeToAccountEventsReqProof from json.
-func (s *SubscribeToAccountEventsReqProof) Decode(d *jx.Decoder) error {
+// Decode decodes SubscriptionFilters from json.
+func (s *SubscriptionFilters) Decode(d *jx.Decoder) error {
 	if s == nil {
-		return errors.New("invalid: unable to decode SubscribeToAccountEventsReqProof to nil")
+		return errors.New("invalid: unable to decode SubscriptionFilters to nil")
 	}
-	var requiredBitSet [1]uint8
 
 	if err := d.ObjBytes(func(d *jx.Decoder, k []byte) error {
 		switch string(k) {
-		case "timestamp":
-			requiredBitSet[0] |= 1 << 0
+		case "actions":
 			if err := func() error {
-				v, err := d.Int64()
-				s.Timestamp = int64(v)
-				if err != nil {
+				s.Actions = make([]SubscriptionFiltersActionsItem, 0)
+				if err := d.Arr(func(d *jx.Decoder) error {
+					var elem SubscriptionFiltersActionsItem
+					if err := elem.Decode(d); err != nil {
+						return err
+					}
+					s.Actions = append(s.Actions, elem)
+					return nil
+				}); err != nil {
 					return err
 				}
 				return nil
 			}(); err != nil {
-				return errors.Wrap(err, "decode field \"timestamp\"")
+				return errors.Wrap(err, "decode field \"actions\"")
 			}
-		case "domain":
-			requiredBitSet[0] |= 1 << 1
+		case "min_ton_amount":
 			if err := func() error {
-				if err := s.Domain.Decode(d); err != nil {
+				s.MinTonAmount.Reset()
+				if err := s.MinTonAmount.Decode(d); err != nil {
 					return err
 				}
 				return nil
 			}(); err != nil {
-				return errors.Wrap(err, "decode field \"domain\"")
+				return errors.Wrap(err, "decode field \"min_ton_amount\"")
 			}
-		case "signature":
-			requiredBitSet[0] |= 1 << 2
+		case "jetton_allowlist":
 			if err := func() error {
-				v, err := d.Str()
-				s.Signature = string(v)
-				if err != nil {
+				s.JettonAllowlist = make([]string, 0)
+				if err := d.Arr(func(d *jx.Decoder) error {
+					var elem string
+					v, err := d.Str()
+					elem = string(v)
+					if err != nil {
+						return err
+					}
+					s.JettonAllowlist = append(s.JettonAllowlist, elem)
+					return nil
+				}); err != nil {
 					return err
 				}
 				return nil
 			}(); err != nil {
-				return errors.Wrap(err, "decode field \"signature\"")
+				return errors.Wrap(err, "decode field \"jetton_allowlist\"")
 			}
-		case "payload":
-			requiredBitSet[0] |= 1 << 3
+		case "jetton_denylist":
 			if err := func() error {
-				v, err := d.Str()
-				s.Payload = string(v)
-				if err != nil {
+				s.JettonDenylist = make([]string, 0)
+				if err := d.Arr(func(d *jx.Decoder) error {
+					var elem string
+					v, err := d.Str()
+					elem = string(v)
+					if err != nil {
+						return err
+					}
+					s.JettonDenylist = append(s.JettonDenylist, elem)
+					return nil
+				}); err != nil {
 					return err
 				}
 				return nil
 			}(); err != nil {
-				return errors.Wrap(err, "decode field \"payload\"")
+				return errors.Wrap(err, "decode field \"jetton_denylist\"")
 			}
-		case "state_init":
+		case "only_verified_jettons":
 			if err := func() error {
-				s.StateInit.Reset()
-				if err := s.StateInit.Decode(d); err != nil {
+				s.OnlyVerifiedJettons.Reset()
+				if err := s.OnlyVerifiedJettons.Decode(d); err != nil {
 					return err
 				}
 				return nil
 			}(); err != nil {
-				return errors.Wrap(err, "decode field \"state_init\"")
+				return errors.Wrap(err, "decode field \"only_verified_jettons\"")
 			}
 		default:
 			return d.Skip()
 		}
 		return nil
-	}); err != nil {
-		return errors.Wrap(err, "decode SubscribeToAccountEventsReqProof")
-	}
-	// Validate required fields.
-	var failures []validate.FieldError
-	for i, mask := range [1]uint8{
-		0b00001111,
-	} {
-		if result := (requiredBitSet[i] & mask) ^ mask; result != 0 {
-			// Mask only required fields and check equality to mask using XOR.
-			//
-			// If XOR result is not zero, result is not equal to expected, so some fields are missed.
-			// Bits of fields which would be set are actually bits of missed fields.
-			missed := bits.OnesCount8(result)
-			for bitN := 0; bitN < missed; bitN++ {
-				bitIdx := bits.TrailingZeros8(result)
-				fieldIdx := i*8 + bitIdx
-				var name string
-				if fieldIdx < len(jsonFieldsNameOfSubscribeToAccountEventsReqProof) {
-					name = jsonFieldsNameOfSubscribeToAccountEventsReqProof[fieldIdx]
-				} else {
-					name = strconv.Itoa(fieldIdx)
-				}
-				failures = append(failures, validate.FieldError{
-					Name:  name,
-					Error: validate.ErrFieldRequired,
-				})
-				// Reset bit.
-				result &^= 1 << bitIdx
-			}
-		}
+	}); err != nil {
+		return errors.Wrap(err, "decode SubscriptionFilters")
 	}
-	if len(failures) > 0 {
-		return &validate.Error{Fields: failures}
+
+	return nil
+}
+
+// MarshalJSON implements stdjson.Marshaler.
+func (s *SubscriptionFilters) MarshalJSON() ([]byte, error) {
+	e := jx.Encoder{}
+	s.Encode(&e)
+	return e.Bytes(), nil
+}
+
+// UnmarshalJSON implements stdjson.Unmarshaler.
+func (s *SubscriptionFilters) UnmarshalJSON(data []byte) error {
+	d := jx.DecodeBytes(data)
+	return s.Decode(d)
+}
+
+// Encode encodes SubscriptionFiltersActionsItem as json.
+func (s SubscriptionFiltersActionsItem) Encode(e *jx.Encoder) {
+	e.Str(string(s))
+}
+
+// Decode decodes SubscriptionFiltersActionsItem from json.
+func (s *SubscriptionFiltersActionsItem) Decode(d *jx.Decoder) error {
+	if s == nil {
+		return errors.New("invalid: unable to decode SubscriptionFiltersActionsItem to nil")
+	}
+	v, err := d.StrBytes()
+	if err != nil {
+		return err
+	}
+	// Try to use constant string.
+	switch SubscriptionFiltersActionsItem(v) {
+	case SubscriptionFiltersActionsItemTonTransfer:
+		*s = SubscriptionFiltersActionsItemTonTransfer
+	case SubscriptionFiltersActionsItemJettonTransfer:
+		*s = SubscriptionFiltersActionsItemJettonTransfer
+	case SubscriptionFiltersActionsItemJettonMint:
+		*s = SubscriptionFiltersActionsItemJettonMint
+	case SubscriptionFiltersActionsItemNftTransfer:
+		*s = SubscriptionFiltersActionsItemNftTransfer
+	case SubscriptionFiltersActionsItemNftPurchase:
+		*s = SubscriptionFiltersActionsItemNftPurchase
+	case SubscriptionFiltersActionsItemJettonSwap:
+		*s = SubscriptionFiltersActionsItemJettonSwap
+	default:
+		*s = SubscriptionFiltersActionsItem(v)
 	}
 
 	return nil
 }
 
 // MarshalJSON implements stdjson.Marshaler.
-func (s *SubscribeToAccountEventsReqProof) MarshalJSON() ([]byte, error) {
+func (s SubscriptionFiltersActionsItem) MarshalJSON() ([]byte, error) {
 	e := jx.Encoder{}
 	s.Encode(&e)
 	return e.Bytes(), nil
 }
 
 // UnmarshalJSON implements stdjson.Unmarshaler.
-func (s *SubscribeToAccountEventsReqProof) UnmarshalJSON(data []byte) error {
+func (s *SubscriptionFiltersActionsItem) UnmarshalJSON(data []byte) error {
 	d := jx.DecodeBytes(data)
 	return s.Decode(d)
 }
 
 // Encode implements json.Marshaler.
-func (s *SubscribeToAccountEventsReqProofDomain) Encode(e *jx.Encoder) {
+func (s *UnsubscribeFromAccountEventsReq) Encode(e *jx.Encoder) {
 	e.ObjStart()
 	s.encodeFields(e)
 	e.ObjEnd()
 }
 
 // encodeFields encodes fields.
-func (s *SubscribeToAccountEventsReqProofDomain) encodeFields(e *jx.Encoder) {
+func (s *UnsubscribeFromAccountEventsReq) encodeFields(e *jx.Encoder) {
 	{
-		if s.LengthBytes.Set {
-			e.FieldStart("length_bytes")
-			s.LengthBytes.Encode(e)
+		if s.TwaInitData.Set {
+			e.FieldStart("twa_init_data")
+			s.TwaInitData.Encode(e)
 		}
 	}
 	{
-		e.FieldStart("value")
-		e.Str(s.Value)
+		if s.Address.Set {
+			e.FieldStart("address")
+			s.Address.Encode(e)
+		}
 	}
 }
 
-var jsonFieldsNameOfSubscribeToAccountEventsReqProofDomain = [2]string{
-	0: "length_bytes",
-	1: "value",
+var jsonFieldsNameOfUnsubscribeFromAccountEventsReq = [2]string{
+	0: "twa_init_data",
+	1: "address",
 }
 
-// Decode decodes SubscribeToAccountEventsReqProofDomain from json.
-func (s *SubscribeToAccountEventsReqProofDomain) Decode(d *jx.Decoder) error {
+// Decode decodes UnsubscribeFromAccountEventsReq from json.
+func (s *UnsubscribeFromAccountEventsReq) Decode(d *jx.Decoder) error {
 	if s == nil {
-		return errors.New("invalid: unable to decode SubscribeToAccountEventsReqProofDomain to nil")
+		return errors.New("invalid: unable to decode UnsubscribeFromAccountEventsReq to nil")
 	}
-	var requiredBitSet [1]uint8
 
 	if err := d.ObjBytes(func(d *jx.Decoder, k []byte) error {
 		switch string(k) {
-		case "length_bytes":
+		case "twa_init_data":
 			if err := func() error {
-				s.LengthBytes.Reset()
-				if err := s.LengthBytes.Decode(d); err != nil {
+				s.TwaInitData.Reset()
+				if err := s.TwaInitData.Decode(d); err != nil {
 					return err
 				}
 				return nil
 			}(); err != nil {
-				return errors.Wrap(err, "decode field \"length_bytes\"")
+				return errors.Wrap(err, "decode field \"twa_init_data\"")
 			}
-		case "value":
-			requiredBitSet[0] |= 1 << 1
+		case "address":
 			if err := func() error {
-				v, err := d.Str()
-				s.Value = string(v)
-				if err != nil {
+				s.Address.Reset()
+				if err := s.Address.Decode(d); err != nil {
 					return err
 				}
 				return nil
 			}(); err != nil {
-				return errors.Wrap(err, "decode field \"value\"")
+				return errors.Wrap(err, "decode field \"address\"")
 			}
 		default:
 			return d.Skip()
 		}
 		return nil
 	}); err != nil {
-		return errors.Wrap(err, "decode SubscribeToAccountEventsReqProofDomain")
-	}
-	// Validate required fields.
-	var failures []validate.FieldError
-	for i, mask := range [1]uint8{
-		0b00000010,
-	} {
-		if result := (requiredBitSet[i] & mask) ^ mask; result != 0 {
-			// Mask only required fields and check equality to mask using XOR.
-			//
-			// If XOR result is not zero, result is not equal to expected, so some fields are missed.
-			// Bits of fields which would be set are actually bits of missed fields.
-			missed := bits.OnesCount8(result)
-			for bitN := 0; bitN < missed; bitN++ {
-				bitIdx := bits.TrailingZeros8(result)
-				fieldIdx := i*8 + bitIdx
-				var name string
-				if fieldIdx < len(jsonFieldsNameOfSubscribeToAccountEventsReqProofDomain) {
-					name = jsonFieldsNameOfSubscribeToAccountEventsReqProofDomain[fieldIdx]
-				} else {
-					name = strconv.Itoa(fieldIdx)
-				}
-				failures = append(failures, validate.FieldError{
-					Name:  name,
-					Error: validate.ErrFieldRequired,
-				})
-				// Reset bit.
-				result &^= 1 << bitIdx
-			}
-		}
-	}
-	if len(failures) > 0 {
-		return &validate.Error{Fields: failures}
+		return errors.Wrap(err, "decode UnsubscribeFromAccountEventsReq")
 	}
 
 	return nil
 }
 
 // MarshalJSON implements stdjson.Marshaler.
-func (s *SubscribeToAccountEventsReqProofDomain) MarshalJSON() ([]byte, error) {
+func (s *UnsubscribeFromAccountEventsReq) MarshalJSON() ([]byte, error) {
 	e := jx.Encoder{}
 	s.Encode(&e)
 	return e.Bytes(), nil
 }
 
 // UnmarshalJSON implements stdjson.Unmarshaler.
-func (s *SubscribeToAccountEventsReqProofDomain) UnmarshalJSON(data []byte) error {
+func (s *UnsubscribeFromAccountEventsReq) UnmarshalJSON(data []byte) error {
 	d := jx.DecodeBytes(data)
 	return s.Decode(d)
 }
 
 // Encode implements json.Marshaler.
-func (s *SubscribeToBridgeEventsReq) Encode(e *jx.Encoder) {
+func (s *UnsubscribeFromBridgeEventsReq) Encode(e *jx.Encoder) {
 	e.ObjStart()
 	s.encodeFields(e)
 	e.ObjEnd()
 }
 
 // encodeFields encodes fields.
-func (s *SubscribeToBridgeEventsReq) encodeFields(e *jx.Encoder) {
-	{
-		e.FieldStart("twa_init_data")
-		e.Str(s.TwaInitData)
-	}
+func (s *UnsubscribeFromBridgeEventsReq) encodeFields(e *jx.Encoder) {
 	{
-		e.FieldStart("client_id")
-		e.Str(s.ClientID)
+		if s.TwaInitData.Set {
+			e.FieldStart("twa_init_data")
+			s.TwaInitData.Encode(e)
+		}
 	}
 	{
-		e.FieldStart("origin")
-		e.Str(s.Origin)
+		if s.ClientID.Set {
+			e.FieldStart("client_id")
+			s.ClientID.Encode(e)
+		}
 	}
 }
 
-var jsonFieldsNameOfSubscribeToBridgeEventsReq = [3]string{
+var jsonFieldsNameOfUnsubscribeFromBridgeEventsReq = [2]string{
 	0: "twa_init_data",
 	1: "client_id",
-	2: "origin",
 }
 
-// Decode decodes SubscribeToBridgeEventsReq from json.
-func (s *SubscribeToBridgeEventsReq) Decode(d *jx.Decoder) error {
+// Decode decodes UnsubscribeFromBridgeEventsReq from json.
+func (s *UnsubscribeFromBridgeEventsReq) Decode(d *jx.Decoder) error {
 	if s == nil {
-		return errors.New("invalid: unable to decode SubscribeToBridgeEventsReq to nil")
+		return errors.New("invalid: unable to decode UnsubscribeFromBridgeEventsReq to nil")
 	}
-	var requiredBitSet [1]uint8
 
 	if err := d.ObjBytes(func(d *jx.Decoder, k []byte) error {
 		switch string(k) {
 		case "twa_init_data":
-			requiredBitSet[0] |= 1 << 0
 			if err := func() error {
-				v, err := d.Str()
-				s.TwaInitData = string(v)
-				if err != nil {
+				s.TwaInitData.Reset()
+				if err := s.TwaInitData.Decode(d); err != nil {
 					return err
 				}
 				return nil
@@ -1050,136 +2789,122 @@ func (s *SubscribeToBridgeEventsReq) Decode(d *jx.Decoder) error {
 				return errors.Wrap(err, "decode field \"twa_init_data\"")
 			}
 		case "client_id":
-			requiredBitSet[0] |= 1 << 1
 			if err := func() error {
-				v, err := d.Str()
-				s.ClientID = string(v)
-				if err != nil {
+				s.ClientID.Reset()
+				if err := s.ClientID.Decode(d); err != nil {
 					return err
 				}
 				return nil
 			}(); err != nil {
 				return errors.Wrap(err, "decode field \"client_id\"")
 			}
-		case "origin":
-			requiredBitSet[0] |= 1 << 2
-			if err := func() error {
-				v, err := d.Str()
-				s.Origin = string(v)
-				if err != nil {
-					return err
-				}
-				return nil
-			}(); err != nil {
-				return errors.Wrap(err, "decode field \"origin\"")
-			}
 		default:
 			return d.Skip()
 		}
 		return nil
 	}); err != nil {
-		return errors.Wrap(err, "decode SubscribeToBridgeEventsReq")
-	}
-	// Validate required fields.
-	var failures []validate.FieldError
-	for i, mask := range [1]uint8{
-		0b00000111,
-	} {
-		if result := (requiredBitSet[i] & mask) ^ mask; result != 0 {
-			// Mask only required fields and check equality to mask using XOR.
-			//
-			// If XOR result is not zero, result is not equal to expected, so some fields are missed.
-			// Bits of fields which would be set are actually bits of missed fields.
-			missed := bits.OnesCount8(result)
-			for bitN := 0; bitN < missed; bitN++ {
-				bitIdx := bits.TrailingZeros8(result)
-				fieldIdx := i*8 + bitIdx
-				var name string
-				if fieldIdx < len(jsonFieldsNameOfSubscribeToBridgeEventsReq) {
-					name = jsonFieldsNameOfSubscribeToBridgeEventsReq[fieldIdx]
-				} else {
-					name = strconv.Itoa(fieldIdx)
-				}
-				failures = append(failures, validate.FieldError{
-					Name:  name,
-					Error: validate.ErrFieldRequired,
-				})
-				// Reset bit.
-				result &^= 1 << bitIdx
-			}
-		}
-	}
-	if len(failures) > 0 {
-		return &validate.Error{Fields: failures}
+		return errors.Wrap(err, "decode UnsubscribeFromBridgeEventsReq")
 	}
 
 	return nil
 }
 
 // MarshalJSON implements stdjson.Marshaler.
-func (s *SubscribeToBridgeEventsReq) MarshalJSON() ([]byte, error) {
+func (s *UnsubscribeFromBridgeEventsReq) MarshalJSON() ([]byte, error) {
 	e := jx.Encoder{}
 	s.Encode(&e)
 	return e.Bytes(), nil
 }
 
 // UnmarshalJSON implements stdjson.Unmarshaler.
-func (s *SubscribeToBridgeEventsReq) UnmarshalJSON(data []byte) error {
+func (s *UnsubscribeFromBridgeEventsReq) UnmarshalJSON(data []byte) error {
 	d := jx.DecodeBytes(data)
 	return s.Decode(d)
 }
 
 // Encode implements json.Marshaler.
-func (s *UnsubscribeFromAccountEventsReq) Encode(e *jx.Encoder) {
+func (s *UpdateAccountEventsFiltersReq) Encode(e *jx.Encoder) {
 	e.ObjStart()
 	s.encodeFields(e)
 	e.ObjEnd()
 }
 
 // encodeFields encodes fields.
-func (s *UnsubscribeFromAccountEventsReq) encodeFields(e *jx.Encoder) {
+func (s *UpdateAccountEventsFiltersReq) encodeFields(e *jx.Encoder) {
 	{
-		e.FieldStart("twa_init_data")
-		e.Str(s.TwaInitData)
+		if s.TwaInitData.Set {
+			e.FieldStart("twa_init_data")
+			s.TwaInitData.Encode(e)
+		}
+	}
+	{
+		e.FieldStart("address")
+		e.Str(s.Address)
+	}
+	{
+		e.FieldStart("filters")
+		s.Filters.Encode(e)
 	}
 }
 
-var jsonFieldsNameOfUnsubscribeFromAccountEventsReq = [1]string{
+var jsonFieldsNameOfUpdateAccountEventsFiltersReq = [3]string{
 	0: "twa_init_data",
+	1: "address",
+	2: "filters",
 }
 
-// Decode decodes UnsubscribeFromAccountEventsReq from json.
-func (s *UnsubscribeFromAccountEventsReq) Decode(d *jx.Decoder) error {
+// Decode decodes UpdateAccountEventsFiltersReq from json.
+func (s *UpdateAccountEventsFiltersReq) Decode(d *jx.Decoder) error {
 	if s == nil {
-		return errors.New("invalid: unable to decode UnsubscribeFromAccountEventsReq to nil")
+		return errors.New("invalid: unable to decode UpdateAccountEventsFiltersReq to nil")
 	}
 	var requiredBitSet [1]uint8
 
 	if err := d.ObjBytes(func(d *jx.Decoder, k []byte) error {
 		switch string(k) {
 		case "twa_init_data":
-			requiredBitSet[0] |= 1 << 0
+			if err := func() error {
+				s.TwaInitData.Reset()
+				if err := s.TwaInitData.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"twa_init_data\"")
+			}
+		case "address":
+			requiredBitSet[0] |= 1 << 1
 			if err := func() error {
 				v, err := d.Str()
-				s.TwaInitData = string(v)
+				s.Address = string(v)
 				if err != nil {
 					return err
 				}
 				return nil
 			}(); err != nil {
-				return errors.Wrap(err, "decode field \"twa_init_data\"")
+				return errors.Wrap(err, "decode field \"address\"")
+			}
+		case "filters":
+			requiredBitSet[0] |= 1 << 2
+			if err := func() error {
+				if err := s.Filters.Decode(d); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return errors.Wrap(err, "decode field \"filters\"")
 			}
 		default:
 			return d.Skip()
 		}
 		return nil
 	}); err != nil {
-		return errors.Wrap(err, "decode UnsubscribeFromAccountEventsReq")
+		return errors.Wrap(err, "decode UpdateAccountEventsFiltersReq")
 	}
 	// Validate required fields.
 	var failures []validate.FieldError
 	for i, mask := range [1]uint8{
-		0b00000001,
+		0b00000110,
 	} {
 		if result := (requiredBitSet[i] & mask) ^ mask; result != 0 {
 			// Mask only required fields and check equality to mask using XOR.
@@ -1191,8 +2916,8 @@ func (s *UnsubscribeFromAccountEventsReq) Decode(d *jx.Decoder) error {
 				bitIdx := bits.TrailingZeros8(result)
 				fieldIdx := i*8 + bitIdx
 				var name string
-				if fieldIdx < len(jsonFieldsNameOfUnsubscribeFromAccountEventsReq) {
-					name = jsonFieldsNameOfUnsubscribeFromAccountEventsReq[fieldIdx]
+				if fieldIdx < len(jsonFieldsNameOfUpdateAccountEventsFiltersReq) {
+					name = jsonFieldsNameOfUpdateAccountEventsFiltersReq[fieldIdx]
 				} else {
 					name = strconv.Itoa(fieldIdx)
 				}
@@ -1213,86 +2938,84 @@ func (s *UnsubscribeFromAccountEventsReq) Decode(d *jx.Decoder) error {
 }
 
 // MarshalJSON implements stdjson.Marshaler.
-func (s *UnsubscribeFromAccountEventsReq) MarshalJSON() ([]byte, error) {
+func (s *UpdateAccountEventsFiltersReq) MarshalJSON() ([]byte, error) {
 	e := jx.Encoder{}
 	s.Encode(&e)
 	return e.Bytes(), nil
 }
 
 // UnmarshalJSON implements stdjson.Unmarshaler.
-func (s *UnsubscribeFromAccountEventsReq) UnmarshalJSON(data []byte) error {
+func (s *UpdateAccountEventsFiltersReq) UnmarshalJSON(data []byte) error {
 	d := jx.DecodeBytes(data)
 	return s.Decode(d)
 }
 
 // Encode implements json.Marshaler.
-func (s *UnsubscribeFromBridgeEventsReq) Encode(e *jx.Encoder) {
+func (s *UpdateNotificationSettingsReq) Encode(e *jx.Encoder) {
 	e.ObjStart()
 	s.encodeFields(e)
 	e.ObjEnd()
 }
 
 // encodeFields encodes fields.
-func (s *UnsubscribeFromBridgeEventsReq) encodeFields(e *jx.Encoder) {
+func (s *UpdateNotificationSettingsReq) encodeFields(e *jx.Encoder) {
 	{
-		e.FieldStart("twa_init_data")
-		e.Str(s.TwaInitData)
+		if s.TwaInitData.Set {
+			e.FieldStart("twa_init_data")
+			s.TwaInitData.Encode(e)
+		}
 	}
 	{
-		if s.ClientID.Set {
-			e.FieldStart("client_id")
-			s.ClientID.Encode(e)
-		}
+		e.FieldStart("settings")
+		s.Settings.Encode(e)
 	}
 }
 
-var jsonFieldsNameOfUnsubscribeFromBridgeEventsReq = [2]string{
+var jsonFieldsNameOfUpdateNotificationSettingsReq = [2]string{
 	0: "twa_init_data",
-	1: "client_id",
+	1: "settings",
 }
 
-// Decode decodes UnsubscribeFromBridgeEventsReq from json.
-func (s *UnsubscribeFromBridgeEventsReq) Decode(d *jx.Decoder) error {
+// Decode decodes UpdateNotificationSettingsReq from json.
+func (s *UpdateNotificationSettingsReq) Decode(d *jx.Decoder) error {
 	if s == nil {
-		return errors.New("invalid: unable to decode UnsubscribeFromBridgeEventsReq to nil")
+		return errors.New("invalid: unable to decode UpdateNotificationSettingsReq to nil")
 	}
 	var requiredBitSet [1]uint8
 
 	if err := d.ObjBytes(func(d *jx.Decoder, k []byte) error {
 		switch string(k) {
 		case "twa_init_data":
-			requiredBitSet[0] |= 1 << 0
 			if err := func() error {
-				v, err := d.Str()
-				s.TwaInitData = string(v)
-				if err != nil {
+				s.TwaInitData.Reset()
+				if err := s.TwaInitData.Decode(d); err != nil {
 					return err
 				}
 				return nil
 			}(); err != nil {
 				return errors.Wrap(err, "decode field \"twa_init_data\"")
 			}
-		case "client_id":
+		case "settings":
+			requiredBitSet[0] |= 1 << 1
 			if err := func() error {
-				s.ClientID.Reset()
-				if err := s.ClientID.Decode(d); err != nil {
+				if err := s.Settings.Decode(d); err != nil {
 					return err
 				}
 				return nil
 			}(); err != nil {
-				return errors.Wrap(err, "decode field \"client_id\"")
+				return errors.Wrap(err, "decode field \"settings\"")
 			}
 		default:
 			return d.Skip()
 		}
 		return nil
 	}); err != nil {
-		return errors.Wrap(err, "decode UnsubscribeFromBridgeEventsReq")
+		return errors.Wrap(err, "decode UpdateNotificationSettingsReq")
 	}
 	// Validate required fields.
 	var failures []validate.FieldError
 	for i, mask := range [1]uint8{
-		0b00000001,
+		0b00000010,
 	} {
 		if result := (requiredBitSet[i] & mask) ^ mask; result != 0 {
 			// Mask only required fields and check equality to mask using XOR.
@@ -1304,8 +3027,8 @@ func (s *UnsubscribeFromBridgeEventsReq) Decode(d *jx.Decoder) error {
 				bitIdx := bits.TrailingZeros8(result)
 				fieldIdx := i*8 + bitIdx
 				var name string
-				if fieldIdx < len(jsonFieldsNameOfUnsubscribeFromBridgeEventsReq) {
-					name = jsonFieldsNameOfUnsubscribeFromBridgeEventsReq[fieldIdx]
+				if fieldIdx < len(jsonFieldsNameOfUpdateNotificationSettingsReq) {
+					name = jsonFieldsNameOfUpdateNotificationSettingsReq[fieldIdx]
 				} else {
 					name = strconv.Itoa(fieldIdx)
 				}
@@ -1326,14 +3049,14 @@ func (s *UnsubscribeFromBridgeEventsReq) Decode(d *jx.Decoder) error {
 }
 
 // MarshalJSON implements stdjson.Marshaler.
-func (s *UnsubscribeFromBridgeEventsReq) MarshalJSON() ([]byte, error) {
+func (s *UpdateNotificationSettingsReq) MarshalJSON() ([]byte, error) {
 	e := jx.Encoder{}
 	s.Encode(&e)
 	return e.Bytes(), nil
 }
 
 // UnmarshalJSON implements stdjson.Unmarshaler.
-func (s *UnsubscribeFromBridgeEventsReq) UnmarshalJSON(data []byte) error {
+func (s *UpdateNotificationSettingsReq) UnmarshalJSON(data []byte) error {
 	d := jx.DecodeBytes(data)
 	return s.Decode(d)
 }
@@ -79,3 +79,129 @@ func decodeBridgeWebhookParams(args [1]string, argsEscaped bool, r *http.Request
 	}
 	return params, nil
 }
+
+// GetAccountEventsSubscriptionsParams is parameters of getAccountEventsSubscriptions operation.
+type GetAccountEventsSubscriptionsParams struct {
+	// Base64 encoded twa init data, required without a session token.
+	XTwaInitData OptString
+}
+
+func unpackGetAccountEventsSubscriptionsParams(packed middleware.Parameters) (params GetAccountEventsSubscriptionsParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "X-Twa-Init-Data",
+			In:   "header",
+		}
+		if v, ok := packed[key]; ok {
+			params.XTwaInitData = v.(OptString)
+		}
+	}
+	return params
+}
+
+func decodeGetAccountEventsSubscriptionsParams(args [0]string, argsEscaped bool, r *http.Request) (params GetAccountEventsSubscriptionsParams, _ error) {
+	h := uri.NewHeaderDecoder(r.Header)
+	// Decode header: X-Twa-Init-Data.
+	if err := func() error {
+		cfg := uri.HeaderParameterDecodingConfig{
+			Name:    "X-Twa-Init-Data",
+			Explode: false,
+		}
+		if err := h.HasParam(cfg); err == nil {
+			if err := h.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotXTwaInitDataVal string
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToString(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotXTwaInitDataVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.XTwaInitData.SetTo(paramsDotXTwaInitDataVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "X-Twa-Init-Data",
+			In:   "header",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
+// GetBridgeSubscriptionsParams is parameters of getBridgeSubscriptions operation.
+type GetBridgeSubscriptionsParams struct {
+	// Base64 encoded twa init data, required without a session token.
+	XTwaInitData OptString
+}
+
+func unpackGetBridgeSubscriptionsParams(packed middleware.Parameters) (params GetBridgeSubscriptionsParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "X-Twa-Init-Data",
+			In:   "header",
+		}
+		if v, ok := packed[key]; ok {
+			params.XTwaInitData = v.(OptString)
+		}
+	}
+	return params
+}
+
+func decodeGetBridgeSubscriptionsParams(args [0]string, argsEscaped bool, r *http.Request) (params GetBridgeSubscriptionsParams, _ error) {
+	h := uri.NewHeaderDecoder(r.Header)
+	// Decode header: X-Twa-Init-Data.
+	if err := func() error {
+		cfg := uri.HeaderParameterDecodingConfig{
+			Name:    "X-Twa-Init-Data",
+			Explode: false,
+		}
+		if err := h.HasParam(cfg); err == nil {
+			if err := h.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotXTwaInitDataVal string
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToString(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotXTwaInitDataVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.XTwaInitData.SetTo(paramsDotXTwaInitDataVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "X-Twa-Init-Data",
+			In:   "header",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
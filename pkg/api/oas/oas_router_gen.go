@@ -60,8 +60,8 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 				break
 			}
 			switch elem[0] {
-			case 'a': // Prefix: "account-events/"
-				if l := len("account-events/"); len(elem) >= l && elem[0:l] == "account-events/" {
+			case 'a': // Prefix: "a"
+				if l := len("a"); len(elem) >= l && elem[0:l] == "a" {
 					elem = elem[l:]
 				} else {
 					break
@@ -71,8 +71,8 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 					break
 				}
 				switch elem[0] {
-				case 's': // Prefix: "subscri"
-					if l := len("subscri"); len(elem) >= l && elem[0:l] == "subscri" {
+				case 'c': // Prefix: "ccount-events/"
+					if l := len("ccount-events/"); len(elem) >= l && elem[0:l] == "ccount-events/" {
 						elem = elem[l:]
 					} else {
 						break
@@ -82,26 +82,123 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 						break
 					}
 					switch elem[0] {
-					case 'b': // Prefix: "be"
-						if l := len("be"); len(elem) >= l && elem[0:l] == "be" {
+					case 'f': // Prefix: "filters"
+						if l := len("filters"); len(elem) >= l && elem[0:l] == "filters" {
 							elem = elem[l:]
 						} else {
 							break
 						}
 
 						if len(elem) == 0 {
-							// Leaf node.
 							switch r.Method {
 							case "POST":
-								s.handleSubscribeToAccountEventsRequest([0]string{}, elemIsEscaped, w, r)
+								s.handleGetAccountEventsFiltersRequest([0]string{}, elemIsEscaped, w, r)
 							default:
 								s.notAllowed(w, r, "POST")
 							}
 
 							return
 						}
-					case 'p': // Prefix: "ption-status"
-						if l := len("ption-status"); len(elem) >= l && elem[0:l] == "ption-status" {
+						switch elem[0] {
+						case '/': // Prefix: "/update"
+							if l := len("/update"); len(elem) >= l && elem[0:l] == "/update" {
+								elem = elem[l:]
+							} else {
+								break
+							}
+
+							if len(elem) == 0 {
+								// Leaf node.
+								switch r.Method {
+								case "POST":
+									s.handleUpdateAccountEventsFiltersRequest([0]string{}, elemIsEscaped, w, r)
+								default:
+									s.notAllowed(w, r, "POST")
+								}
+
+								return
+							}
+						}
+					case 's': // Prefix: "subscri"
+						if l := len("subscri"); len(elem) >= l && elem[0:l] == "subscri" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							break
+						}
+						switch elem[0] {
+						case 'b': // Prefix: "be"
+							if l := len("be"); len(elem) >= l && elem[0:l] == "be" {
+								elem = elem[l:]
+							} else {
+								break
+							}
+
+							if len(elem) == 0 {
+								// Leaf node.
+								switch r.Method {
+								case "POST":
+									s.handleSubscribeToAccountEventsRequest([0]string{}, elemIsEscaped, w, r)
+								default:
+									s.notAllowed(w, r, "POST")
+								}
+
+								return
+							}
+						case 'p': // Prefix: "ption"
+							if l := len("ption"); len(elem) >= l && elem[0:l] == "ption" {
+								elem = elem[l:]
+							} else {
+								break
+							}
+
+							if len(elem) == 0 {
+								break
+							}
+							switch elem[0] {
+							case '-': // Prefix: "-status"
+								if l := len("-status"); len(elem) >= l && elem[0:l] == "-status" {
+									elem = elem[l:]
+								} else {
+									break
+								}
+
+								if len(elem) == 0 {
+									// Leaf node.
+									switch r.Method {
+									case "POST":
+										s.handleAccountEventsSubscriptionStatusRequest([0]string{}, elemIsEscaped, w, r)
+									default:
+										s.notAllowed(w, r, "POST")
+									}
+
+									return
+								}
+							case 's': // Prefix: "s"
+								if l := len("s"); len(elem) >= l && elem[0:l] == "s" {
+									elem = elem[l:]
+								} else {
+									break
+								}
+
+								if len(elem) == 0 {
+									// Leaf node.
+									switch r.Method {
+									case "GET":
+										s.handleGetAccountEventsSubscriptionsRequest([0]string{}, elemIsEscaped, w, r)
+									default:
+										s.notAllowed(w, r, "GET")
+									}
+
+									return
+								}
+							}
+						}
+					case 'u': // Prefix: "unsubscribe"
+						if l := len("unsubscribe"); len(elem) >= l && elem[0:l] == "unsubscribe" {
 							elem = elem[l:]
 						} else {
 							break
@@ -111,7 +208,7 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 							// Leaf node.
 							switch r.Method {
 							case "POST":
-								s.handleAccountEventsSubscriptionStatusRequest([0]string{}, elemIsEscaped, w, r)
+								s.handleUnsubscribeFromAccountEventsRequest([0]string{}, elemIsEscaped, w, r)
 							default:
 								s.notAllowed(w, r, "POST")
 							}
@@ -119,23 +216,53 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 							return
 						}
 					}
-				case 'u': // Prefix: "unsubscribe"
-					if l := len("unsubscribe"); len(elem) >= l && elem[0:l] == "unsubscribe" {
+				case 'u': // Prefix: "uth/"
+					if l := len("uth/"); len(elem) >= l && elem[0:l] == "uth/" {
 						elem = elem[l:]
 					} else {
 						break
 					}
 
 					if len(elem) == 0 {
-						// Leaf node.
-						switch r.Method {
-						case "POST":
-							s.handleUnsubscribeFromAccountEventsRequest([0]string{}, elemIsEscaped, w, r)
-						default:
-							s.notAllowed(w, r, "POST")
+						break
+					}
+					switch elem[0] {
+					case 'r': // Prefix: "refresh"
+						if l := len("refresh"); len(elem) >= l && elem[0:l] == "refresh" {
+							elem = elem[l:]
+						} else {
+							break
 						}
 
-						return
+						if len(elem) == 0 {
+							// Leaf node.
+							switch r.Method {
+							case "POST":
+								s.handleRefreshSessionRequest([0]string{}, elemIsEscaped, w, r)
+							default:
+								s.notAllowed(w, r, "POST")
+							}
+
+							return
+						}
+					case 's': // Prefix: "session"
+						if l := len("session"); len(elem) >= l && elem[0:l] == "session" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							// Leaf node.
+							switch r.Method {
+							case "POST":
+								s.handleCreateSessionRequest([0]string{}, elemIsEscaped, w, r)
+							default:
+								s.notAllowed(w, r, "POST")
+							}
+
+							return
+						}
 					}
 				}
 			case 'b': // Prefix: "bridge/"
@@ -149,23 +276,53 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 					break
 				}
 				switch elem[0] {
-				case 's': // Prefix: "subscribe"
-					if l := len("subscribe"); len(elem) >= l && elem[0:l] == "subscribe" {
+				case 's': // Prefix: "subscri"
+					if l := len("subscri"); len(elem) >= l && elem[0:l] == "subscri" {
 						elem = elem[l:]
 					} else {
 						break
 					}
 
 					if len(elem) == 0 {
-						// Leaf node.
-						switch r.Method {
-						case "POST":
-							s.handleSubscribeToBridgeEventsRequest([0]string{}, elemIsEscaped, w, r)
-						default:
-							s.notAllowed(w, r, "POST")
+						break
+					}
+					switch elem[0] {
+					case 'b': // Prefix: "be"
+						if l := len("be"); len(elem) >= l && elem[0:l] == "be" {
+							elem = elem[l:]
+						} else {
+							break
 						}
 
-						return
+						if len(elem) == 0 {
+							// Leaf node.
+							switch r.Method {
+							case "POST":
+								s.handleSubscribeToBridgeEventsRequest([0]string{}, elemIsEscaped, w, r)
+							default:
+								s.notAllowed(w, r, "POST")
+							}
+
+							return
+						}
+					case 'p': // Prefix: "ptions"
+						if l := len("ptions"); len(elem) >= l && elem[0:l] == "ptions" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							// Leaf node.
+							switch r.Method {
+							case "GET":
+								s.handleGetBridgeSubscriptionsRequest([0]string{}, elemIsEscaped, w, r)
+							default:
+								s.notAllowed(w, r, "GET")
+							}
+
+							return
+						}
 					}
 				case 'u': // Prefix: "unsubscribe"
 					if l := len("unsubscribe"); len(elem) >= l && elem[0:l] == "unsubscribe" {
@@ -208,6 +365,43 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 							s.notAllowed(w, r, "POST")
 						}
 
+						return
+					}
+				}
+			case 'n': // Prefix: "notification-settings"
+				if l := len("notification-settings"); len(elem) >= l && elem[0:l] == "notification-settings" {
+					elem = elem[l:]
+				} else {
+					break
+				}
+
+				if len(elem) == 0 {
+					switch r.Method {
+					case "POST":
+						s.handleGetNotificationSettingsRequest([0]string{}, elemIsEscaped, w, r)
+					default:
+						s.notAllowed(w, r, "POST")
+					}
+
+					return
+				}
+				switch elem[0] {
+				case '/': // Prefix: "/update"
+					if l := len("/update"); len(elem) >= l && elem[0:l] == "/update" {
+						elem = elem[l:]
+					} else {
+						break
+					}
+
+					if len(elem) == 0 {
+						// Leaf node.
+						switch r.Method {
+						case "POST":
+							s.handleUpdateNotificationSettingsRequest([0]string{}, elemIsEscaped, w, r)
+						default:
+							s.notAllowed(w, r, "POST")
+						}
+
 						return
 					}
 				}
@@ -315,8 +509,8 @@ func (s *Server) FindPath(method string, u *url.URL) (r Route, _ bool) {
 				break
 			}
 			switch elem[0] {
-			case 'a': // Prefix: "account-events/"
-				if l := len("account-events/"); len(elem) >= l && elem[0:l] == "account-events/" {
+			case 'a': // Prefix: "a"
+				if l := len("a"); len(elem) >= l && elem[0:l] == "a" {
 					elem = elem[l:]
 				} else {
 					break
@@ -326,8 +520,8 @@ func (s *Server) FindPath(method string, u *url.URL) (r Route, _ bool) {
 					break
 				}
 				switch elem[0] {
-				case 's': // Prefix: "subscri"
-					if l := len("subscri"); len(elem) >= l && elem[0:l] == "subscri" {
+				case 'c': // Prefix: "ccount-events/"
+					if l := len("ccount-events/"); len(elem) >= l && elem[0:l] == "ccount-events/" {
 						elem = elem[l:]
 					} else {
 						break
@@ -337,8 +531,8 @@ func (s *Server) FindPath(method string, u *url.URL) (r Route, _ bool) {
 						break
 					}
 					switch elem[0] {
-					case 'b': // Prefix: "be"
-						if l := len("be"); len(elem) >= l && elem[0:l] == "be" {
+					case 'f': // Prefix: "filters"
+						if l := len("filters"); len(elem) >= l && elem[0:l] == "filters" {
 							elem = elem[l:]
 						} else {
 							break
@@ -347,10 +541,9 @@ func (s *Server) FindPath(method string, u *url.URL) (r Route, _ bool) {
 						if len(elem) == 0 {
 							switch method {
 							case "POST":
-								// Leaf: SubscribeToAccountEvents
-								r.name = "SubscribeToAccountEvents"
-								r.operationID = "subscribeToAccountEvents"
-								r.pathPattern = "/account-events/subscribe"
+								r.name = "GetAccountEventsFilters"
+								r.operationID = "getAccountEventsFilters"
+								r.pathPattern = "/account-events/filters"
 								r.args = args
 								r.count = 0
 								return r, true
@@ -358,8 +551,118 @@ func (s *Server) FindPath(method string, u *url.URL) (r Route, _ bool) {
 								return
 							}
 						}
-					case 'p': // Prefix: "ption-status"
-						if l := len("ption-status"); len(elem) >= l && elem[0:l] == "ption-status" {
+						switch elem[0] {
+						case '/': // Prefix: "/update"
+							if l := len("/update"); len(elem) >= l && elem[0:l] == "/update" {
+								elem = elem[l:]
+							} else {
+								break
+							}
+
+							if len(elem) == 0 {
+								switch method {
+								case "POST":
+									// Leaf: UpdateAccountEventsFilters
+									r.name = "UpdateAccountEventsFilters"
+									r.operationID = "updateAccountEventsFilters"
+									r.pathPattern = "/account-events/filters/update"
+									r.args = args
+									r.count = 0
+									return r, true
+								default:
+									return
+								}
+							}
+						}
+					case 's': // Prefix: "subscri"
+						if l := len("subscri"); len(elem) >= l && elem[0:l] == "subscri" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							break
+						}
+						switch elem[0] {
+						case 'b': // Prefix: "be"
+							if l := len("be"); len(elem) >= l && elem[0:l] == "be" {
+								elem = elem[l:]
+							} else {
+								break
+							}
+
+							if len(elem) == 0 {
+								switch method {
+								case "POST":
+									// Leaf: SubscribeToAccountEvents
+									r.name = "SubscribeToAccountEvents"
+									r.operationID = "subscribeToAccountEvents"
+									r.pathPattern = "/account-events/subscribe"
+									r.args = args
+									r.count = 0
+									return r, true
+								default:
+									return
+								}
+							}
+						case 'p': // Prefix: "ption"
+							if l := len("ption"); len(elem) >= l && elem[0:l] == "ption" {
+								elem = elem[l:]
+							} else {
+								break
+							}
+
+							if len(elem) == 0 {
+								break
+							}
+							switch elem[0] {
+							case '-': // Prefix: "-status"
+								if l := len("-status"); len(elem) >= l && elem[0:l] == "-status" {
+									elem = elem[l:]
+								} else {
+									break
+								}
+
+								if len(elem) == 0 {
+									switch method {
+									case "POST":
+										// Leaf: AccountEventsSubscriptionStatus
+										r.name = "AccountEventsSubscriptionStatus"
+										r.operationID = "accountEventsSubscriptionStatus"
+										r.pathPattern = "/account-events/subscription-status"
+										r.args = args
+										r.count = 0
+										return r, true
+									default:
+										return
+									}
+								}
+							case 's': // Prefix: "s"
+								if l := len("s"); len(elem) >= l && elem[0:l] == "s" {
+									elem = elem[l:]
+								} else {
+									break
+								}
+
+								if len(elem) == 0 {
+									switch method {
+									case "GET":
+										// Leaf: GetAccountEventsSubscriptions
+										r.name = "GetAccountEventsSubscriptions"
+										r.operationID = "getAccountEventsSubscriptions"
+										r.pathPattern = "/account-events/subscriptions"
+										r.args = args
+										r.count = 0
+										return r, true
+									default:
+										return
+									}
+								}
+							}
+						}
+					case 'u': // Prefix: "unsubscribe"
+						if l := len("unsubscribe"); len(elem) >= l && elem[0:l] == "unsubscribe" {
 							elem = elem[l:]
 						} else {
 							break
@@ -368,10 +671,10 @@ func (s *Server) FindPath(method string, u *url.URL) (r Route, _ bool) {
 						if len(elem) == 0 {
 							switch method {
 							case "POST":
-								// Leaf: AccountEventsSubscriptionStatus
-								r.name = "AccountEventsSubscriptionStatus"
-								r.operationID = "accountEventsSubscriptionStatus"
-								r.pathPattern = "/account-events/subscription-status"
+								// Leaf: UnsubscribeFromAccountEvents
+								r.name = "UnsubscribeFromAccountEvents"
+								r.operationID = "unsubscribeFromAccountEvents"
+								r.pathPattern = "/account-events/unsubscribe"
 								r.args = args
 								r.count = 0
 								return r, true
@@ -380,25 +683,58 @@ func (s *Server) FindPath(method string, u *url.URL) (r Route, _ bool) {
 							}
 						}
 					}
-				case 'u': // Prefix: "unsubscribe"
-					if l := len("unsubscribe"); len(elem) >= l && elem[0:l] == "unsubscribe" {
+				case 'u': // Prefix: "uth/"
+					if l := len("uth/"); len(elem) >= l && elem[0:l] == "uth/" {
 						elem = elem[l:]
 					} else {
 						break
 					}
 
 					if len(elem) == 0 {
-						switch method {
-						case "POST":
-							// Leaf: UnsubscribeFromAccountEvents
-							r.name = "UnsubscribeFromAccountEvents"
-							r.operationID = "unsubscribeFromAccountEvents"
-							r.pathPattern = "/account-events/unsubscribe"
-							r.args = args
-							r.count = 0
-							return r, true
-						default:
-							return
+						break
+					}
+					switch elem[0] {
+					case 'r': // Prefix: "refresh"
+						if l := len("refresh"); len(elem) >= l && elem[0:l] == "refresh" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							switch method {
+							case "POST":
+								// Leaf: RefreshSession
+								r.name = "RefreshSession"
+								r.operationID = "refreshSession"
+								r.pathPattern = "/auth/refresh"
+								r.args = args
+								r.count = 0
+								return r, true
+							default:
+								return
+							}
+						}
+					case 's': // Prefix: "session"
+						if l := len("session"); len(elem) >= l && elem[0:l] == "session" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							switch method {
+							case "POST":
+								// Leaf: CreateSession
+								r.name = "CreateSession"
+								r.operationID = "createSession"
+								r.pathPattern = "/auth/session"
+								r.args = args
+								r.count = 0
+								return r, true
+							default:
+								return
+							}
 						}
 					}
 				}
@@ -413,25 +749,58 @@ func (s *Server) FindPath(method string, u *url.URL) (r Route, _ bool) {
 					break
 				}
 				switch elem[0] {
-				case 's': // Prefix: "subscribe"
-					if l := len("subscribe"); len(elem) >= l && elem[0:l] == "subscribe" {
+				case 's': // Prefix: "subscri"
+					if l := len("subscri"); len(elem) >= l && elem[0:l] == "subscri" {
 						elem = elem[l:]
 					} else {
 						break
 					}
 
 					if len(elem) == 0 {
-						switch method {
-						case "POST":
-							// Leaf: SubscribeToBridgeEvents
-							r.name = "SubscribeToBridgeEvents"
-							r.operationID = "subscribeToBridgeEvents"
-							r.pathPattern = "/bridge/subscribe"
-							r.args = args
-							r.count = 0
-							return r, true
-						default:
-							return
+						break
+					}
+					switch elem[0] {
+					case 'b': // Prefix: "be"
+						if l := len("be"); len(elem) >= l && elem[0:l] == "be" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							switch method {
+							case "POST":
+								// Leaf: SubscribeToBridgeEvents
+								r.name = "SubscribeToBridgeEvents"
+								r.operationID = "subscribeToBridgeEvents"
+								r.pathPattern = "/bridge/subscribe"
+								r.args = args
+								r.count = 0
+								return r, true
+							default:
+								return
+							}
+						}
+					case 'p': // Prefix: "ptions"
+						if l := len("ptions"); len(elem) >= l && elem[0:l] == "ptions" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							switch method {
+							case "GET":
+								// Leaf: GetBridgeSubscriptions
+								r.name = "GetBridgeSubscriptions"
+								r.operationID = "getBridgeSubscriptions"
+								r.pathPattern = "/bridge/subscriptions"
+								r.args = args
+								r.count = 0
+								return r, true
+							default:
+								return
+							}
 						}
 					}
 				case 'u': // Prefix: "unsubscribe"
@@ -482,6 +851,49 @@ func (s *Server) FindPath(method string, u *url.URL) (r Route, _ bool) {
 						}
 					}
 				}
+			case 'n': // Prefix: "notification-settings"
+				if l := len("notification-settings"); len(elem) >= l && elem[0:l] == "notification-settings" {
+					elem = elem[l:]
+				} else {
+					break
+				}
+
+				if len(elem) == 0 {
+					switch method {
+					case "POST":
+						r.name = "GetNotificationSettings"
+						r.operationID = "getNotificationSettings"
+						r.pathPattern = "/notification-settings"
+						r.args = args
+						r.count = 0
+						return r, true
+					default:
+						return
+					}
+				}
+				switch elem[0] {
+				case '/': // Prefix: "/update"
+					if l := len("/update"); len(elem) >= l && elem[0:l] == "/update" {
+						elem = elem[l:]
+					} else {
+						break
+					}
+
+					if len(elem) == 0 {
+						switch method {
+						case "POST":
+							// Leaf: UpdateNotificationSettings
+							r.name = "UpdateNotificationSettings"
+							r.operationID = "updateNotificationSettings"
+							r.pathPattern = "/notification-settings/update"
+							r.args = args
+							r.count = 0
+							return r, true
+						default:
+							return
+						}
+					}
+				}
 			case 't': // Prefix: "tonconnect/payload"
 				if l := len("tonconnect/payload"); len(elem) >= l && elem[0:l] == "tonconnect/payload" {
 					elem = elem[l:]
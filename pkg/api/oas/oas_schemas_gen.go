@@ -4,12 +4,54 @@ package oas
 
 import (
 	"fmt"
+
+	"github.com/go-faster/errors"
 )
 
 func (s *ErrorStatusCode) Error() string {
 	return fmt.Sprintf("code %d: %+v", s.StatusCode, s.Response)
 }
 
+// Ref: #/components/schemas/AccountEventsSubscription
+type AccountEventsSubscription struct {
+	// Raw form of the address.
+	Address string `json:"address"`
+	// User-friendly form of the address.
+	FriendlyAddress string `json:"friendly_address"`
+	// Unix timestamp when the subscription was created.
+	CreatedAt int64 `json:"created_at"`
+}
+
+// GetAddress returns the value of Address.
+func (s *AccountEventsSubscription) GetAddress() string {
+	return s.Address
+}
+
+// GetFriendlyAddress returns the value of FriendlyAddress.
+func (s *AccountEventsSubscription) GetFriendlyAddress() string {
+	return s.FriendlyAddress
+}
+
+// GetCreatedAt returns the value of CreatedAt.
+func (s *AccountEventsSubscription) GetCreatedAt() int64 {
+	return s.CreatedAt
+}
+
+// SetAddress sets the value of Address.
+func (s *AccountEventsSubscription) SetAddress(val string) {
+	s.Address = val
+}
+
+// SetFriendlyAddress sets the value of FriendlyAddress.
+func (s *AccountEventsSubscription) SetFriendlyAddress(val string) {
+	s.FriendlyAddress = val
+}
+
+// SetCreatedAt sets the value of CreatedAt.
+func (s *AccountEventsSubscription) SetCreatedAt(val int64) {
+	s.CreatedAt = val
+}
+
 type AccountEventsSubscriptionStatusOK struct {
 	Subscribed bool `json:"subscribed"`
 }
@@ -25,14 +67,14 @@ func (s *AccountEventsSubscriptionStatusOK) SetSubscribed(val bool) {
 }
 
 type AccountEventsSubscriptionStatusReq struct {
-	// Base64 encoded twa init data.
-	TwaInitData string `json:"twa_init_data"`
+	// Base64 encoded twa init data, required without a session token.
+	TwaInitData OptString `json:"twa_init_data"`
 	// Wallet or smart contract address.
 	Address string `json:"address"`
 }
 
 // GetTwaInitData returns the value of TwaInitData.
-func (s *AccountEventsSubscriptionStatusReq) GetTwaInitData() string {
+func (s *AccountEventsSubscriptionStatusReq) GetTwaInitData() OptString {
 	return s.TwaInitData
 }
 
@@ -42,7 +84,7 @@ func (s *AccountEventsSubscriptionStatusReq) GetAddress() string {
 }
 
 // SetTwaInitData sets the value of TwaInitData.
-func (s *AccountEventsSubscriptionStatusReq) SetTwaInitData(val string) {
+func (s *AccountEventsSubscriptionStatusReq) SetTwaInitData(val OptString) {
 	s.TwaInitData = val
 }
 
@@ -51,6 +93,58 @@ func (s *AccountEventsSubscriptionStatusReq) SetAddress(val string) {
 	s.Address = val
 }
 
+type BearerAuth struct {
+	Token string
+}
+
+// GetToken returns the value of Token.
+func (s *BearerAuth) GetToken() string {
+	return s.Token
+}
+
+// SetToken sets the value of Token.
+func (s *BearerAuth) SetToken(val string) {
+	s.Token = val
+}
+
+// Ref: #/components/schemas/BridgeSubscription
+type BridgeSubscription struct {
+	ClientID string `json:"client_id"`
+	Origin   string `json:"origin"`
+	// Unix timestamp when the subscription was created.
+	CreatedAt int64 `json:"created_at"`
+}
+
+// GetClientID returns the value of ClientID.
+func (s *BridgeSubscription) GetClientID() string {
+	return s.ClientID
+}
+
+// GetOrigin returns the value of Origin.
+func (s *BridgeSubscription) GetOrigin() string {
+	return s.Origin
+}
+
+// GetCreatedAt returns the value of CreatedAt.
+func (s *BridgeSubscription) GetCreatedAt() int64 {
+	return s.CreatedAt
+}
+
+// SetClientID sets the value of ClientID.
+func (s *BridgeSubscription) SetClientID(val string) {
+	s.ClientID = val
+}
+
+// SetOrigin sets the value of Origin.
+func (s *BridgeSubscription) SetOrigin(val string) {
+	s.Origin = val
+}
+
+// SetCreatedAt sets the value of CreatedAt.
+func (s *BridgeSubscription) SetCreatedAt(val int64) {
+	s.CreatedAt = val
+}
+
 // BridgeWebhookOK is response for BridgeWebhook operation.
 type BridgeWebhookOK struct{}
 
@@ -79,8 +173,24 @@ func (s *BridgeWebhookReq) SetHash(val string) {
 	s.Hash = val
 }
 
+type CreateSessionReq struct {
+	// Base64 encoded twa init data.
+	TwaInitData string `json:"twa_init_data"`
+}
+
+// GetTwaInitData returns the value of TwaInitData.
+func (s *CreateSessionReq) GetTwaInitData() string {
+	return s.TwaInitData
+}
+
+// SetTwaInitData sets the value of TwaInitData.
+func (s *CreateSessionReq) SetTwaInitData(val string) {
+	s.TwaInitData = val
+}
+
 type Error struct {
-	Error string `json:"error"`
+	Error string    `json:"error"`
+	Code  OptString `json:"code"`
 }
 
 // GetError returns the value of Error.
@@ -88,11 +198,21 @@ func (s *Error) GetError() string {
 	return s.Error
 }
 
+// GetCode returns the value of Code.
+func (s *Error) GetCode() OptString {
+	return s.Code
+}
+
 // SetError sets the value of Error.
 func (s *Error) SetError(val string) {
 	s.Error = val
 }
 
+// SetCode sets the value of Code.
+func (s *Error) SetCode(val OptString) {
+	s.Code = val
+}
+
 // ErrorStatusCode wraps Error with StatusCode.
 type ErrorStatusCode struct {
 	StatusCode int
@@ -119,6 +239,76 @@ func (s *ErrorStatusCode) SetResponse(val Error) {
 	s.Response = val
 }
 
+type GetAccountEventsFiltersReq struct {
+	// Base64 encoded twa init data, required without a session token.
+	TwaInitData OptString `json:"twa_init_data"`
+	// Wallet or smart contract address the user is subscribed to.
+	Address string `json:"address"`
+}
+
+// GetTwaInitData returns the value of TwaInitData.
+func (s *GetAccountEventsFiltersReq) GetTwaInitData() OptString {
+	return s.TwaInitData
+}
+
+// GetAddress returns the value of Address.
+func (s *GetAccountEventsFiltersReq) GetAddress() string {
+	return s.Address
+}
+
+// SetTwaInitData sets the value of TwaInitData.
+func (s *GetAccountEventsFiltersReq) SetTwaInitData(val OptString) {
+	s.TwaInitData = val
+}
+
+// SetAddress sets the value of Address.
+func (s *GetAccountEventsFiltersReq) SetAddress(val string) {
+	s.Address = val
+}
+
+type GetAccountEventsSubscriptionsOK struct {
+	Subscriptions []AccountEventsSubscription `json:"subscriptions"`
+}
+
+// GetSubscriptions returns the value of Subscriptions.
+func (s *GetAccountEventsSubscriptionsOK) GetSubscriptions() []AccountEventsSubscription {
+	return s.Subscriptions
+}
+
+// SetSubscriptions sets the value of Subscriptions.
+func (s *GetAccountEventsSubscriptionsOK) SetSubscriptions(val []AccountEventsSubscription) {
+	s.Subscriptions = val
+}
+
+type GetBridgeSubscriptionsOK struct {
+	Subscriptions []BridgeSubscription `json:"subscriptions"`
+}
+
+// GetSubscriptions returns the value of Subscriptions.
+func (s *GetBridgeSubscriptionsOK) GetSubscriptions() []BridgeSubscription {
+	return s.Subscriptions
+}
+
+// SetSubscriptions sets the value of Subscriptions.
+func (s *GetBridgeSubscriptionsOK) SetSubscriptions(val []BridgeSubscription) {
+	s.Subscriptions = val
+}
+
+type GetNotificationSettingsReq struct {
+	// Base64 encoded twa init data, required without a session token.
+	TwaInitData OptString `json:"twa_init_data"`
+}
+
+// GetTwaInitData returns the value of TwaInitData.
+func (s *GetNotificationSettingsReq) GetTwaInitData() OptString {
+	return s.TwaInitData
+}
+
+// SetTwaInitData sets the value of TwaInitData.
+func (s *GetNotificationSettingsReq) SetTwaInitData(val OptString) {
+	s.TwaInitData = val
+}
+
 type GetTonConnectPayloadOK struct {
 	Payload string `json:"payload"`
 }
@@ -133,6 +323,212 @@ func (s *GetTonConnectPayloadOK) SetPayload(val string) {
 	s.Payload = val
 }
 
+// Ref: #/components/schemas/NotificationSettings
+type NotificationSettings struct {
+	// Daily window when notifications are held and delivered later as one summary.
+	QuietHours OptNotificationSettingsQuietHours `json:"quiet_hours"`
+	// IANA timezone used to interpret quiet hours, UTC by default.
+	Timezone OptString `json:"timezone"`
+	// Unix timestamp until which notifications are muted.
+	MuteUntil OptInt64 `json:"mute_until"`
+}
+
+// GetQuietHours returns the value of QuietHours.
+func (s *NotificationSettings) GetQuietHours() OptNotificationSettingsQuietHours {
+	return s.QuietHours
+}
+
+// GetTimezone returns the value of Timezone.
+func (s *NotificationSettings) GetTimezone() OptString {
+	return s.Timezone
+}
+
+// GetMuteUntil returns the value of MuteUntil.
+func (s *NotificationSettings) GetMuteUntil() OptInt64 {
+	return s.MuteUntil
+}
+
+// SetQuietHours sets the value of QuietHours.
+func (s *NotificationSettings) SetQuietHours(val OptNotificationSettingsQuietHours) {
+	s.QuietHours = val
+}
+
+// SetTimezone sets the value of Timezone.
+func (s *NotificationSettings) SetTimezone(val OptString) {
+	s.Timezone = val
+}
+
+// SetMuteUntil sets the value of MuteUntil.
+func (s *NotificationSettings) SetMuteUntil(val OptInt64) {
+	s.MuteUntil = val
+}
+
+// Daily window when notifications are held and delivered later as one summary.
+type NotificationSettingsQuietHours struct {
+	// Time of day in the HH:MM format.
+	Start string `json:"start"`
+	// Time of day in the HH:MM format.
+	End string `json:"end"`
+}
+
+// GetStart returns the value of Start.
+func (s *NotificationSettingsQuietHours) GetStart() string {
+	return s.Start
+}
+
+// GetEnd returns the value of End.
+func (s *NotificationSettingsQuietHours) GetEnd() string {
+	return s.End
+}
+
+// SetStart sets the value of Start.
+func (s *NotificationSettingsQuietHours) SetStart(val string) {
+	s.Start = val
+}
+
+// SetEnd sets the value of End.
+func (s *NotificationSettingsQuietHours) SetEnd(val string) {
+	s.End = val
+}
+
+// NewOptBool returns new OptBool with value set to v.
+func NewOptBool(v bool) OptBool {
+	return OptBool{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptBool is optional bool.
+type OptBool struct {
+	Value bool
+	Set   bool
+}
+
+// IsSet returns true if OptBool was set.
+func (o OptBool) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptBool) Reset() {
+	var v bool
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptBool) SetTo(v bool) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptBool) Get() (v bool, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptBool) Or(d bool) bool {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptInt64 returns new OptInt64 with value set to v.
+func NewOptInt64(v int64) OptInt64 {
+	return OptInt64{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptInt64 is optional int64.
+type OptInt64 struct {
+	Value int64
+	Set   bool
+}
+
+// IsSet returns true if OptInt64 was set.
+func (o OptInt64) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptInt64) Reset() {
+	var v int64
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptInt64) SetTo(v int64) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptInt64) Get() (v int64, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptInt64) Or(d int64) int64 {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptNotificationSettingsQuietHours returns new OptNotificationSettingsQuietHours with value set to v.
+func NewOptNotificationSettingsQuietHours(v NotificationSettingsQuietHours) OptNotificationSettingsQuietHours {
+	return OptNotificationSettingsQuietHours{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptNotificationSettingsQuietHours is optional NotificationSettingsQuietHours.
+type OptNotificationSettingsQuietHours struct {
+	Value NotificationSettingsQuietHours
+	Set   bool
+}
+
+// IsSet returns true if OptNotificationSettingsQuietHours was set.
+func (o OptNotificationSettingsQuietHours) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptNotificationSettingsQuietHours) Reset() {
+	var v NotificationSettingsQuietHours
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptNotificationSettingsQuietHours) SetTo(v NotificationSettingsQuietHours) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptNotificationSettingsQuietHours) Get() (v NotificationSettingsQuietHours, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptNotificationSettingsQuietHours) Or(d NotificationSettingsQuietHours) NotificationSettingsQuietHours {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
 // NewOptString returns new OptString with value set to v.
 func NewOptString(v string) OptString {
 	return OptString{
@@ -225,12 +621,78 @@ func (o OptUint32) Or(d uint32) uint32 {
 	return d
 }
 
+type RefreshSessionReq struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// GetRefreshToken returns the value of RefreshToken.
+func (s *RefreshSessionReq) GetRefreshToken() string {
+	return s.RefreshToken
+}
+
+// SetRefreshToken sets the value of RefreshToken.
+func (s *RefreshSessionReq) SetRefreshToken(val string) {
+	s.RefreshToken = val
+}
+
+// Ref: #/components/schemas/Session
+type Session struct {
+	// Session token to be sent in the Authorization header.
+	Token string `json:"token"`
+	// Unix timestamp when the session token expires.
+	ExpiresAt int64 `json:"expires_at"`
+	// Token to get a new session with /auth/refresh.
+	RefreshToken string `json:"refresh_token"`
+	// Unix timestamp when the refresh token expires.
+	RefreshExpiresAt int64 `json:"refresh_expires_at"`
+}
+
+// GetToken returns the value of Token.
+func (s *Session) GetToken() string {
+	return s.Token
+}
+
+// GetExpiresAt returns the value of ExpiresAt.
+func (s *Session) GetExpiresAt() int64 {
+	return s.ExpiresAt
+}
+
+// GetRefreshToken returns the value of RefreshToken.
+func (s *Session) GetRefreshToken() string {
+	return s.RefreshToken
+}
+
+// GetRefreshExpiresAt returns the value of RefreshExpiresAt.
+func (s *Session) GetRefreshExpiresAt() int64 {
+	return s.RefreshExpiresAt
+}
+
+// SetToken sets the value of Token.
+func (s *Session) SetToken(val string) {
+	s.Token = val
+}
+
+// SetExpiresAt sets the value of ExpiresAt.
+func (s *Session) SetExpiresAt(val int64) {
+	s.ExpiresAt = val
+}
+
+// SetRefreshToken sets the value of RefreshToken.
+func (s *Session) SetRefreshToken(val string) {
+	s.RefreshToken = val
+}
+
+// SetRefreshExpiresAt sets the value of RefreshExpiresAt.
+func (s *Session) SetRefreshExpiresAt(val int64) {
+	s.RefreshExpiresAt = val
+}
+
 // SubscribeToAccountEventsOK is response for SubscribeToAccountEvents operation.
 type SubscribeToAccountEventsOK struct{}
 
 type SubscribeToAccountEventsReq struct {
-	// Base64 encoded twa init data.
-	TwaInitData string `json:"twa_init_data"`
+	// Base64 encoded twa init data, required without a session token.
+	TwaInitData OptString `json:"twa_init_data"`
 	// Wallet or smart contract address.
 	Address string `json:"address"`
 	// TON Connect proof of ownership of the address.
@@ -238,7 +700,7 @@ type SubscribeToAccountEventsReq struct {
 }
 
 // GetTwaInitData returns the value of TwaInitData.
-func (s *SubscribeToAccountEventsReq) GetTwaInitData() string {
+func (s *SubscribeToAccountEventsReq) GetTwaInitData() OptString {
 	return s.TwaInitData
 }
 
@@ -253,7 +715,7 @@ func (s *SubscribeToAccountEventsReq) GetProof() SubscribeToAccountEventsReqProo
 }
 
 // SetTwaInitData sets the value of TwaInitData.
-func (s *SubscribeToAccountEventsReq) SetTwaInitData(val string) {
+func (s *SubscribeToAccountEventsReq) SetTwaInitData(val OptString) {
 	s.TwaInitData = val
 }
 
@@ -355,14 +817,14 @@ func (s *SubscribeToAccountEventsReqProofDomain) SetValue(val string) {
 type SubscribeToBridgeEventsOK struct{}
 
 type SubscribeToBridgeEventsReq struct {
-	// Base64 encoded twa init data.
-	TwaInitData string `json:"twa_init_data"`
-	ClientID    string `json:"client_id"`
-	Origin      string `json:"origin"`
+	// Base64 encoded twa init data, required without a session token.
+	TwaInitData OptString `json:"twa_init_data"`
+	ClientID    string    `json:"client_id"`
+	Origin      string    `json:"origin"`
 }
 
 // GetTwaInitData returns the value of TwaInitData.
-func (s *SubscribeToBridgeEventsReq) GetTwaInitData() string {
+func (s *SubscribeToBridgeEventsReq) GetTwaInitData() OptString {
 	return s.TwaInitData
 }
 
@@ -377,7 +839,7 @@ func (s *SubscribeToBridgeEventsReq) GetOrigin() string {
 }
 
 // SetTwaInitData sets the value of TwaInitData.
-func (s *SubscribeToBridgeEventsReq) SetTwaInitData(val string) {
+func (s *SubscribeToBridgeEventsReq) SetTwaInitData(val OptString) {
 	s.TwaInitData = val
 }
 
@@ -391,35 +853,181 @@ func (s *SubscribeToBridgeEventsReq) SetOrigin(val string) {
 	s.Origin = val
 }
 
+// Filters apply to incoming actions, the user is always notified about outgoing transfers.
+// Ref: #/components/schemas/SubscriptionFilters
+type SubscriptionFilters struct {
+	// Action types the user is notified about, all types by default.
+	Actions []SubscriptionFiltersActionsItem `json:"actions"`
+	// Minimum amount of TON transfers in nanotons.
+	MinTonAmount OptInt64 `json:"min_ton_amount"`
+	// Master addresses of jettons, if not empty, the user is notified about these jettons only.
+	JettonAllowlist []string `json:"jetton_allowlist"`
+	// Master addresses of jettons the user is never notified about.
+	JettonDenylist []string `json:"jetton_denylist"`
+	// Notify about jettons verified by Tonkeeper only.
+	OnlyVerifiedJettons OptBool `json:"only_verified_jettons"`
+}
+
+// GetActions returns the value of Actions.
+func (s *SubscriptionFilters) GetActions() []SubscriptionFiltersActionsItem {
+	return s.Actions
+}
+
+// GetMinTonAmount returns the value of MinTonAmount.
+func (s *SubscriptionFilters) GetMinTonAmount() OptInt64 {
+	return s.MinTonAmount
+}
+
+// GetJettonAllowlist returns the value of JettonAllowlist.
+func (s *SubscriptionFilters) GetJettonAllowlist() []string {
+	return s.JettonAllowlist
+}
+
+// GetJettonDenylist returns the value of JettonDenylist.
+func (s *SubscriptionFilters) GetJettonDenylist() []string {
+	return s.JettonDenylist
+}
+
+// GetOnlyVerifiedJettons returns the value of OnlyVerifiedJettons.
+func (s *SubscriptionFilters) GetOnlyVerifiedJettons() OptBool {
+	return s.OnlyVerifiedJettons
+}
+
+// SetActions sets the value of Actions.
+func (s *SubscriptionFilters) SetActions(val []SubscriptionFiltersActionsItem) {
+	s.Actions = val
+}
+
+// SetMinTonAmount sets the value of MinTonAmount.
+func (s *SubscriptionFilters) SetMinTonAmount(val OptInt64) {
+	s.MinTonAmount = val
+}
+
+// SetJettonAllowlist sets the value of JettonAllowlist.
+func (s *SubscriptionFilters) SetJettonAllowlist(val []string) {
+	s.JettonAllowlist = val
+}
+
+// SetJettonDenylist sets the value of JettonDenylist.
+func (s *SubscriptionFilters) SetJettonDenylist(val []string) {
+	s.JettonDenylist = val
+}
+
+// SetOnlyVerifiedJettons sets the value of OnlyVerifiedJettons.
+func (s *SubscriptionFilters) SetOnlyVerifiedJettons(val OptBool) {
+	s.OnlyVerifiedJettons = val
+}
+
+type SubscriptionFiltersActionsItem string
+
+const (
+	SubscriptionFiltersActionsItemTonTransfer    SubscriptionFiltersActionsItem = "ton_transfer"
+	SubscriptionFiltersActionsItemJettonTransfer SubscriptionFiltersActionsItem = "jetton_transfer"
+	SubscriptionFiltersActionsItemJettonMint     SubscriptionFiltersActionsItem = "jetton_mint"
+	SubscriptionFiltersActionsItemNftTransfer    SubscriptionFiltersActionsItem = "nft_transfer"
+	SubscriptionFiltersActionsItemNftPurchase    SubscriptionFiltersActionsItem = "nft_purchase"
+	SubscriptionFiltersActionsItemJettonSwap     SubscriptionFiltersActionsItem = "jetton_swap"
+)
+
+// AllValues returns all SubscriptionFiltersActionsItem values.
+func (SubscriptionFiltersActionsItem) AllValues() []SubscriptionFiltersActionsItem {
+	return []SubscriptionFiltersActionsItem{
+		SubscriptionFiltersActionsItemTonTransfer,
+		SubscriptionFiltersActionsItemJettonTransfer,
+		SubscriptionFiltersActionsItemJettonMint,
+		SubscriptionFiltersActionsItemNftTransfer,
+		SubscriptionFiltersActionsItemNftPurchase,
+		SubscriptionFiltersActionsItemJettonSwap,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s SubscriptionFiltersActionsItem) MarshalText() ([]byte, error) {
+	switch s {
+	case SubscriptionFiltersActionsItemTonTransfer:
+		return []byte(s), nil
+	case SubscriptionFiltersActionsItemJettonTransfer:
+		return []byte(s), nil
+	case SubscriptionFiltersActionsItemJettonMint:
+		return []byte(s), nil
+	case SubscriptionFiltersActionsItemNftTransfer:
+		return []byte(s), nil
+	case SubscriptionFiltersActionsItemNftPurchase:
+		return []byte(s), nil
+	case SubscriptionFiltersActionsItemJettonSwap:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *SubscriptionFiltersActionsItem) UnmarshalText(data []byte) error {
+	switch SubscriptionFiltersActionsItem(data) {
+	case SubscriptionFiltersActionsItemTonTransfer:
+		*s = SubscriptionFiltersActionsItemTonTransfer
+		return nil
+	case SubscriptionFiltersActionsItemJettonTransfer:
+		*s = SubscriptionFiltersActionsItemJettonTransfer
+		return nil
+	case SubscriptionFiltersActionsItemJettonMint:
+		*s = SubscriptionFiltersActionsItemJettonMint
+		return nil
+	case SubscriptionFiltersActionsItemNftTransfer:
+		*s = SubscriptionFiltersActionsItemNftTransfer
+		return nil
+	case SubscriptionFiltersActionsItemNftPurchase:
+		*s = SubscriptionFiltersActionsItemNftPurchase
+		return nil
+	case SubscriptionFiltersActionsItemJettonSwap:
+		*s = SubscriptionFiltersActionsItemJettonSwap
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
 // UnsubscribeFromAccountEventsOK is response for UnsubscribeFromAccountEvents operation.
 type UnsubscribeFromAccountEventsOK struct{}
 
 type UnsubscribeFromAccountEventsReq struct {
-	// Base64 encoded twa init data.
-	TwaInitData string `json:"twa_init_data"`
+	// Base64 encoded twa init data, required without a session token.
+	TwaInitData OptString `json:"twa_init_data"`
+	// Wallet or smart contract address to unsubscribe from, all addresses if not set.
+	Address OptString `json:"address"`
 }
 
 // GetTwaInitData returns the value of TwaInitData.
-func (s *UnsubscribeFromAccountEventsReq) GetTwaInitData() string {
+func (s *UnsubscribeFromAccountEventsReq) GetTwaInitData() OptString {
 	return s.TwaInitData
 }
 
+// GetAddress returns the value of Address.
+func (s *UnsubscribeFromAccountEventsReq) GetAddress() OptString {
+	return s.Address
+}
+
 // SetTwaInitData sets the value of TwaInitData.
-func (s *UnsubscribeFromAccountEventsReq) SetTwaInitData(val string) {
+func (s *UnsubscribeFromAccountEventsReq) SetTwaInitData(val OptString) {
 	s.TwaInitData = val
 }
 
+// SetAddress sets the value of Address.
+func (s *UnsubscribeFromAccountEventsReq) SetAddress(val OptString) {
+	s.Address = val
+}
+
 // UnsubscribeFromBridgeEventsOK is response for UnsubscribeFromBridgeEvents operation.
 type UnsubscribeFromBridgeEventsOK struct{}
 
 type UnsubscribeFromBridgeEventsReq struct {
-	// Base64 encoded twa init data.
-	TwaInitData string    `json:"twa_init_data"`
+	// Base64 encoded twa init data, required without a session token.
+	TwaInitData OptString `json:"twa_init_data"`
 	ClientID    OptString `json:"client_id"`
 }
 
 // GetTwaInitData returns the value of TwaInitData.
-func (s *UnsubscribeFromBridgeEventsReq) GetTwaInitData() string {
+func (s *UnsubscribeFromBridgeEventsReq) GetTwaInitData() OptString {
 	return s.TwaInitData
 }
 
@@ -429,7 +1037,7 @@ func (s *UnsubscribeFromBridgeEventsReq) GetClientID() OptString {
 }
 
 // SetTwaInitData sets the value of TwaInitData.
-func (s *UnsubscribeFromBridgeEventsReq) SetTwaInitData(val string) {
+func (s *UnsubscribeFromBridgeEventsReq) SetTwaInitData(val OptString) {
 	s.TwaInitData = val
 }
 
@@ -437,3 +1045,73 @@ func (s *UnsubscribeFromBridgeEventsReq) SetTwaInitData(val string) {
 func (s *UnsubscribeFromBridgeEventsReq) SetClientID(val OptString) {
 	s.ClientID = val
 }
+
+// UpdateAccountEventsFiltersOK is response for UpdateAccountEventsFilters operation.
+type UpdateAccountEventsFiltersOK struct{}
+
+type UpdateAccountEventsFiltersReq struct {
+	// Base64 encoded twa init data, required without a session token.
+	TwaInitData OptString `json:"twa_init_data"`
+	// Wallet or smart contract address the user is subscribed to.
+	Address string              `json:"address"`
+	Filters SubscriptionFilters `json:"filters"`
+}
+
+// GetTwaInitData returns the value of TwaInitData.
+func (s *UpdateAccountEventsFiltersReq) GetTwaInitData() OptString {
+	return s.TwaInitData
+}
+
+// GetAddress returns the value of Address.
+func (s *UpdateAccountEventsFiltersReq) GetAddress() string {
+	return s.Address
+}
+
+// GetFilters returns the value of Filters.
+func (s *UpdateAccountEventsFiltersReq) GetFilters() SubscriptionFilters {
+	return s.Filters
+}
+
+// SetTwaInitData sets the value of TwaInitData.
+func (s *UpdateAccountEventsFiltersReq) SetTwaInitData(val OptString) {
+	s.TwaInitData = val
+}
+
+// SetAddress sets the value of Address.
+func (s *UpdateAccountEventsFiltersReq) SetAddress(val string) {
+	s.Address = val
+}
+
+// SetFilters sets the value of Filters.
+func (s *UpdateAccountEventsFiltersReq) SetFilters(val SubscriptionFilters) {
+	s.Filters = val
+}
+
+// UpdateNotificationSettingsOK is response for UpdateNotificationSettings operation.
+type UpdateNotificationSettingsOK struct{}
+
+type UpdateNotificationSettingsReq struct {
+	// Base64 encoded twa init data, required without a session token.
+	TwaInitData OptString            `json:"twa_init_data"`
+	Settings    NotificationSettings `json:"settings"`
+}
+
+// GetTwaInitData returns the value of TwaInitData.
+func (s *UpdateNotificationSettingsReq) GetTwaInitData() OptString {
+	return s.TwaInitData
+}
+
+// GetSettings returns the value of Settings.
+func (s *UpdateNotificationSettingsReq) GetSettings() NotificationSettings {
+	return s.Settings
+}
+
+// SetTwaInitData sets the value of TwaInitData.
+func (s *UpdateNotificationSettingsReq) SetTwaInitData(val OptString) {
+	s.TwaInitData = val
+}
+
+// SetSettings sets the value of Settings.
+func (s *UpdateNotificationSettingsReq) SetSettings(val NotificationSettings) {
+	s.Settings = val
+}
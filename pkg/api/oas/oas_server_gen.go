@@ -20,12 +20,52 @@ type Handler interface {
 	//
 	// POST /bridge/webhook/{client_id}
 	BridgeWebhook(ctx context.Context, req *BridgeWebhookReq, params BridgeWebhookParams) error
+	// CreateSession implements createSession operation.
+	//
+	// Exchange twa init data for a session token. Init data can be exchanged only once. Other operations
+	// accept the token as a bearer token in the Authorization header instead of twa_init_data.
+	//
+	// POST /auth/session
+	CreateSession(ctx context.Context, req *CreateSessionReq) (*Session, error)
+	// GetAccountEventsFilters implements getAccountEventsFilters operation.
+	//
+	// Get filters deciding which events of an account a user is notified about.
+	//
+	// POST /account-events/filters
+	GetAccountEventsFilters(ctx context.Context, req *GetAccountEventsFiltersReq) (*SubscriptionFilters, error)
+	// GetAccountEventsSubscriptions implements getAccountEventsSubscriptions operation.
+	//
+	// Get accounts a user is subscribed to. Without a session token, twa init data is passed in a header,
+	//  so it doesn't end up in URLs.
+	//
+	// GET /account-events/subscriptions
+	GetAccountEventsSubscriptions(ctx context.Context, params GetAccountEventsSubscriptionsParams) (*GetAccountEventsSubscriptionsOK, error)
+	// GetBridgeSubscriptions implements getBridgeSubscriptions operation.
+	//
+	// Get dApps a user gets bridge notifications from. Without a session token, twa init data is passed
+	// in a header, so it doesn't end up in URLs.
+	//
+	// GET /bridge/subscriptions
+	GetBridgeSubscriptions(ctx context.Context, params GetBridgeSubscriptionsParams) (*GetBridgeSubscriptionsOK, error)
+	// GetNotificationSettings implements getNotificationSettings operation.
+	//
+	// Get notification settings of a user.
+	//
+	// POST /notification-settings
+	GetNotificationSettings(ctx context.Context, req *GetNotificationSettingsReq) (*NotificationSettings, error)
 	// GetTonConnectPayload implements getTonConnectPayload operation.
 	//
 	// Get a challenge for TON Connect.
 	//
 	// GET /tonconnect/payload
 	GetTonConnectPayload(ctx context.Context) (*GetTonConnectPayloadOK, error)
+	// RefreshSession implements refreshSession operation.
+	//
+	// Exchange a refresh token for a new session before the refresh token expires. A refresh token can
+	// be exchanged only once, the new session expires when the refreshed one does.
+	//
+	// POST /auth/refresh
+	RefreshSession(ctx context.Context, req *RefreshSessionReq) (*Session, error)
 	// SubscribeToAccountEvents implements subscribeToAccountEvents operation.
 	//
 	// Subscribe to notifications about events in the TON blockchain for a specific address.
@@ -40,16 +80,31 @@ type Handler interface {
 	SubscribeToBridgeEvents(ctx context.Context, req *SubscribeToBridgeEventsReq) error
 	// UnsubscribeFromAccountEvents implements unsubscribeFromAccountEvents operation.
 	//
-	// Unsubscribe from notifications about events in the TON blockchain for a specific address.
+	// Unsubscribe from notifications about events in the TON blockchain for a specific address or, if no
+	// address is given, for all addresses. The same twa_init_data is accepted only once, a session token
+	// can be used until it expires.
 	//
 	// POST /account-events/unsubscribe
 	UnsubscribeFromAccountEvents(ctx context.Context, req *UnsubscribeFromAccountEventsReq) error
 	// UnsubscribeFromBridgeEvents implements unsubscribeFromBridgeEvents operation.
 	//
-	// Unsubscribe from bridge notifications.
+	// Unsubscribe from bridge notifications. The same twa_init_data is accepted only once, a session
+	// token can be used until it expires.
 	//
 	// POST /bridge/unsubscribe
 	UnsubscribeFromBridgeEvents(ctx context.Context, req *UnsubscribeFromBridgeEventsReq) error
+	// UpdateAccountEventsFilters implements updateAccountEventsFilters operation.
+	//
+	// Replace filters deciding which events of an account a user is notified about.
+	//
+	// POST /account-events/filters/update
+	UpdateAccountEventsFilters(ctx context.Context, req *UpdateAccountEventsFiltersReq) error
+	// UpdateNotificationSettings implements updateNotificationSettings operation.
+	//
+	// Update notification settings of a user.
+	//
+	// POST /notification-settings/update
+	UpdateNotificationSettings(ctx context.Context, req *UpdateNotificationSettingsReq) error
 	// NewError creates *ErrorStatusCode from error returned by handler.
 	//
 	// Used for common default response.
@@ -59,18 +114,20 @@ type Handler interface {
 // Server implements http server based on OpenAPI v3 specification and
 // calls Handler to handle requests.
 type Server struct {
-	h Handler
+	h   Handler
+	sec SecurityHandler
 	baseServer
 }
 
 // NewServer creates new Server.
-func NewServer(h Handler, opts ...ServerOption) (*Server, error) {
+func NewServer(h Handler, sec SecurityHandler, opts ...ServerOption) (*Server, error) {
 	s, err := newServerConfig(opts...).baseServer()
 	if err != nil {
 		return nil, err
 	}
 	return &Server{
 		h:          h,
+		sec:        sec,
 		baseServer: s,
 	}, nil
 }
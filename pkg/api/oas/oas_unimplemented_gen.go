@@ -31,6 +31,55 @@ func (UnimplementedHandler) BridgeWebhook(ctx context.Context, req *BridgeWebhoo
 	return ht.ErrNotImplemented
 }
 
+// CreateSession implements createSession operation.
+//
+// Exchange twa init data for a session token. Init data can be exchanged only once. Other operations
+// accept the token as a bearer token in the Authorization header instead of twa_init_data.
+//
+// POST /auth/session
+func (UnimplementedHandler) CreateSession(ctx context.Context, req *CreateSessionReq) (r *Session, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GetAccountEventsFilters implements getAccountEventsFilters operation.
+//
+// Get filters deciding which events of an account a user is notified about.
+//
+// POST /account-events/filters
+func (UnimplementedHandler) GetAccountEventsFilters(ctx context.Context, req *GetAccountEventsFiltersReq) (r *SubscriptionFilters, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GetAccountEventsSubscriptions implements getAccountEventsSubscriptions operation.
+//
+// Get accounts a user is subscribed to. Without a session token, twa init data is passed in a header,
+//
+//	so it doesn't end up in URLs.
+//
+// GET /account-events/subscriptions
+func (UnimplementedHandler) GetAccountEventsSubscriptions(ctx context.Context, params GetAccountEventsSubscriptionsParams) (r *GetAccountEventsSubscriptionsOK, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GetBridgeSubscriptions implements getBridgeSubscriptions operation.
+//
+// Get dApps a user gets bridge notifications from. Without a session token, twa init data is passed
+// in a header, so it doesn't end up in URLs.
+//
+// GET /bridge/subscriptions
+func (UnimplementedHandler) GetBridgeSubscriptions(ctx context.Context, params GetBridgeSubscriptionsParams) (r *GetBridgeSubscriptionsOK, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GetNotificationSettings implements getNotificationSettings operation.
+//
+// Get notification settings of a user.
+//
+// POST /notification-settings
+func (UnimplementedHandler) GetNotificationSettings(ctx context.Context, req *GetNotificationSettingsReq) (r *NotificationSettings, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
 // GetTonConnectPayload implements getTonConnectPayload operation.
 //
 // Get a challenge for TON Connect.
@@ -40,6 +89,16 @@ func (UnimplementedHandler) GetTonConnectPayload(ctx context.Context) (r *GetTon
 	return r, ht.ErrNotImplemented
 }
 
+// RefreshSession implements refreshSession operation.
+//
+// Exchange a refresh token for a new session before the refresh token expires. A refresh token can
+// be exchanged only once, the new session expires when the refreshed one does.
+//
+// POST /auth/refresh
+func (UnimplementedHandler) RefreshSession(ctx context.Context, req *RefreshSessionReq) (r *Session, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
 // SubscribeToAccountEvents implements subscribeToAccountEvents operation.
 //
 // Subscribe to notifications about events in the TON blockchain for a specific address.
@@ -60,7 +119,9 @@ func (UnimplementedHandler) SubscribeToBridgeEvents(ctx context.Context, req *Su
 
 // UnsubscribeFromAccountEvents implements unsubscribeFromAccountEvents operation.
 //
-// Unsubscribe from notifications about events in the TON blockchain for a specific address.
+// Unsubscribe from notifications about events in the TON blockchain for a specific address or, if no
+// address is given, for all addresses. The same twa_init_data is accepted only once, a session token
+// can be used until it expires.
 //
 // POST /account-events/unsubscribe
 func (UnimplementedHandler) UnsubscribeFromAccountEvents(ctx context.Context, req *UnsubscribeFromAccountEventsReq) error {
@@ -69,13 +130,32 @@ func (UnimplementedHandler) UnsubscribeFromAccountEvents(ctx context.Context, re
 
 // UnsubscribeFromBridgeEvents implements unsubscribeFromBridgeEvents operation.
 //
-// Unsubscribe from bridge notifications.
+// Unsubscribe from bridge notifications. The same twa_init_data is accepted only once, a session
+// token can be used until it expires.
 //
 // POST /bridge/unsubscribe
 func (UnimplementedHandler) UnsubscribeFromBridgeEvents(ctx context.Context, req *UnsubscribeFromBridgeEventsReq) error {
 	return ht.ErrNotImplemented
 }
 
+// UpdateAccountEventsFilters implements updateAccountEventsFilters operation.
+//
+// Replace filters deciding which events of an account a user is notified about.
+//
+// POST /account-events/filters/update
+func (UnimplementedHandler) UpdateAccountEventsFilters(ctx context.Context, req *UpdateAccountEventsFiltersReq) error {
+	return ht.ErrNotImplemented
+}
+
+// UpdateNotificationSettings implements updateNotificationSettings operation.
+//
+// Update notification settings of a user.
+//
+// POST /notification-settings/update
+func (UnimplementedHandler) UpdateNotificationSettings(ctx context.Context, req *UpdateNotificationSettingsReq) error {
+	return ht.ErrNotImplemented
+}
+
 // NewError creates *ErrorStatusCode from error returned by handler.
 //
 // Used for common default response.
@@ -0,0 +1,53 @@
+package api
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const replayGuardCleanupInterval = time.Minute
+
+// ReplayGuard remembers used init data to reject it when it is sent again.
+type ReplayGuard interface {
+	// UseOnce marks a key as used for the given ttl.
+	// It returns false if the key has been already used and its ttl hasn't expired yet.
+	UseOnce(ctx context.Context, key string, ttl time.Duration) (bool, error)
+}
+
+// MemoryReplayGuard is a ReplayGuard that keeps used keys in memory.
+// It protects a single replica only,
+// several replicas should share a guard backed by storage.
+type MemoryReplayGuard struct {
+	mu          sync.Mutex
+	keys        map[string]time.Time
+	lastCleanup time.Time
+}
+
+func NewMemoryReplayGuard() *MemoryReplayGuard {
+	return &MemoryReplayGuard{
+		keys: map[string]time.Time{},
+	}
+}
+
+func (g *MemoryReplayGuard) UseOnce(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(g.lastCleanup) > replayGuardCleanupInterval {
+		for k, expiresAt := range g.keys {
+			if !expiresAt.After(now) {
+				delete(g.keys, k)
+			}
+		}
+		g.lastCleanup = now
+	}
+	if expiresAt, ok := g.keys[key]; ok && expiresAt.After(now) {
+		return false, nil
+	}
+	g.keys[key] = now.Add(ttl)
+	return true, nil
+}
+
+var _ ReplayGuard = (*MemoryReplayGuard)(nil)
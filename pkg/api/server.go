@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"errors"
 	"net/http"
 
@@ -9,6 +10,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/tonkeeper/tonkeeper-twa-api/pkg/api/oas"
+	"github.com/tonkeeper/tonkeeper-twa-api/pkg/telegram"
 )
 
 // Server is an HTTP server that serves the API described in api/tonkeeper-twa-api.yaml.
@@ -17,9 +19,30 @@ type Server struct {
 	httpServer *http.Server
 }
 
-func NewServer(log *zap.Logger, pool *pgxpool.Pool, handler *Handler, address string) (*Server, error) {
+type serverOptions struct {
+	webhookSecretToken string
+	updateHandler      telegram.UpdateHandler
+}
+
+// ServerOption configures a Server.
+type ServerOption func(o *serverOptions)
+
+// WithTelegramWebhook enables an endpoint at TelegramWebhookPath that receives updates from the Telegram Bot API.
+// Requests without the secret token are rejected.
+func WithTelegramWebhook(secretToken string, handler telegram.UpdateHandler) ServerOption {
+	return func(o *serverOptions) {
+		o.webhookSecretToken = secretToken
+		o.updateHandler = handler
+	}
+}
+
+func NewServer(log *zap.Logger, pool *pgxpool.Pool, handler *Handler, address string, opts ...ServerOption) (*Server, error) {
+	options := &serverOptions{}
+	for _, o := range opts {
+		o(options)
+	}
 	ogenMiddlewares := []oas.Middleware{ogenLoggingMiddleware(log)}
-	ogenServer, err := oas.NewServer(handler,
+	ogenServer, err := oas.NewServer(handler, handler,
 		oas.WithMiddleware(ogenMiddlewares...))
 
 	if err != nil {
@@ -28,12 +51,19 @@ func NewServer(log *zap.Logger, pool *pgxpool.Pool, handler *Handler, address st
 	mux := http.NewServeMux()
 	mux.Handle("/", ogenServer)
 	mux.HandleFunc("/healthz", healthzHandler(pool))
+	if options.updateHandler != nil {
+		mux.HandleFunc(TelegramWebhookPath, telegramWebhookHandler(log, options.webhookSecretToken, options.updateHandler))
+	}
 
+	corsHandler := cors.New(cors.Options{
+		// the default headers and Authorization to pass session tokens.
+		AllowedHeaders: []string{"Origin", "Accept", "Content-Type", "X-Requested-With", "Authorization", "X-Twa-Init-Data"},
+	})
 	serv := Server{
 		logger: log,
 		httpServer: &http.Server{
 			Addr:    address,
-			Handler: cors.Default().Handler(mux),
+			Handler: corsHandler.Handler(mux),
 		},
 	}
 	return &serv, nil
@@ -48,6 +78,11 @@ func (s *Server) Run() {
 	s.logger.Fatal("ListedAndServe() failed", zap.Error(err))
 }
 
+// Shutdown stops accepting new connections and waits until the active requests are served or ctx is done.
+func (s *Server) Shutdown(ctx context.Context) error {
+	return s.httpServer.Shutdown(ctx)
+}
+
 func healthzHandler(pool *pgxpool.Pool) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		if err := pool.Ping(r.Context()); err != nil {
@@ -0,0 +1,166 @@
+package api
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/tonkeeper/tonkeeper-twa-api/pkg/telegram"
+)
+
+const (
+	defaultSessionLifetime = 15 * time.Minute
+	defaultRefreshLifetime = 7 * 24 * time.Hour
+)
+
+type tokenKind string
+
+const (
+	// accessTokenKind is sent in the Authorization header to call operations.
+	accessTokenKind tokenKind = "access"
+	// refreshTokenKind is exchanged for a new session only.
+	refreshTokenKind tokenKind = "refresh"
+)
+
+// sessionClaims is the payload of a session token.
+type sessionClaims struct {
+	// ID is a random ID of a refresh token, so it can be used once.
+	ID        string          `json:"jti,omitempty"`
+	UserID    telegram.UserID `json:"uid"`
+	Kind      tokenKind       `json:"kind"`
+	ExpiresAt int64           `json:"exp"`
+}
+
+// session is a pair of tokens issued in exchange for TWA init data.
+type session struct {
+	Token            string
+	ExpiresAt        time.Time
+	RefreshToken     string
+	RefreshExpiresAt time.Time
+}
+
+// sessionIssuer issues and checks session tokens.
+// A token is "<base64url(claims)>.<base64url(hmac-sha256(claims))>",
+// so we don't keep sessions in storage and any replica with the same key accepts the token.
+type sessionIssuer struct {
+	key             []byte
+	lifetime        time.Duration
+	refreshLifetime time.Duration
+}
+
+func newSessionIssuer(key string, lifetime, refreshLifetime time.Duration) (*sessionIssuer, error) {
+	issuer := &sessionIssuer{
+		key:             []byte(key),
+		lifetime:        lifetime,
+		refreshLifetime: refreshLifetime,
+	}
+	if len(issuer.key) == 0 {
+		// tokens issued with a random key are valid until restart and on this replica only.
+		issuer.key = make([]byte, 32)
+		if _, err := rand.Read(issuer.key); err != nil {
+			return nil, err
+		}
+	}
+	if issuer.lifetime <= 0 {
+		issuer.lifetime = defaultSessionLifetime
+	}
+	if issuer.refreshLifetime <= 0 {
+		issuer.refreshLifetime = defaultRefreshLifetime
+	}
+	return issuer, nil
+}
+
+// issue issues a session which ends at refreshExpiresAt.
+// A new session gets a zero refreshExpiresAt and lasts refreshLifetime,
+// a refreshed one keeps the end of the session it replaces, so refreshing doesn't prolong it.
+func (s *sessionIssuer) issue(userID telegram.UserID, now, refreshExpiresAt time.Time) (session, error) {
+	if refreshExpiresAt.IsZero() {
+		refreshExpiresAt = now.Add(s.refreshLifetime)
+	}
+	expiresAt := now.Add(s.lifetime)
+	if expiresAt.After(refreshExpiresAt) {
+		expiresAt = refreshExpiresAt
+	}
+	token, err := s.sign(sessionClaims{UserID: userID, Kind: accessTokenKind, ExpiresAt: expiresAt.Unix()})
+	if err != nil {
+		return session{}, err
+	}
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return session{}, err
+	}
+	refreshToken, err := s.sign(sessionClaims{
+		ID:        base64.RawURLEncoding.EncodeToString(id),
+		UserID:    userID,
+		Kind:      refreshTokenKind,
+		ExpiresAt: refreshExpiresAt.Unix(),
+	})
+	if err != nil {
+		return session{}, err
+	}
+	return session{
+		Token:            token,
+		ExpiresAt:        expiresAt,
+		RefreshToken:     refreshToken,
+		RefreshExpiresAt: refreshExpiresAt,
+	}, nil
+}
+
+func (s *sessionIssuer) sign(claims sessionClaims) (string, error) {
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(s.mac(encoded)), nil
+}
+
+func (s *sessionIssuer) mac(payload string) []byte {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
+
+// verify checks a token of the given kind and returns its claims.
+func (s *sessionIssuer) verify(token string, kind tokenKind, now time.Time) (sessionClaims, error) {
+	payload, signature, found := strings.Cut(token, ".")
+	if !found {
+		return sessionClaims{}, fmt.Errorf("invalid session token")
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(mac, s.mac(payload)) {
+		return sessionClaims{}, fmt.Errorf("invalid session token")
+	}
+	rawClaims, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return sessionClaims{}, fmt.Errorf("invalid session token")
+	}
+	var claims sessionClaims
+	if err := json.Unmarshal(rawClaims, &claims); err != nil {
+		return sessionClaims{}, fmt.Errorf("invalid session token")
+	}
+	if claims.Kind != kind {
+		return sessionClaims{}, fmt.Errorf("invalid session token")
+	}
+	if kind == refreshTokenKind && len(claims.ID) == 0 {
+		return sessionClaims{}, fmt.Errorf("invalid session token")
+	}
+	if !now.Before(time.Unix(claims.ExpiresAt, 0)) {
+		return sessionClaims{}, fmt.Errorf("session token is expired")
+	}
+	return claims, nil
+}
+
+type sessionUserKey struct{}
+
+// sessionUser returns a user authenticated by a session token.
+func sessionUser(ctx context.Context) (telegram.UserID, bool) {
+	userID, ok := ctx.Value(sessionUserKey{}).(telegram.UserID)
+	return userID, ok
+}
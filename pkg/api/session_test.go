@@ -0,0 +1,159 @@
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/tonkeeper/tonkeeper-twa-api/pkg/api/oas"
+	"github.com/tonkeeper/tonkeeper-twa-api/pkg/core"
+	"github.com/tonkeeper/tonkeeper-twa-api/pkg/telegram"
+)
+
+func Test_sessionIssuer_verify(t *testing.T) {
+	now := time.Now()
+	issuer, err := newSessionIssuer("secret", 0, 0)
+	require.Nil(t, err)
+	s, err := issuer.issue(1, now, time.Time{})
+	require.Nil(t, err)
+	require.Equal(t, now.Add(defaultSessionLifetime), s.ExpiresAt)
+	require.Equal(t, now.Add(defaultRefreshLifetime), s.RefreshExpiresAt)
+
+	// a refreshed session keeps its end.
+	end := now.Add(5 * time.Minute)
+	refreshed, err := issuer.issue(1, now, end)
+	require.Nil(t, err)
+	require.Equal(t, end, refreshed.ExpiresAt)
+	require.Equal(t, end, refreshed.RefreshExpiresAt)
+
+	otherIssuer, err := newSessionIssuer("other secret", 0, 0)
+	require.Nil(t, err)
+
+	tests := []struct {
+		name    string
+		issuer  *sessionIssuer
+		token   string
+		kind    tokenKind
+		now     time.Time
+		wantErr string
+	}{
+		{
+			name:   "all good",
+			issuer: issuer,
+			token:  s.Token,
+			kind:   accessTokenKind,
+			now:    now,
+		},
+		{
+			name:   "refresh token",
+			issuer: issuer,
+			token:  s.RefreshToken,
+			kind:   refreshTokenKind,
+			now:    now.Add(time.Hour),
+		},
+		{
+			name:    "refresh token used as access token",
+			issuer:  issuer,
+			token:   s.RefreshToken,
+			kind:    accessTokenKind,
+			now:     now,
+			wantErr: "invalid session token",
+		},
+		{
+			name:    "expired",
+			issuer:  issuer,
+			token:   s.Token,
+			kind:    accessTokenKind,
+			now:     now.Add(time.Hour),
+			wantErr: "session token is expired",
+		},
+		{
+			name:    "signed with another key",
+			issuer:  otherIssuer,
+			token:   s.Token,
+			kind:    accessTokenKind,
+			now:     now,
+			wantErr: "invalid session token",
+		},
+		{
+			name:    "malformed",
+			issuer:  issuer,
+			token:   "token",
+			kind:    accessTokenKind,
+			now:     now,
+			wantErr: "invalid session token",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := tt.issuer.verify(tt.token, tt.kind, tt.now)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, telegram.UserID(1), claims.UserID)
+		})
+	}
+}
+
+func TestHandler_session(t *testing.T) {
+	s := &MockStorage{}
+	notificator, err := core.NewNotificator(zap.L(), s, "")
+	require.Nil(t, err)
+	sessions, err := newSessionIssuer("secret", 0, 0)
+	require.Nil(t, err)
+	h := &Handler{
+		logger:      zap.L(),
+		storage:     s,
+		notificator: notificator,
+		verifyInitDataFn: func(data string) (telegram.InitData, error) {
+			return telegram.InitData{User: telegram.User{ID: 1}, ReplayKey: data}, nil
+		},
+		replayGuard:      NewMemoryReplayGuard(),
+		initDataLifetime: time.Hour,
+		sessions:         sessions,
+	}
+	ctx := context.Background()
+
+	session, err := h.CreateSession(ctx, &oas.CreateSessionReq{TwaInitData: "init data"})
+	require.Nil(t, err)
+
+	// init data is exchanged for a session only once.
+	_, err = h.CreateSession(ctx, &oas.CreateSessionReq{TwaInitData: "init data"})
+	require.EqualError(t, err, "code 401: {Error:init data has already been used Code:{Value: Set:false}}")
+
+	// the session token replaces init data.
+	sessionCtx, err := h.HandleBearerAuth(ctx, "AccountEventsSubscriptionStatus", oas.BearerAuth{Token: session.Token})
+	require.Nil(t, err)
+	userID, err := h.authenticate(sessionCtx, oas.OptString{})
+	require.Nil(t, err)
+	require.Equal(t, telegram.UserID(1), userID)
+	// requests with a session token count as seeing the user.
+	require.Equal(t, []telegram.UserID{1}, s.touched)
+
+	_, err = h.authenticate(ctx, oas.OptString{})
+	require.EqualError(t, err, "code 401: {Error:session token or twa init data is required Code:{Value: Set:false}}")
+
+	_, err = h.HandleBearerAuth(ctx, "AccountEventsSubscriptionStatus", oas.BearerAuth{Token: session.RefreshToken})
+	require.EqualError(t, err, "invalid session token")
+
+	refreshed, err := h.RefreshSession(ctx, &oas.RefreshSessionReq{RefreshToken: session.RefreshToken})
+	require.Nil(t, err)
+	_, err = h.HandleBearerAuth(ctx, "AccountEventsSubscriptionStatus", oas.BearerAuth{Token: refreshed.Token})
+	require.Nil(t, err)
+	// refreshing doesn't prolong the session.
+	require.Equal(t, session.RefreshExpiresAt, refreshed.RefreshExpiresAt)
+
+	// a refresh token is accepted only once.
+	_, err = h.RefreshSession(ctx, &oas.RefreshSessionReq{RefreshToken: session.RefreshToken})
+	require.EqualError(t, err, "code 401: {Error:refresh token has already been used Code:{Value: Set:false}}")
+	_, err = h.RefreshSession(ctx, &oas.RefreshSessionReq{RefreshToken: refreshed.RefreshToken})
+	require.Nil(t, err)
+
+	_, err = h.RefreshSession(ctx, &oas.RefreshSessionReq{RefreshToken: session.Token})
+	require.EqualError(t, err, "code 401: {Error:invalid session token Code:{Value: Set:false}}")
+}
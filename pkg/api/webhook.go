@@ -0,0 +1,37 @@
+package api
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+
+	"github.com/tonkeeper/tonkeeper-twa-api/pkg/telegram"
+)
+
+// TelegramWebhookPath is a path of the endpoint receiving updates from the Telegram Bot API.
+const TelegramWebhookPath = "/telegram/webhook"
+
+func telegramWebhookHandler(logger *zap.Logger, secretToken string, handler telegram.UpdateHandler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		token := r.Header.Get(telegram.SecretTokenHeader)
+		if subtle.ConstantTimeCompare([]byte(token), []byte(secretToken)) != 1 {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var update tgbotapi.Update
+		if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+			logger.Error("failed to decode telegram update", zap.Error(err))
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		handler.HandleUpdate(r.Context(), update)
+		w.WriteHeader(http.StatusOK)
+	}
+}
@@ -0,0 +1,74 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/tonkeeper/tonkeeper-twa-api/pkg/telegram"
+)
+
+type mockUpdateHandler struct {
+	updates []tgbotapi.Update
+}
+
+func (m *mockUpdateHandler) HandleUpdate(ctx context.Context, update tgbotapi.Update) {
+	m.updates = append(m.updates, update)
+}
+
+func Test_telegramWebhookHandler(t *testing.T) {
+	tests := []struct {
+		name           string
+		secretToken    string
+		body           string
+		wantStatusCode int
+		wantUpdates    int
+	}{
+		{
+			name:           "all good",
+			secretToken:    "secret",
+			body:           `{"update_id": 1, "message": {"message_id": 1, "text": "/start"}}`,
+			wantStatusCode: http.StatusOK,
+			wantUpdates:    1,
+		},
+		{
+			name:           "wrong secret token",
+			secretToken:    "not-a-secret",
+			body:           `{"update_id": 1}`,
+			wantStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:           "no secret token",
+			body:           `{"update_id": 1}`,
+			wantStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:           "broken body",
+			secretToken:    "secret",
+			body:           `{"update_id": `,
+			wantStatusCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updateHandler := &mockUpdateHandler{}
+			handler := telegramWebhookHandler(zap.L(), "secret", updateHandler)
+
+			req := httptest.NewRequest(http.MethodPost, TelegramWebhookPath, strings.NewReader(tt.body))
+			if len(tt.secretToken) > 0 {
+				req.Header.Set(telegram.SecretTokenHeader, tt.secretToken)
+			}
+			w := httptest.NewRecorder()
+			handler(w, req)
+
+			require.Equal(t, tt.wantStatusCode, w.Code)
+			require.Equal(t, tt.wantUpdates, len(updateHandler.updates))
+		})
+	}
+}
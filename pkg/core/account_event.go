@@ -1,12 +1,14 @@
 package core
 
 import (
-	"fmt"
 	"math/big"
 
 	"github.com/shopspring/decimal"
 	tonapiClient "github.com/tonkeeper/opentonapi/client"
 	"github.com/tonkeeper/tongo"
+
+	"github.com/tonkeeper/tonkeeper-twa-api/pkg/i18n"
+	"github.com/tonkeeper/tonkeeper-twa-api/pkg/telegram"
 )
 
 func scaleTons(amount int64) decimal.Decimal {
@@ -20,79 +22,153 @@ func scaleJettons(amount string, decimals int) decimal.Decimal {
 	return decimal.NewFromBigInt(&value, int32(-decimals))
 }
 
-func formatTonTransfer(accountID tongo.AccountID, action tonapiClient.OptTonTransferAction) string {
-	if action.Value.Recipient.Address == accountID.ToRaw() {
-		return fmt.Sprintf("Received %v TON", scaleTons(action.Value.Amount))
+// formatAccount returns a name of an account if it is known or its user-friendly address.
+func formatAccount(account tonapiClient.AccountAddress) string {
+	if account.Name.IsSet() && len(account.Name.Value) > 0 {
+		return "<b>" + telegram.EscapeHTML(account.Name.Value) + "</b>"
+	}
+	accountID, err := tongo.ParseAccountID(account.Address)
+	if err != nil {
+		return "<code>" + telegram.EscapeHTML(account.Address) + "</code>"
+	}
+	return "<code>" + accountID.ToHuman(true, false) + "</code>"
+}
+
+func formatTonTransfer(p i18n.Printer, accountID tongo.AccountID, action tonapiClient.OptTonTransferAction) string {
+	switch accountID.ToRaw() {
+	case action.Value.Recipient.Address:
+		return p.Sprintf(i18n.ReceivedTon, p.Decimal(scaleTons(action.Value.Amount)))
+	case action.Value.Sender.Address:
+		return p.Sprintf(i18n.SentTon, p.Decimal(scaleTons(action.Value.Amount)), formatAccount(action.Value.Recipient))
 	}
 	return ""
 }
 
-func formatJettonTransfer(accountID tongo.AccountID, action tonapiClient.OptJettonTransferAction) string {
+func formatJettonTransfer(p i18n.Printer, accountID tongo.AccountID, action tonapiClient.OptJettonTransferAction) string {
 	if !action.Set {
 		return ""
 	}
-	if !action.Value.Recipient.IsSet() {
-		return ""
+	amount := scaleJettons(action.Value.Amount, action.Value.Jetton.Decimals)
+	symbol := telegram.EscapeHTML(action.Value.Jetton.Symbol)
+	if action.Value.Recipient.IsSet() && action.Value.Recipient.Value.Address == accountID.ToRaw() {
+		return p.Sprintf(i18n.ReceivedJetton, p.Decimal(amount), symbol)
 	}
-	if action.Value.Recipient.Value.Address == accountID.ToRaw() {
-		return fmt.Sprintf("Received %v %v", scaleJettons(action.Value.Amount, action.Value.Jetton.Decimals), action.Value.Jetton.Symbol)
+	if action.Value.Sender.IsSet() && action.Value.Sender.Value.Address == accountID.ToRaw() {
+		return p.Sprintf(i18n.SentJetton, p.Decimal(amount), symbol)
 	}
 	return ""
 }
 
-func formatJettonMint(accountID tongo.AccountID, action tonapiClient.OptJettonMintAction) string {
+func formatJettonMint(p i18n.Printer, accountID tongo.AccountID, action tonapiClient.OptJettonMintAction) string {
 	if action.Value.Recipient.Address == accountID.ToRaw() {
-		return fmt.Sprintf("Received %v %v", scaleJettons(action.Value.Amount, action.Value.Jetton.Decimals), action.Value.Jetton.Symbol)
+		amount := scaleJettons(action.Value.Amount, action.Value.Jetton.Decimals)
+		return p.Sprintf(i18n.ReceivedJetton, p.Decimal(amount), telegram.EscapeHTML(action.Value.Jetton.Symbol))
 	}
 	return ""
 }
 
-func formatNftTransfer(accountID tongo.AccountID, action tonapiClient.OptNftItemTransferAction) string {
-	if !action.Value.Recipient.IsSet() {
-		return ""
+func formatNftTransfer(p i18n.Printer, accountID tongo.AccountID, action tonapiClient.OptNftItemTransferAction) string {
+	if action.Value.Recipient.IsSet() && action.Value.Recipient.Value.Address == accountID.ToRaw() {
+		return p.Sprintf(i18n.ReceivedNft)
 	}
-	if action.Value.Recipient.Value.Address == accountID.ToRaw() {
-		return fmt.Sprintf("Received NFT")
+	if action.Value.Sender.IsSet() && action.Value.Sender.Value.Address == accountID.ToRaw() {
+		return p.Sprintf(i18n.SentNft)
 	}
 	return ""
 }
 
-func formatNftPurchase(accountID tongo.AccountID, action tonapiClient.OptNftPurchaseAction) string {
-	if action.Value.Buyer.Address == accountID.ToRaw() {
-		return fmt.Sprintf("Received NFT")
+// isOutgoing returns true if TON, jettons or an NFT left the account in the action.
+func isOutgoing(accountID tongo.AccountID, action tonapiClient.Action) bool {
+	raw := accountID.ToRaw()
+	switch {
+	case action.Type == tonapiClient.ActionTypeTonTransfer && action.TonTransfer.IsSet():
+		transfer := action.TonTransfer.Value
+		return transfer.Sender.Address == raw && transfer.Recipient.Address != raw
+	case action.Type == tonapiClient.ActionTypeJettonTransfer && action.JettonTransfer.IsSet():
+		transfer := action.JettonTransfer.Value
+		return transfer.Sender.IsSet() && transfer.Sender.Value.Address == raw &&
+			!(transfer.Recipient.IsSet() && transfer.Recipient.Value.Address == raw)
+	case action.Type == tonapiClient.ActionTypeNftItemTransfer && action.NftItemTransfer.IsSet():
+		transfer := action.NftItemTransfer.Value
+		return transfer.Sender.IsSet() && transfer.Sender.Value.Address == raw &&
+			!(transfer.Recipient.IsSet() && transfer.Recipient.Value.Address == raw)
+	case action.Type == tonapiClient.ActionTypeNftPurchase && action.NftPurchase.IsSet():
+		purchase := action.NftPurchase.Value
+		return purchase.Seller.Address == raw && purchase.Buyer.Address != raw
+	}
+	return false
+}
+
+func formatNftPurchase(p i18n.Printer, accountID tongo.AccountID, action tonapiClient.OptNftPurchaseAction) string {
+	switch accountID.ToRaw() {
+	case action.Value.Buyer.Address:
+		return p.Sprintf(i18n.ReceivedNft)
+	case action.Value.Seller.Address:
+		return p.Sprintf(i18n.SoldNft)
 	}
 	return ""
 }
 
-func formatMessages(accountID tongo.AccountID, event *tonapiClient.AccountEvent) []string {
-	var messages []string
+func formatSwapAmount(p i18n.Printer, ton tonapiClient.OptInt64, amount string, jetton tonapiClient.OptJettonPreview) string {
+	if ton.IsSet() {
+		return p.Decimal(scaleTons(ton.Value)) + " TON"
+	}
+	return p.Decimal(scaleJettons(amount, jetton.Value.Decimals)) + " " + telegram.EscapeHTML(jetton.Value.Symbol)
+}
+
+func formatJettonSwap(p i18n.Printer, action tonapiClient.OptJettonSwapAction) string {
+	swap := action.Value
+	amountIn := formatSwapAmount(p, swap.TonIn, swap.AmountIn, swap.JettonMasterIn)
+	amountOut := formatSwapAmount(p, swap.TonOut, swap.AmountOut, swap.JettonMasterOut)
+	return p.Sprintf(i18n.Swap, amountIn, amountOut)
+}
+
+// formattedAction is a notification about an action formatted with telegram.ParseModeHTML.
+type formattedAction struct {
+	text string
+	// priority is high for outgoing actions, so the user notices an unauthorized spend right away.
+	priority telegram.Priority
+}
+
+// formatEvent returns notifications about the actions of the event.
+// Actions not allowed by the filters are skipped unless they are outgoing,
+// the fee paid by the account is added to the first outgoing action.
+func formatEvent(p i18n.Printer, accountID tongo.AccountID, event *tonapiClient.AccountEvent, filters SubscriptionFilters) []formattedAction {
+	var messages []formattedAction
+	feeAdded := false
 	for _, action := range event.Actions {
+		outgoing := isOutgoing(accountID, action)
+		if !outgoing && !filters.Allows(action) {
+			continue
+		}
+		var msg string
 		switch {
 		case action.Type == tonapiClient.ActionTypeTonTransfer && action.TonTransfer.IsSet():
-			if msg := formatTonTransfer(accountID, action.TonTransfer); len(msg) > 0 {
-				messages = append(messages, msg)
-			}
+			msg = formatTonTransfer(p, accountID, action.TonTransfer)
 		case action.Type == tonapiClient.ActionTypeJettonTransfer && action.JettonTransfer.IsSet():
-			if msg := formatJettonTransfer(accountID, action.JettonTransfer); len(msg) > 0 {
-				messages = append(messages, msg)
-			}
+			msg = formatJettonTransfer(p, accountID, action.JettonTransfer)
 		case action.Type == tonapiClient.ActionTypeJettonMint && action.JettonMint.IsSet():
-			if msg := formatJettonMint(accountID, action.JettonMint); len(msg) > 0 {
-				messages = append(messages, msg)
-			}
+			msg = formatJettonMint(p, accountID, action.JettonMint)
 		case action.Type == tonapiClient.ActionTypeNftItemTransfer && action.NftItemTransfer.IsSet():
-			if msg := formatNftTransfer(accountID, action.NftItemTransfer); len(msg) > 0 {
-				messages = append(messages, msg)
-			}
+			msg = formatNftTransfer(p, accountID, action.NftItemTransfer)
 		case action.Type == tonapiClient.ActionTypeNftPurchase && action.NftPurchase.IsSet():
-			if msg := formatNftPurchase(accountID, action.NftPurchase); len(msg) > 0 {
-				messages = append(messages, msg)
-			}
+			msg = formatNftPurchase(p, accountID, action.NftPurchase)
 		case action.Type == tonapiClient.ActionTypeJettonSwap && action.JettonSwap.IsSet():
-			if msg := action.SimplePreview.Description; len(msg) > 0 {
-				messages = append(messages, msg)
-			}
+			msg = formatJettonSwap(p, action.JettonSwap)
+		}
+		if len(msg) == 0 {
+			continue
+		}
+		if !outgoing {
+			messages = append(messages, formattedAction{text: msg, priority: telegram.PriorityNormal})
+			continue
+		}
+		// a negative extra is the fee paid by the account.
+		if !feeAdded && event.Extra < 0 {
+			msg += "\n" + p.Sprintf(i18n.Fee, p.Decimal(scaleTons(-event.Extra)))
+			feeAdded = true
 		}
+		messages = append(messages, formattedAction{text: msg, priority: telegram.PriorityHigh})
 	}
 	return messages
 }
@@ -9,9 +9,12 @@ import (
 	"github.com/stretchr/testify/require"
 	tonapiClient "github.com/tonkeeper/opentonapi/client"
 	"github.com/tonkeeper/tongo"
+
+	"github.com/tonkeeper/tonkeeper-twa-api/pkg/i18n"
+	"github.com/tonkeeper/tonkeeper-twa-api/pkg/telegram"
 )
 
-func Test_formatMessages(t *testing.T) {
+func Test_formatEvent_tonapi(t *testing.T) {
 	tests := []struct {
 		name      string
 		accountID tongo.AccountID
@@ -23,7 +26,7 @@ func Test_formatMessages(t *testing.T) {
 			accountID: tongo.MustParseAddress("EQDdYTAOAGD4AjM2OztKDzsnrQOxnMS-xux5iqsLPkeeuorE").ID,
 			eventID:   "e68822c836dd87a99e2b4172693add64e85594aeaf62623eca92ac0e189f8bcf",
 			want: []string{
-				"Received NFT",
+				"Received <b>NFT</b>",
 			},
 		},
 		{
@@ -31,21 +34,23 @@ func Test_formatMessages(t *testing.T) {
 			accountID: tongo.MustParseAddress("EQDdYTAOAGD4AjM2OztKDzsnrQOxnMS-xux5iqsLPkeeuorE").ID,
 			eventID:   "fea6cac8da213b0e7a05dcd5eb5daa55550a6c1d2ff8d6e75b309e649333fe73",
 			want: []string{
-				"Received 0.1 TON",
+				"Received <b>0.1 TON</b>",
 			},
 		},
 		{
 			name:      "sent nft",
 			accountID: tongo.MustParseAddress("EQDdYTAOAGD4AjM2OztKDzsnrQOxnMS-xux5iqsLPkeeuorE").ID,
 			eventID:   "a24664bf86739c912d9ed94d68a3409d7414adc272be8a773fffbf8b5f8f5841",
-			want:      nil,
+			want: []string{
+				"Sent <b>NFT</b>",
+			},
 		},
 		{
 			name:      "received jetton",
 			accountID: tongo.MustParseAddress("EQDdYTAOAGD4AjM2OztKDzsnrQOxnMS-xux5iqsLPkeeuorE").ID,
 			eventID:   "4e3697c01dcee652ead6b7625bf8efa7a35efbd0fffa0dd7b58ff13f1a230e35",
 			want: []string{
-				"Received 0.05 jUSDC",
+				"Received <b>0.05 jUSDC</b>",
 			},
 		},
 		{
@@ -53,7 +58,7 @@ func Test_formatMessages(t *testing.T) {
 			accountID: tongo.MustParseAddress("EQBszTJahYw3lpP64ryqscKQaDGk4QpsO7RO6LYVvKHSINS0").ID,
 			eventID:   "43de17434a703a3d20f1e9c016c1ab14c6ec4a1eebedcc7f5615fd87ed2a1612",
 			want: []string{
-				"Swapping 0.1 WTON for 0.000078233399479493 oETH",
+				"Swapping <b>0.1 WTON</b> for <b>0.000078233399479493 oETH</b>",
 			},
 		},
 		{
@@ -61,7 +66,7 @@ func Test_formatMessages(t *testing.T) {
 			accountID: tongo.MustParseAddress("EQBszTJahYw3lpP64ryqscKQaDGk4QpsO7RO6LYVvKHSINS0").ID,
 			eventID:   "d2f0bec210c5adf20929c12680359006c5ab56e440b58120a785f05b3e5f02a2",
 			want: []string{
-				"Received NFT",
+				"Received <b>NFT</b>",
 			},
 		},
 		{
@@ -69,7 +74,7 @@ func Test_formatMessages(t *testing.T) {
 			accountID: tongo.MustParseAddress("EQBszTJahYw3lpP64ryqscKQaDGk4QpsO7RO6LYVvKHSINS0").ID,
 			eventID:   "27ac37b27880386337ab1c80b533e99c35a3756d23458c36171fe1a09bd678af",
 			want: []string{
-				"Received NFT",
+				"Received <b>NFT</b>",
 			},
 		},
 	}
@@ -88,9 +93,114 @@ func Test_formatMessages(t *testing.T) {
 			event, err := cli.GetAccountEvent(context.Background(), params)
 			require.Nil(t, err)
 
-			messages := formatMessages(tt.accountID, event)
+			var messages []string
+			for _, msg := range formatEvent(i18n.NewPrinter(i18n.English), tt.accountID, event, SubscriptionFilters{}) {
+				messages = append(messages, msg.text)
+			}
 			fmt.Printf("%v\n", messages)
 			require.Equal(t, tt.want, messages)
 		})
 	}
 }
+
+func Test_formatEvent(t *testing.T) {
+	wallet := tongo.MustParseAddress("EQDdYTAOAGD4AjM2OztKDzsnrQOxnMS-xux5iqsLPkeeuorE").ID
+	other := tongo.MustParseAddress("EQBszTJahYw3lpP64ryqscKQaDGk4QpsO7RO6LYVvKHSINS0").ID
+	tonTransfer := func(from, to tongo.AccountID, amount int64) tonapiClient.Action {
+		return tonapiClient.Action{
+			Type: tonapiClient.ActionTypeTonTransfer,
+			TonTransfer: tonapiClient.NewOptTonTransferAction(tonapiClient.TonTransferAction{
+				Sender:    tonapiClient.AccountAddress{Address: from.ToRaw()},
+				Recipient: tonapiClient.AccountAddress{Address: to.ToRaw()},
+				Amount:    amount,
+			}),
+		}
+	}
+	nftPurchase := func(seller, buyer tongo.AccountID) tonapiClient.Action {
+		return tonapiClient.Action{
+			Type: tonapiClient.ActionTypeNftPurchase,
+			NftPurchase: tonapiClient.NewOptNftPurchaseAction(tonapiClient.NftPurchaseAction{
+				Seller: tonapiClient.AccountAddress{Address: seller.ToRaw()},
+				Buyer:  tonapiClient.AccountAddress{Address: buyer.ToRaw()},
+			}),
+		}
+	}
+	tests := []struct {
+		name    string
+		event   *tonapiClient.AccountEvent
+		filters SubscriptionFilters
+		want    []formattedAction
+	}{
+		{
+			name: "incoming transfer",
+			event: &tonapiClient.AccountEvent{
+				Actions: []tonapiClient.Action{tonTransfer(other, wallet, 1_000_000_000)},
+			},
+			want: []formattedAction{
+				{text: "Received <b>1 TON</b>", priority: telegram.PriorityNormal},
+			},
+		},
+		{
+			name: "outgoing transfer with fee",
+			event: &tonapiClient.AccountEvent{
+				Actions: []tonapiClient.Action{tonTransfer(wallet, other, 1_500_000_000)},
+				Extra:   -5_000_000,
+			},
+			want: []formattedAction{
+				{text: "Sent <b>1.5 TON</b> to <code>EQBszTJahYw3lpP64ryqscKQaDGk4QpsO7RO6LYVvKHSINS0</code>\nFee <b>0.005 TON</b>", priority: telegram.PriorityHigh},
+			},
+		},
+		{
+			name: "fee is added to the outgoing transfer only",
+			event: &tonapiClient.AccountEvent{
+				Actions: []tonapiClient.Action{
+					tonTransfer(wallet, other, 1_500_000_000),
+					tonTransfer(other, wallet, 1_000_000_000),
+				},
+				Extra: -5_000_000,
+			},
+			want: []formattedAction{
+				{text: "Sent <b>1.5 TON</b> to <code>EQBszTJahYw3lpP64ryqscKQaDGk4QpsO7RO6LYVvKHSINS0</code>\nFee <b>0.005 TON</b>", priority: telegram.PriorityHigh},
+				{text: "Received <b>1 TON</b>", priority: telegram.PriorityNormal},
+			},
+		},
+		{
+			name: "outgoing transfer is not filtered out",
+			event: &tonapiClient.AccountEvent{
+				Actions: []tonapiClient.Action{
+					tonTransfer(other, wallet, 1_000),
+					tonTransfer(wallet, other, 1_000),
+				},
+				Extra: -5_000_000,
+			},
+			filters: SubscriptionFilters{MinTonAmount: 1_000_000, Actions: []ActionType{ActionTypeJettonTransfer}},
+			want: []formattedAction{
+				{text: "Sent <b>0.000001 TON</b> to <code>EQBszTJahYw3lpP64ryqscKQaDGk4QpsO7RO6LYVvKHSINS0</code>\nFee <b>0.005 TON</b>", priority: telegram.PriorityHigh},
+			},
+		},
+		{
+			name: "sold nft",
+			event: &tonapiClient.AccountEvent{
+				Actions: []tonapiClient.Action{nftPurchase(wallet, other)},
+			},
+			filters: SubscriptionFilters{Actions: []ActionType{ActionTypeTonTransfer}},
+			want: []formattedAction{
+				{text: "Sold <b>NFT</b>", priority: telegram.PriorityHigh},
+			},
+		},
+		{
+			name: "bought nft",
+			event: &tonapiClient.AccountEvent{
+				Actions: []tonapiClient.Action{nftPurchase(other, wallet)},
+			},
+			want: []formattedAction{
+				{text: "Received <b>NFT</b>", priority: telegram.PriorityNormal},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, formatEvent(i18n.NewPrinter(i18n.English), wallet, tt.event, tt.filters))
+		})
+	}
+}
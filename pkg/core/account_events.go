@@ -3,7 +3,9 @@ package core
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
 	"net/http"
 	"sync"
 	"time"
@@ -18,6 +20,7 @@ import (
 	"go.uber.org/zap"
 	"golang.org/x/exp/maps"
 
+	"github.com/tonkeeper/tonkeeper-twa-api/pkg/i18n"
 	"github.com/tonkeeper/tonkeeper-twa-api/pkg/telegram"
 )
 
@@ -26,21 +29,38 @@ var (
 		Name: "twa_api_account_event_subscribers",
 		Help: "Number of account-events subscribers",
 	})
+	duplicateNotificationsCounter = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "twa_api_duplicate_notifications_counter",
+		Help: "Number of trace notifications skipped because the user has been already notified",
+	})
+)
+
+const (
+	// an event can be missing for a moment after its trace is received,
+	// so a rejected request is sent a few times before giving up.
+	fetchEventRejectedAttempts = 3
+	fetchEventMaxDelay         = time.Minute
+	notificationAckTimeout     = 5 * time.Second
 )
 
 type AccountEventsNotificator struct {
 	logger    *zap.Logger
 	storage   Storage
 	tonapiKey string
+	webAppURL string
 
-	client *tonapiClient.Client
+	client  *tonapiClient.Client
+	streams *accountStreams
+	traces  *tracePool
+	dedup   Deduplicator
 
 	mu               sync.RWMutex
 	subsPerUserID    map[telegram.UserID]map[ton.AccountID]struct{}
 	subsPerAccountID map[ton.AccountID]map[telegram.UserID]struct{}
 }
 
-func NewNotificator(logger *zap.Logger, storage Storage, tonapiKey string) (*AccountEventsNotificator, error) {
+func NewNotificator(logger *zap.Logger, storage Storage, tonapiKey string, opts ...Option) (*AccountEventsNotificator, error) {
+	options := applyOptions(opts)
 	if len(tonapiKey) > 0 {
 		req, err := http.NewRequest(http.MethodGet, "https://tonapi.io/v2/me", nil)
 		if err != nil {
@@ -82,14 +102,23 @@ func NewNotificator(logger *zap.Logger, storage Storage, tonapiKey string) (*Acc
 
 	accountEventsSubscribers.Set(float64(len(subsPerUserID)))
 
-	return &AccountEventsNotificator{
+	n := &AccountEventsNotificator{
 		tonapiKey:        tonapiKey,
+		webAppURL:        options.WebAppURL,
 		logger:           logger,
 		client:           cli,
 		storage:          storage,
 		subsPerAccountID: subsPerAccountID,
 		subsPerUserID:    subsPerUserID,
-	}, nil
+		traces:           newTracePool(options.TraceWorkers, options.TraceQueueSize, options.TraceOverflow),
+		dedup:            options.Deduplicator,
+	}
+	if n.dedup == nil {
+		n.dedup = NewMemoryDeduplicator(defaultDeduplicatorSize)
+	}
+	n.streams = newAccountStreams(logger, storage, tonapiKey, n.watchedAccounts)
+	n.streams.replicaID = options.ReplicaID
+	return n, nil
 }
 
 func (n *AccountEventsNotificator) Subscribe(userID telegram.UserID, account ton.Address) error {
@@ -98,6 +127,7 @@ func (n *AccountEventsNotificator) Subscribe(userID telegram.UserID, account ton
 	}
 	n.subscribe(userID, account)
 	n.updateMetrics()
+	n.streams.changed()
 	return nil
 }
 
@@ -131,6 +161,13 @@ func (n *AccountEventsNotificator) IsSubscribed(userID telegram.UserID, account
 	return ok
 }
 
+// Accounts returns accounts a user is subscribed to.
+func (n *AccountEventsNotificator) Accounts(userID telegram.UserID) []ton.AccountID {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return maps.Keys(n.subsPerUserID[userID])
+}
+
 func (n *AccountEventsNotificator) subscribedAccounts(accountIDs []ton.AccountID) []ton.AccountID {
 	n.mu.RLock()
 	defer n.mu.RUnlock()
@@ -143,22 +180,37 @@ func (n *AccountEventsNotificator) subscribedAccounts(accountIDs []ton.AccountID
 	return accounts
 }
 
-func (n *AccountEventsNotificator) unsubscribe(userID telegram.UserID) {
+// unsubscribe removes a subscription of a user to the given account or, if account is nil, all subscriptions of the user.
+func (n *AccountEventsNotificator) unsubscribe(userID telegram.UserID, account *ton.AccountID) {
 	n.mu.Lock()
 	defer n.mu.Unlock()
 	subs, ok := n.subsPerUserID[userID]
 	if !ok {
 		return
 	}
-	for account := range subs {
-		delete(n.subsPerAccountID[account], userID)
-		if len(n.subsPerAccountID[account]) == 0 {
-			delete(n.subsPerAccountID, account)
+	if account != nil {
+		n.removeSubscriber(*account, userID)
+		delete(subs, *account)
+		if len(subs) == 0 {
+			delete(n.subsPerUserID, userID)
 		}
+		return
+	}
+	for account := range subs {
+		n.removeSubscriber(account, userID)
 	}
 	delete(n.subsPerUserID, userID)
 }
 
+// removeSubscriber removes a user from subscribers of an account.
+// The caller must hold the lock.
+func (n *AccountEventsNotificator) removeSubscriber(account ton.AccountID, userID telegram.UserID) {
+	delete(n.subsPerAccountID[account], userID)
+	if len(n.subsPerAccountID[account]) == 0 {
+		delete(n.subsPerAccountID, account)
+	}
+}
+
 func (n *AccountEventsNotificator) accountSubscribers(account ton.AccountID) []telegram.UserID {
 	n.mu.RLock()
 	defer n.mu.RUnlock()
@@ -170,7 +222,32 @@ func (n *AccountEventsNotificator) accountSubscribers(account ton.AccountID) []t
 	return maps.Keys(subs)
 }
 
-func (n *AccountEventsNotificator) notify(accounts []ton.AccountID, hash string, messageCh chan<- telegram.Message) {
+// notify sends notifications about a trace to the subscribers of accounts.
+// done is called once all notifications have been passed on by notifier, that can happen after notify returns.
+// If the event of the trace hasn't been fetched or a notification hasn't been passed on, done isn't called,
+// so the stream position stays before the trace and it is received again after a restart.
+func (n *AccountEventsNotificator) notify(ctx context.Context, accounts []ton.AccountID, hash string, notifier Notifier, done func()) {
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		failed bool
+	)
+	fail := func() {
+		mu.Lock()
+		defer mu.Unlock()
+		failed = true
+	}
+	defer func() {
+		go func() {
+			wg.Wait()
+			mu.Lock()
+			defer mu.Unlock()
+			if !failed {
+				done()
+			}
+		}()
+	}()
+
 	rawAccounts := make([]string, 0, len(accounts))
 	for _, account := range accounts {
 		rawAccounts = append(rawAccounts, account.ToRaw())
@@ -185,88 +262,275 @@ func (n *AccountEventsNotificator) notify(accounts []ton.AccountID, hash string,
 		if len(subscribers) == 0 {
 			continue
 		}
-		var event *tonapiClient.AccountEvent
-		err := retry.Do(func() error {
-			params := tonapiClient.GetAccountEventParams{
-				AccountID: account.ToRaw(),
-				EventID:   hash,
-				SubjectOnly: tonapiClient.OptBool{
-					Value: true,
-					Set:   true,
-				},
-			}
-			e, err := n.client.GetAccountEvent(context.TODO(), params)
-			if err != nil {
-				return err
-			}
-			event = e
-			return nil
-		}, retry.Attempts(3), retry.Delay(1*time.Second))
+		event, err := n.fetchEvent(ctx, account, hash)
 		if err != nil {
-			n.logger.Error("GetAccountEvent() failed", zap.Error(err))
+			n.logger.Error("GetAccountEvent() failed",
+				zap.String("hash", hash),
+				zap.String("account", account.ToRaw()),
+				zap.Error(err))
+			if !isRejected(err) {
+				fail()
+			}
+			continue
+		}
+		// the same trace can be received again after a reconnect or by another replica.
+		subscribers = n.notNotified(ctx, hash, account, subscribers)
+		if len(subscribers) == 0 {
 			continue
 		}
-		msgs := formatMessages(account, event)
-		n.logger.Info("send-notification",
-			zap.String("hash", hash),
-			zap.Int("#messages", len(msgs)),
-			zap.Int("#subscribers", len(subscribers)))
+		languages, err := n.storage.GetLanguageCodes(ctx, subscribers)
+		if err != nil {
+			// better to notify in English than not to notify at all.
+			n.logger.Error("GetLanguageCodes() failed", zap.Error(err))
+		}
+		filters, err := n.storage.GetAccountSubscriptionFilters(ctx, account, subscribers)
+		if err != nil {
+			// better to notify about everything than not to notify at all.
+			n.logger.Error("GetAccountSubscriptionFilters() failed", zap.Error(err))
+		}
+		type localized struct {
+			messages []formattedAction
+			keyboard [][]telegram.Button
+		}
+		localize := func(language string, f SubscriptionFilters) localized {
+			printer := i18n.NewPrinter(language)
+			return localized{
+				messages: formatEvent(printer, account, event, f),
+				keyboard: n.eventKeyboard(printer, hash),
+			}
+		}
+		// most subscribers don't have filters and their texts depend on a language only,
+		// so we format them once per language.
+		perLanguage := map[string]localized{}
 		for _, userID := range subscribers {
-			for _, msg := range msgs {
-				messageCh <- telegram.Message{
-					UserID: userID,
-					Text:   msg,
+			language := i18n.Language(languages[userID])
+			var l localized
+			if f := filters[userID]; !f.IsZero() {
+				l = localize(language, f)
+			} else if cached, ok := perLanguage[language]; ok {
+				l = cached
+			} else {
+				l = localize(language, SubscriptionFilters{})
+				perLanguage[language] = l
+			}
+			n.logger.Info("send-notification",
+				zap.String("hash", hash),
+				zap.Int64("user_id", int64(userID)),
+				zap.Int("#messages", len(l.messages)))
+			wg.Add(1)
+			ack := n.ackNotification(ctx, NotificationKey{TraceHash: hash, Account: account, UserID: userID}, len(l.messages), func(err error) {
+				if err != nil {
+					fail()
 				}
+				wg.Done()
+			})
+			for _, m := range l.messages {
+				msg := telegram.Message{
+					UserID:    userID,
+					Text:      m.text,
+					ParseMode: telegram.ParseModeHTML,
+					Keyboard:  l.keyboard,
+					Priority:  m.priority,
+				}
+				notifyAck(ctx, notifier, msg, ack)
 			}
 		}
 	}
 }
 
-func (n *AccountEventsNotificator) sseSubscribe(ctx context.Context, messageCh chan<- telegram.Message) error {
-	sseClient := sse.NewClient("https://tonapi.io/v2/sse/accounts/traces?accounts=ALL")
-	if len(n.tonapiKey) > 0 {
-		sseClient.Headers["Authorization"] = fmt.Sprintf("Bearer %s", n.tonapiKey)
+// fetchEvent returns the event of a trace for an account.
+// An event is needed no matter how long TonAPI is unavailable, so it is fetched until ctx is done
+// unless TonAPI keeps rejecting the request.
+func (n *AccountEventsNotificator) fetchEvent(ctx context.Context, account ton.AccountID, hash string) (*tonapiClient.AccountEvent, error) {
+	params := tonapiClient.GetAccountEventParams{
+		AccountID: account.ToRaw(),
+		EventID:   hash,
+		SubjectOnly: tonapiClient.OptBool{
+			Value: true,
+			Set:   true,
+		},
 	}
-	return sseClient.Subscribe("", func(msg *sse.Event) {
-		switch string(msg.Event) {
-		case "heartbeat":
-			n.logger.Info("sse heartbeat")
-			return
-
-		case "message":
-			data := TraceEventData{}
+	var (
+		event    *tonapiClient.AccountEvent
+		rejected uint
+	)
+	err := retry.Do(func() error {
+		e, err := n.client.GetAccountEvent(ctx, params)
+		if err != nil {
+			return err
+		}
+		event = e
+		return nil
+	},
+		retry.Context(ctx),
+		retry.Attempts(math.MaxUint32),
+		retry.Delay(time.Second),
+		retry.MaxDelay(fetchEventMaxDelay),
+		retry.DelayType(retry.BackOffDelay),
+		retry.LastErrorOnly(true),
+		retry.RetryIf(func(err error) bool {
+			if !isRejected(err) {
+				return true
+			}
+			rejected++
+			return rejected < fetchEventRejectedAttempts
+		}))
+	return event, err
+}
 
-			n.logger.Info("trace event",
-				zap.String("event-id", string(msg.ID)))
+// isRejected returns true if TonAPI has rejected a request, so sending it again doesn't help.
+func isRejected(err error) bool {
+	var statusErr *tonapiClient.ErrorStatusCode
+	if !errors.As(err, &statusErr) {
+		return false
+	}
+	return statusErr.StatusCode >= 400 && statusErr.StatusCode < 500 && statusErr.StatusCode != http.StatusTooManyRequests
+}
 
-			if err := json.Unmarshal(msg.Data, &data); err != nil {
-				n.logger.Error("json.Unmarshal() failed",
-					zap.Error(err),
-					zap.String("data", string(msg.Data)))
-				return
-			}
-			if accounts := n.subscribedAccounts(data.AccountIDs); len(accounts) > 0 {
-				go n.notify(accounts, data.Hash, messageCh)
+// ackNotification returns a function to be called with the result of each of count messages of a notification.
+// Once all of them are passed on, the notification is confirmed or, if any has failed, released
+// to be sent when the trace is received again. Then done is called with the first error.
+func (n *AccountEventsNotificator) ackNotification(ctx context.Context, key NotificationKey, count int, done func(err error)) func(err error) {
+	var (
+		mu     sync.Mutex
+		failed error
+	)
+	finish := func() {
+		defer func() { done(failed) }()
+		// messages are passed on after ctx is done during a shutdown,
+		// their results are still recorded, so we use a fresh ctx.
+		ctx := ctx
+		if ctx.Err() != nil {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(context.Background(), notificationAckTimeout)
+			defer cancel()
+		}
+		if failed != nil {
+			n.logger.Error("notifier.Notify() failed",
+				zap.Int64("user_id", int64(key.UserID)),
+				zap.Error(failed))
+			if err := n.dedup.ReleaseNotified(ctx, key); err != nil {
+				n.logger.Error("ReleaseNotified() failed", zap.Error(err))
 			}
+			return
 		}
-	})
+		if err := n.dedup.ConfirmNotified(ctx, key, notificationDedupTTL); err != nil {
+			n.logger.Error("ConfirmNotified() failed", zap.Error(err))
+		}
+	}
+	if count == 0 {
+		finish()
+		return func(error) {}
+	}
+	return func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil && failed == nil {
+			failed = err
+		}
+		count--
+		if count == 0 {
+			finish()
+		}
+	}
 }
 
-func (n *AccountEventsNotificator) Run(ctx context.Context, messageCh chan<- telegram.Message) {
-	for {
-		if err := n.sseSubscribe(ctx, messageCh); err != nil {
-			n.logger.Error("sseClient.Subscribe() failed", zap.Error(err))
-			time.Sleep(10 * time.Second)
+// notNotified marks notifications about a trace as being sent and returns users who haven't been notified yet.
+// The marks are confirmed or released by ackNotification.
+func (n *AccountEventsNotificator) notNotified(ctx context.Context, hash string, account ton.AccountID, subscribers []telegram.UserID) []telegram.UserID {
+	result := make([]telegram.UserID, 0, len(subscribers))
+	for _, userID := range subscribers {
+		key := NotificationKey{TraceHash: hash, Account: account, UserID: userID}
+		first, err := n.dedup.MarkNotified(ctx, key, notificationClaimTTL)
+		if err != nil {
+			// a duplicate is better than a lost notification.
+			n.logger.Error("MarkNotified() failed", zap.Error(err))
+			first = true
 		}
+		if !first {
+			duplicateNotificationsCounter.Inc()
+			continue
+		}
+		result = append(result, userID)
+	}
+	return result
+}
+
+// eventKeyboard returns buttons to open the TWA and to look at the transaction in the explorer.
+func (n *AccountEventsNotificator) eventKeyboard(p i18n.Printer, hash string) [][]telegram.Button {
+	var buttons []telegram.Button
+	if len(n.webAppURL) > 0 {
+		buttons = append(buttons, telegram.Button{Text: p.Sprintf(i18n.OpenTonkeeperButton), WebAppURL: n.webAppURL + "/activity"})
 	}
+	buttons = append(buttons, telegram.Button{Text: p.Sprintf(i18n.ViewTransactionButton), URL: explorerTransactionURL + hash})
+	return [][]telegram.Button{buttons}
+}
+
+// handleEvent processes an event received from the TonAPI SSE stream.
+// done is called once the subscribers have been notified about the trace of the event.
+func (n *AccountEventsNotificator) handleEvent(msg *sse.Event, done func()) {
+	switch string(msg.Event) {
+	case "heartbeat":
+		n.logger.Info("sse heartbeat")
+		done()
+		return
+
+	case "message":
+		data := TraceEventData{}
+
+		n.logger.Info("trace event",
+			zap.String("event-id", string(msg.ID)))
+
+		if err := json.Unmarshal(msg.Data, &data); err != nil {
+			n.logger.Error("json.Unmarshal() failed",
+				zap.Error(err),
+				zap.String("data", string(msg.Data)))
+			done()
+			return
+		}
+		accounts := n.subscribedAccounts(data.AccountIDs)
+		if len(accounts) == 0 {
+			done()
+			return
+		}
+		if !n.traces.submit(traceJob{accounts: accounts, hash: data.Hash, done: done}) {
+			n.logger.Warn("trace queue is full, trace is dropped", zap.String("hash", data.Hash))
+			done()
+		}
+	default:
+		done()
+	}
+}
+
+// watchedAccounts returns accounts with at least one subscriber.
+func (n *AccountEventsNotificator) watchedAccounts() []ton.AccountID {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return maps.Keys(n.subsPerAccountID)
+}
+
+// Run listens to traces of the watched accounts and sends notifications about them until ctx is done.
+// It returns once the received events have been processed,
+// the traces whose events can't be fetched after ctx is done are received again after a restart.
+func (n *AccountEventsNotificator) Run(ctx context.Context, notifier Notifier) {
+	n.traces.start(func(job traceJob) {
+		n.notify(ctx, job.accounts, job.hash, notifier, job.done)
+	})
+	n.streams.run(ctx, n.handleEvent)
+	n.traces.stop()
+	// the positions of the traces whose notifications have been stored are saved,
+	// the rest are received again after a restart.
+	n.streams.flush()
 }
 
-func (n *AccountEventsNotificator) Unsubscribe(userID telegram.UserID) error {
-	if err := n.storage.UnsubscribeAccountEvents(context.TODO(), userID); err != nil {
+// Unsubscribe unsubscribes a telegram user from events of the given account or, if account is nil, of all accounts.
+func (n *AccountEventsNotificator) Unsubscribe(userID telegram.UserID, account *ton.AccountID) error {
+	if err := n.storage.UnsubscribeAccountEvents(context.TODO(), userID, account); err != nil {
 		return err
 	}
-	n.unsubscribe(userID)
+	n.unsubscribe(userID, account)
 	n.updateMetrics()
+	n.streams.changed()
 	return nil
 }
 
@@ -0,0 +1,156 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	tonapiClient "github.com/tonkeeper/opentonapi/client"
+	"github.com/tonkeeper/tongo/ton"
+	"go.uber.org/zap"
+
+	"github.com/tonkeeper/tonkeeper-twa-api/pkg/telegram"
+)
+
+func TestAccountEventsNotificator_Unsubscribe(t *testing.T) {
+	first := ton.MustParseAccountID("0:6ccd325a858c379693fae2bcaab1c2906831a4e10a6c3bb44ee8b615bca1d220")
+	second := ton.MustParseAccountID("0:bdf3fa8098d129b54b4f73b5bac5d1e1fd91eb054169c3916dfc8ccd536d1000")
+	tests := []struct {
+		name                 string
+		userID               telegram.UserID
+		account              *ton.AccountID
+		wantSubsPerUserID    map[telegram.UserID]map[ton.AccountID]struct{}
+		wantSubsPerAccountID map[ton.AccountID]map[telegram.UserID]struct{}
+	}{
+		{
+			name:   "all accounts",
+			userID: 1,
+			wantSubsPerUserID: map[telegram.UserID]map[ton.AccountID]struct{}{
+				2: {first: {}},
+			},
+			wantSubsPerAccountID: map[ton.AccountID]map[telegram.UserID]struct{}{
+				first: {2: {}},
+			},
+		},
+		{
+			name:    "single account",
+			userID:  1,
+			account: &second,
+			wantSubsPerUserID: map[telegram.UserID]map[ton.AccountID]struct{}{
+				1: {first: {}},
+				2: {first: {}},
+			},
+			wantSubsPerAccountID: map[ton.AccountID]map[telegram.UserID]struct{}{
+				first: {1: {}, 2: {}},
+			},
+		},
+		{
+			name:    "last account of a user",
+			userID:  2,
+			account: &first,
+			wantSubsPerUserID: map[telegram.UserID]map[ton.AccountID]struct{}{
+				1: {first: {}, second: {}},
+			},
+			wantSubsPerAccountID: map[ton.AccountID]map[telegram.UserID]struct{}{
+				first:  {1: {}},
+				second: {1: {}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := &AccountEventsNotificator{
+				logger:  zap.L(),
+				storage: &mockStorage{},
+				streams: newAccountStreams(zap.L(), &mockStorage{}, "", nil),
+				subsPerUserID: map[telegram.UserID]map[ton.AccountID]struct{}{
+					1: {first: {}, second: {}},
+					2: {first: {}},
+				},
+				subsPerAccountID: map[ton.AccountID]map[telegram.UserID]struct{}{
+					first:  {1: {}, 2: {}},
+					second: {1: {}},
+				},
+			}
+			err := n.Unsubscribe(tt.userID, tt.account)
+			require.Nil(t, err)
+			require.Equal(t, tt.wantSubsPerUserID, n.subsPerUserID)
+			require.Equal(t, tt.wantSubsPerAccountID, n.subsPerAccountID)
+		})
+	}
+}
+
+func TestAccountEventsNotificator_ackNotification(t *testing.T) {
+	key := NotificationKey{
+		TraceHash: "hash",
+		Account:   ton.MustParseAccountID("0:6ccd325a858c379693fae2bcaab1c2906831a4e10a6c3bb44ee8b615bca1d220"),
+		UserID:    1,
+	}
+	tests := []struct {
+		name      string
+		errs      []error
+		wantFirst bool
+	}{
+		{
+			name:      "all messages are stored",
+			errs:      []error{nil, nil},
+			wantFirst: false,
+		},
+		{
+			name:      "a message has failed",
+			errs:      []error{nil, errors.New("outbox is unavailable")},
+			wantFirst: true,
+		},
+		{
+			name:      "nothing to send",
+			wantFirst: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dedup := NewMemoryDeduplicator(10)
+			n := &AccountEventsNotificator{logger: zap.L(), dedup: dedup}
+			first, err := dedup.MarkNotified(context.Background(), key, notificationClaimTTL)
+			require.Nil(t, err)
+			require.True(t, first)
+
+			done := 0
+			var doneErr error
+			ack := n.ackNotification(context.Background(), key, len(tt.errs), func(err error) {
+				done++
+				doneErr = err
+			})
+			for _, err := range tt.errs {
+				require.Equal(t, 0, done)
+				ack(err)
+			}
+			require.Equal(t, 1, done)
+			require.Equal(t, tt.wantFirst, doneErr != nil)
+
+			first, err = dedup.MarkNotified(context.Background(), key, notificationClaimTTL)
+			require.Nil(t, err)
+			require.Equal(t, tt.wantFirst, first)
+		})
+	}
+}
+
+func Test_isRejected(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "not found", err: &tonapiClient.ErrorStatusCode{StatusCode: http.StatusNotFound}, want: true},
+		{name: "too many requests", err: &tonapiClient.ErrorStatusCode{StatusCode: http.StatusTooManyRequests}},
+		{name: "internal error", err: &tonapiClient.ErrorStatusCode{StatusCode: http.StatusInternalServerError}},
+		{name: "network error", err: errors.New("connection reset by peer")},
+		{name: "context", err: context.Canceled},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, isRejected(tt.err))
+		})
+	}
+}
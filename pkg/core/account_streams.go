@@ -0,0 +1,414 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/r3labs/sse/v2"
+	"github.com/tonkeeper/tongo/ton"
+	"go.uber.org/zap"
+	"gopkg.in/cenkalti/backoff.v1"
+)
+
+var (
+	sseStreams = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "twa_api_sse_streams",
+		Help: "Number of TonAPI SSE connections listening to watched accounts",
+	})
+	sseReconnectGap = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "twa_api_sse_reconnect_gap_seconds",
+		Help:    "Time between a disconnect from the TonAPI SSE stream and a successful reconnect",
+		Buckets: prometheus.ExponentialBuckets(1, 2, 12),
+	})
+	sseStreamGaps = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "twa_api_sse_stream_gaps_counter",
+		Help: "Number of times the TonAPI SSE stream couldn't be resumed and events were lost",
+	})
+)
+
+const (
+	accountTracesURL = "https://tonapi.io/v2/sse/accounts/traces"
+	// accountTracesStream identifies TonAPI SSE streams in storage.
+	// Shards are named "account-traces/N" or, if a replica ID is set, "account-traces/REPLICA/N",
+	// the name itself belongs to the single stream of all accounts used before sharding.
+	accountTracesStream = "account-traces"
+	// accountsPerStream limits the number of accounts listened to by a single SSE connection
+	// to keep its URL short.
+	accountsPerStream = 100
+	// streamRebuildDelay collects changes of the watched accounts,
+	// so a burst of subscriptions rebuilds a connection once.
+	streamRebuildDelay   = time.Second
+	streamReconnectDelay = 10 * time.Second
+)
+
+// accountStreams listens to traces of the watched accounts with several SSE connections to TonAPI,
+// every connection listens to its own shard of accounts.
+// When the watched accounts change, only the connections whose shards have changed are rebuilt.
+type accountStreams struct {
+	logger    *zap.Logger
+	storage   Storage
+	tonapiKey string
+	shardSize int
+	// replicaID is a part of the shard names, so replicas listening to the same accounts
+	// don't overwrite positions of each other.
+	replicaID string
+	// watched returns accounts to listen to.
+	watched func() []ton.AccountID
+	// handle processes events received by all connections.
+	handle eventHandler
+
+	changes chan struct{}
+	// shards are never removed, so a shard keeps its name and position in storage.
+	// A shard without accounts has no connection.
+	shards []*accountStream
+	// checkpoints tracks goroutines saving positions of the shards.
+	checkpoints sync.WaitGroup
+	// legacy is the position of the single stream used before sharding.
+	// Shards created at startup without their own positions are resumed from it.
+	legacy *StreamPosition
+}
+
+// eventHandler processes an event received from an SSE stream and calls done once the event is processed,
+// the stream position moves past the event only after that. done can be called by another goroutine.
+type eventHandler func(msg *sse.Event, done func())
+
+// accountStream is an SSE connection listening to a shard of accounts.
+type accountStream struct {
+	logger     *zap.Logger
+	storage    Storage
+	tonapiKey  string
+	name       string
+	accounts   map[ton.AccountID]struct{}
+	checkpoint *streamCheckpoint
+
+	cancel context.CancelFunc
+	done   chan struct{}
+	// disconnectedAt is when the connection was lost, zero if it hasn't been connected yet or is connected now.
+	// It is accessed by the goroutine running the connection only.
+	disconnectedAt time.Time
+}
+
+func newAccountStreams(logger *zap.Logger, storage Storage, tonapiKey string, watched func() []ton.AccountID) *accountStreams {
+	return &accountStreams{
+		logger:    logger,
+		storage:   storage,
+		tonapiKey: tonapiKey,
+		shardSize: accountsPerStream,
+		watched:   watched,
+		changes:   make(chan struct{}, 1),
+	}
+}
+
+// changed tells that the watched accounts have changed.
+func (s *accountStreams) changed() {
+	select {
+	case s.changes <- struct{}{}:
+	default:
+	}
+}
+
+// run keeps the connections in line with the watched accounts until ctx is done.
+// It returns after all connections are closed, the final positions are saved by flush.
+func (s *accountStreams) run(ctx context.Context, handle eventHandler) {
+	s.handle = handle
+	s.loadLegacy(ctx)
+	s.reconcile(ctx)
+	s.retireLegacy(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			for _, shard := range s.shards {
+				shard.stop()
+			}
+			s.checkpoints.Wait()
+			return
+		case <-s.changes:
+		}
+		select {
+		case <-ctx.Done():
+			continue
+		case <-time.After(streamRebuildDelay):
+		}
+		s.reconcile(ctx)
+	}
+}
+
+// flush saves the positions of all shards.
+// It is called after run returns and the received events are processed,
+// ctx of run is done by then, so flush has its own timeout.
+func (s *accountStreams) flush() {
+	ctx, cancel := context.WithTimeout(context.Background(), streamFlushTimeout)
+	defer cancel()
+	for _, shard := range s.shards {
+		if err := shard.checkpoint.flush(ctx); err != nil {
+			shard.logger.Error("failed to save sse stream position", zap.Error(err))
+		}
+	}
+}
+
+// loadLegacy loads the position of the single stream used before sharding, if it is still saved.
+func (s *accountStreams) loadLegacy(ctx context.Context) {
+	position, err := s.storage.GetStreamPosition(ctx, accountTracesStream)
+	if err != nil {
+		s.logger.Error("failed to load legacy sse stream position", zap.Error(err))
+		return
+	}
+	if len(position.EventID) > 0 {
+		s.legacy = &position
+	}
+}
+
+// retireLegacy deletes the position of the single stream once the shards resumed from it have saved their own ones,
+// so shards created later don't start from a stale position.
+func (s *accountStreams) retireLegacy(ctx context.Context) {
+	if s.legacy == nil {
+		return
+	}
+	s.legacy = nil
+	for _, shard := range s.shards {
+		if err := shard.checkpoint.flush(ctx); err != nil {
+			shard.logger.Error("failed to save sse stream position", zap.Error(err))
+			return
+		}
+	}
+	if err := s.storage.DeleteStreamPosition(ctx, accountTracesStream); err != nil {
+		s.logger.Error("failed to delete legacy sse stream position", zap.Error(err))
+	}
+}
+
+func (s *accountStreams) reconcile(ctx context.Context) {
+	created := len(s.shards)
+	changed := s.assign(ctx, s.watched())
+	for _, shard := range s.shards[created:] {
+		s.checkpoints.Add(1)
+		go func(shard *accountStream) {
+			defer s.checkpoints.Done()
+			shard.checkpoint.run(ctx, streamCheckpointInterval)
+		}(shard)
+	}
+	for _, shard := range changed {
+		shard.restart(ctx, s.handle)
+	}
+	var active int
+	for _, shard := range s.shards {
+		if len(shard.accounts) > 0 {
+			active++
+		}
+	}
+	sseStreams.Set(float64(active))
+}
+
+// assign distributes the watched accounts among shards and returns the shards that have changed.
+// Accounts stay in their shards, new accounts go to the first shards with free room.
+func (s *accountStreams) assign(ctx context.Context, watched []ton.AccountID) []*accountStream {
+	watchedSet := make(map[ton.AccountID]struct{}, len(watched))
+	for _, account := range watched {
+		watchedSet[account] = struct{}{}
+	}
+	changed := map[*accountStream]bool{}
+	assigned := make(map[ton.AccountID]struct{}, len(watched))
+	for _, shard := range s.shards {
+		for account := range shard.accounts {
+			if _, ok := watchedSet[account]; !ok {
+				delete(shard.accounts, account)
+				changed[shard] = true
+				continue
+			}
+			assigned[account] = struct{}{}
+		}
+	}
+	var missing []ton.AccountID
+	for account := range watchedSet {
+		if _, ok := assigned[account]; !ok {
+			missing = append(missing, account)
+		}
+	}
+	// sorted accounts fall into the same shards after a restart,
+	// so the shards are resumed from their saved positions.
+	sort.Slice(missing, func(i, j int) bool {
+		return missing[i].ToRaw() < missing[j].ToRaw()
+	})
+	for _, account := range missing {
+		shard := s.shardWithRoom(ctx)
+		shard.accounts[account] = struct{}{}
+		changed[shard] = true
+	}
+	var result []*accountStream
+	for _, shard := range s.shards {
+		if changed[shard] {
+			result = append(result, shard)
+		}
+	}
+	return result
+}
+
+func (s *accountStreams) shardWithRoom(ctx context.Context) *accountStream {
+	for _, shard := range s.shards {
+		if len(shard.accounts) < s.shardSize {
+			return shard
+		}
+	}
+	name := fmt.Sprintf("%s/%d", accountTracesStream, len(s.shards))
+	if len(s.replicaID) > 0 {
+		name = fmt.Sprintf("%s/%s/%d", accountTracesStream, s.replicaID, len(s.shards))
+	}
+	shard := &accountStream{
+		logger:     s.logger.With(zap.String("stream", name)),
+		storage:    s.storage,
+		tonapiKey:  s.tonapiKey,
+		name:       name,
+		accounts:   map[ton.AccountID]struct{}{},
+		checkpoint: newStreamCheckpoint(s.logger, s.storage, name),
+	}
+	if err := shard.checkpoint.load(ctx); err != nil {
+		// new events can be still processed, but we don't know where the previous run stopped.
+		s.logger.Error("failed to load sse stream position", zap.String("stream", name), zap.Error(err))
+	} else if s.legacy != nil && len(shard.checkpoint.last().EventID) == 0 {
+		// the shard listens to accounts the single stream listened to, so it continues where that stream stopped.
+		shard.checkpoint.seed(*s.legacy)
+	}
+	s.shards = append(s.shards, shard)
+	return shard
+}
+
+// restart replaces the connection with a new one listening to the current accounts of the shard.
+// The new connection is resumed from the last event processed by the old one.
+func (s *accountStream) restart(ctx context.Context, handle eventHandler) {
+	s.stop()
+	if len(s.accounts) == 0 {
+		return
+	}
+	rawAccounts := make([]string, 0, len(s.accounts))
+	for account := range s.accounts {
+		rawAccounts = append(rawAccounts, account.ToRaw())
+	}
+	sort.Strings(rawAccounts)
+	url := accountTracesURL + "?accounts=" + strings.Join(rawAccounts, ",")
+
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	s.cancel = cancel
+	s.done = done
+	go func() {
+		defer close(done)
+		s.run(ctx, url, handle)
+	}()
+}
+
+// stop closes the connection and waits until it is closed.
+func (s *accountStream) stop() {
+	if s.cancel == nil {
+		return
+	}
+	s.cancel()
+	<-s.done
+	s.cancel = nil
+	s.done = nil
+	s.disconnected(time.Now())
+}
+
+func (s *accountStream) run(ctx context.Context, url string, handle eventHandler) {
+	for {
+		err := s.subscribe(ctx, url, handle)
+		if ctx.Err() != nil {
+			return
+		}
+		s.disconnected(time.Now())
+		s.logger.Error("sseClient.Subscribe() failed", zap.Error(err))
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(streamReconnectDelay):
+		}
+	}
+}
+
+// disconnected remembers when the connection was lost unless it is already known.
+func (s *accountStream) disconnected(now time.Time) {
+	if s.disconnectedAt.IsZero() {
+		s.disconnectedAt = now
+	}
+}
+
+func (s *accountStream) subscribe(ctx context.Context, url string, handle eventHandler) error {
+	sseClient := sse.NewClient(url)
+	if len(s.tonapiKey) > 0 {
+		sseClient.Headers["Authorization"] = fmt.Sprintf("Bearer %s", s.tonapiKey)
+	}
+	sseClient.ReconnectStrategy = backoff.WithContext(backoff.NewExponentialBackOff(), ctx)
+	// the client sends Last-Event-ID itself when it reconnects,
+	// we only need to start it from the position saved before.
+	if position := s.checkpoint.last(); len(position.EventID) > 0 {
+		sseClient.LastEventID.Store([]byte(position.EventID))
+	}
+	sseClient.ResponseValidator = s.validateResponse
+	// the client reconnects itself after errors.
+	sseClient.ReconnectNotify = func(err error, d time.Duration) {
+		s.disconnected(time.Now())
+	}
+	return sseClient.SubscribeWithContext(ctx, "", func(msg *sse.Event) {
+		if string(msg.Event) != "message" {
+			handle(msg, func() {})
+			return
+		}
+		seq := s.checkpoint.received(string(msg.ID))
+		handle(msg, func() {
+			s.checkpoint.processed(seq, time.Now())
+		})
+	})
+}
+
+// validateResponse is called every time the client connects to the SSE stream.
+func (s *accountStream) validateResponse(c *sse.Client, resp *http.Response) error {
+	position := s.checkpoint.last()
+	if resp.StatusCode == http.StatusOK {
+		if !s.disconnectedAt.IsZero() {
+			sseReconnectGap.Observe(time.Since(s.disconnectedAt).Seconds())
+			s.disconnectedAt = time.Time{}
+		}
+		return nil
+	}
+	resp.Body.Close()
+	lastEventID, _ := c.LastEventID.Load().([]byte)
+	if len(lastEventID) > 0 && cannotResume(resp.StatusCode) {
+		// we start from now and leave the lost events to a backfill.
+		// The saved position is reset as well, otherwise the next connection would hit the same gap.
+		now := time.Now()
+		s.saveGap(StreamGap{
+			Stream:      s.name,
+			LastEventID: string(lastEventID),
+			From:        position.EventAt,
+			To:          now,
+		})
+		s.checkpoint.reset(now)
+		c.LastEventID.Store([]byte(nil))
+	}
+	return fmt.Errorf("could not connect to stream: %s", http.StatusText(resp.StatusCode))
+}
+
+// cannotResume returns true if the status means the server doesn't know the event from Last-Event-ID.
+func cannotResume(statusCode int) bool {
+	switch statusCode {
+	case http.StatusBadRequest, http.StatusNotFound, http.StatusGone:
+		return true
+	}
+	return false
+}
+
+func (s *accountStream) saveGap(gap StreamGap) {
+	sseStreamGaps.Inc()
+	s.logger.Warn("sse stream can't be resumed, events are lost",
+		zap.String("last_event_id", gap.LastEventID),
+		zap.Time("from", gap.From),
+		zap.Time("to", gap.To))
+	if err := s.storage.SaveStreamGap(context.TODO(), gap); err != nil {
+		s.logger.Error("SaveStreamGap() failed", zap.Error(err))
+	}
+}
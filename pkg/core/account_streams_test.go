@@ -0,0 +1,211 @@
+package core
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/r3labs/sse/v2"
+	"github.com/stretchr/testify/require"
+	"github.com/tonkeeper/tongo/ton"
+	"go.uber.org/zap"
+)
+
+func Test_accountStreams_assign(t *testing.T) {
+	a := ton.MustParseAccountID("0:0000000000000000000000000000000000000000000000000000000000000001")
+	b := ton.MustParseAccountID("0:0000000000000000000000000000000000000000000000000000000000000002")
+	c := ton.MustParseAccountID("0:0000000000000000000000000000000000000000000000000000000000000003")
+	d := ton.MustParseAccountID("0:0000000000000000000000000000000000000000000000000000000000000004")
+
+	s := newAccountStreams(zap.L(), &mockStorage{}, "", nil)
+	s.shardSize = 2
+	ctx := context.Background()
+
+	shardAccounts := func() []map[ton.AccountID]struct{} {
+		var result []map[ton.AccountID]struct{}
+		for _, shard := range s.shards {
+			result = append(result, shard.accounts)
+		}
+		return result
+	}
+
+	changed := s.assign(ctx, []ton.AccountID{c, a, b})
+	require.Len(t, changed, 2)
+	require.Equal(t, []map[ton.AccountID]struct{}{
+		{a: {}, b: {}},
+		{c: {}},
+	}, shardAccounts())
+	require.Equal(t, "account-traces/1", s.shards[1].name)
+
+	// nothing has changed.
+	require.Empty(t, s.assign(ctx, []ton.AccountID{a, b, c}))
+
+	// only the first shard loses an account, so only it is rebuilt.
+	changed = s.assign(ctx, []ton.AccountID{b, c})
+	require.Equal(t, []*accountStream{s.shards[0]}, changed)
+
+	// the free room in the first shard is used before the second shard.
+	changed = s.assign(ctx, []ton.AccountID{b, c, d})
+	require.Equal(t, []*accountStream{s.shards[0]}, changed)
+	require.Equal(t, []map[ton.AccountID]struct{}{
+		{b: {}, d: {}},
+		{c: {}},
+	}, shardAccounts())
+
+	// empty shards are kept to be reused later.
+	changed = s.assign(ctx, []ton.AccountID{b, d})
+	require.Equal(t, []*accountStream{s.shards[1]}, changed)
+	require.Len(t, s.shards, 2)
+	require.Empty(t, s.shards[1].accounts)
+}
+
+func Test_accountStream_validateResponse(t *testing.T) {
+	eventAt := time.Now().Add(-time.Minute)
+	disconnectedAt := time.Now().Add(-time.Second)
+	tests := []struct {
+		name            string
+		statusCode      int
+		wantErr         bool
+		wantGap         bool
+		wantLastEventID string
+		// wantDisconnected is true if the stream is still disconnected after the response.
+		wantDisconnected bool
+	}{
+		{
+			name:            "resumed",
+			statusCode:      http.StatusOK,
+			wantLastEventID: "100",
+		},
+		{
+			name:             "can't resume",
+			statusCode:       http.StatusGone,
+			wantErr:          true,
+			wantGap:          true,
+			wantDisconnected: true,
+		},
+		{
+			name:             "unauthorized",
+			statusCode:       http.StatusUnauthorized,
+			wantErr:          true,
+			wantLastEventID:  "100",
+			wantDisconnected: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gaps []StreamGap
+			s := &mockStorage{
+				OnSaveStreamGap: func(ctx context.Context, gap StreamGap) error {
+					gaps = append(gaps, gap)
+					return nil
+				},
+			}
+			stream := &accountStream{
+				logger:         zap.L(),
+				storage:        s,
+				name:           accountTracesStream + "/0",
+				checkpoint:     newStreamCheckpoint(zap.L(), s, accountTracesStream+"/0"),
+				disconnectedAt: disconnectedAt,
+			}
+			stream.checkpoint.processed(stream.checkpoint.received("100"), eventAt)
+			c := sse.NewClient(accountTracesURL)
+			c.LastEventID.Store([]byte("100"))
+
+			resp := &http.Response{StatusCode: tt.statusCode, Body: io.NopCloser(strings.NewReader(""))}
+			err := stream.validateResponse(c, resp)
+			if tt.wantErr {
+				require.NotNil(t, err)
+			} else {
+				require.Nil(t, err)
+			}
+			lastEventID, _ := c.LastEventID.Load().([]byte)
+			require.Equal(t, tt.wantLastEventID, string(lastEventID))
+			// the reconnect gap is measured from the disconnect until a successful reconnect.
+			require.Equal(t, tt.wantDisconnected, !stream.disconnectedAt.IsZero())
+			if !tt.wantGap {
+				require.Empty(t, gaps)
+				return
+			}
+			require.Len(t, gaps, 1)
+			require.Equal(t, accountTracesStream+"/0", gaps[0].Stream)
+			require.Equal(t, "100", gaps[0].LastEventID)
+			require.Equal(t, eventAt, gaps[0].From)
+			// the next connection starts from now instead of hitting the gap again.
+			require.Empty(t, stream.checkpoint.last().EventID)
+			require.Equal(t, gaps[0].To, stream.checkpoint.last().EventAt)
+		})
+	}
+}
+
+func Test_accountStreams_legacy(t *testing.T) {
+	a := ton.MustParseAccountID("0:0000000000000000000000000000000000000000000000000000000000000001")
+	b := ton.MustParseAccountID("0:0000000000000000000000000000000000000000000000000000000000000002")
+	legacy := StreamPosition{EventID: "100", EventAt: time.Now()}
+
+	saved := map[string]StreamPosition{}
+	var deleted []string
+	s := newAccountStreams(zap.L(), &mockStorage{
+		OnGetStreamPosition: func(ctx context.Context, stream string) (StreamPosition, error) {
+			if stream == accountTracesStream {
+				return legacy, nil
+			}
+			return StreamPosition{}, nil
+		},
+		OnSaveStreamPosition: func(ctx context.Context, stream string, position StreamPosition) error {
+			saved[stream] = position
+			return nil
+		},
+		OnDeleteStreamPosition: func(ctx context.Context, stream string) error {
+			deleted = append(deleted, stream)
+			return nil
+		},
+	}, "", nil)
+	s.shardSize = 1
+	ctx := context.Background()
+
+	// shards created at startup continue where the single stream stopped.
+	s.loadLegacy(ctx)
+	s.assign(ctx, []ton.AccountID{a})
+	require.Equal(t, legacy, s.shards[0].checkpoint.last())
+
+	s.retireLegacy(ctx)
+	require.Equal(t, map[string]StreamPosition{accountTracesStream + "/0": legacy}, saved)
+	require.Equal(t, []string{accountTracesStream}, deleted)
+
+	// shards created later start from scratch.
+	s.assign(ctx, []ton.AccountID{a, b})
+	require.Equal(t, StreamPosition{}, s.shards[1].checkpoint.last())
+}
+
+func Test_accountStreams_replicaID(t *testing.T) {
+	a := ton.MustParseAccountID("0:0000000000000000000000000000000000000000000000000000000000000001")
+	saved := map[string]StreamPosition{}
+	s := &mockStorage{
+		OnGetStreamPosition: func(ctx context.Context, stream string) (StreamPosition, error) {
+			return saved[stream], nil
+		},
+		OnSaveStreamPosition: func(ctx context.Context, stream string, position StreamPosition) error {
+			saved[stream] = position
+			return nil
+		},
+	}
+	ctx := context.Background()
+	now := time.Now()
+
+	// replicas listening to the same account keep their positions apart.
+	for _, replica := range []string{"api-0", "api-1"} {
+		streams := newAccountStreams(zap.L(), s, "", nil)
+		streams.replicaID = replica
+		streams.assign(ctx, []ton.AccountID{a})
+		shard := streams.shards[0]
+		shard.checkpoint.processed(shard.checkpoint.received(replica), now)
+		require.Nil(t, shard.checkpoint.flush(ctx))
+	}
+	require.Equal(t, map[string]StreamPosition{
+		accountTracesStream + "/api-0/0": {EventID: "api-0", EventAt: now},
+		accountTracesStream + "/api-1/0": {EventID: "api-1", EventAt: now},
+	}, saved)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"go.uber.org/zap"
 
+	"github.com/tonkeeper/tonkeeper-twa-api/pkg/i18n"
 	"github.com/tonkeeper/tonkeeper-twa-api/pkg/telegram"
 )
 
@@ -26,7 +27,8 @@ type Bridge struct {
 	logger *zap.Logger
 
 	storage   Storage
-	messageCh chan<- telegram.Message
+	notifier  Notifier
+	webAppURL string
 
 	mu               sync.RWMutex
 	subsPerClientID  map[ClientID]bridgeSubscription
@@ -40,7 +42,8 @@ var (
 	})
 )
 
-func NewBridge(logger *zap.Logger, storage Storage, messageCh chan<- telegram.Message) (*Bridge, error) {
+func NewBridge(logger *zap.Logger, storage Storage, notifier Notifier, opts ...Option) (*Bridge, error) {
+	options := applyOptions(opts)
 	subscriptions, err := storage.GetBridgeSubscriptions(context.TODO())
 	if err != nil {
 		return nil, err
@@ -61,18 +64,20 @@ func NewBridge(logger *zap.Logger, storage Storage, messageCh chan<- telegram.Me
 	return &Bridge{
 		logger:           logger,
 		storage:          storage,
-		messageCh:        messageCh,
+		notifier:         notifier,
+		webAppURL:        options.WebAppURL,
 		subsPerClientID:  subsPerClientID,
 		clientIDsPerUser: clientIDsPerUser,
 	}, nil
 }
 
-func formatMessage(topic string, origin string) (string, error) {
+// formatMessage returns a text of a notification formatted with telegram.ParseModeHTML.
+func formatMessage(p i18n.Printer, topic string, origin string) (string, error) {
 	switch topic {
 	case "sendTransaction":
-		return fmt.Sprintf("Transaction for %v", origin), nil
+		return p.Sprintf(i18n.TransactionRequest, telegram.EscapeHTML(origin)), nil
 	case "signData":
-		return fmt.Sprintf("Data signature request %v", origin), nil
+		return p.Sprintf(i18n.SignDataRequest, telegram.EscapeHTML(origin)), nil
 	default:
 		return "", fmt.Errorf("unknown topic")
 	}
@@ -84,15 +89,36 @@ func (b *Bridge) HandleWebhook(clientID ClientID, topic string) {
 	if !ok {
 		return
 	}
-	msg, err := formatMessage(topic, subscription.Origin)
+	p := i18n.NewPrinter(b.language(subscription.UserID))
+	msg, err := formatMessage(p, topic, subscription.Origin)
 	if err != nil {
 		b.logger.Error("failed to format message", zap.Error(err))
 		return
 	}
-	b.messageCh <- telegram.Message{
-		UserID: subscription.UserID,
-		Text:   msg,
+	message := telegram.Message{
+		UserID:    subscription.UserID,
+		Text:      msg,
+		ParseMode: telegram.ParseModeHTML,
 	}
+	if len(b.webAppURL) > 0 {
+		message.Keyboard = [][]telegram.Button{
+			{{Text: p.Sprintf(i18n.OpenTonkeeperButton), WebAppURL: b.webAppURL}},
+		}
+	}
+	if err := b.notifier.Notify(context.TODO(), message); err != nil {
+		b.logger.Error("notifier.Notify() failed",
+			zap.Int64("user_id", int64(subscription.UserID)),
+			zap.Error(err))
+	}
+}
+
+// language returns a language of notifications for a given user.
+func (b *Bridge) language(userID telegram.UserID) string {
+	languages, err := b.storage.GetLanguageCodes(context.TODO(), []telegram.UserID{userID})
+	if err != nil {
+		b.logger.Error("GetLanguageCodes() failed", zap.Error(err))
+	}
+	return i18n.Language(languages[userID])
 }
 
 // Subscribe subscribes a telegram user to the HTTP Bridge events.
@@ -119,6 +145,19 @@ func (b *Bridge) Unsubscribe(userID telegram.UserID, clientID *ClientID) error {
 	return nil
 }
 
+// Origins returns origins of dApps a user is subscribed to.
+func (b *Bridge) Origins(userID telegram.UserID) []string {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	var origins []string
+	for clientID := range b.clientIDsPerUser[userID] {
+		if sub, ok := b.subsPerClientID[clientID]; ok {
+			origins = append(origins, sub.Origin)
+		}
+	}
+	return origins
+}
+
 func (b *Bridge) subscribe(userID telegram.UserID, clientID ClientID, origin string) {
 	b.mu.Lock()
 	defer b.mu.Unlock()
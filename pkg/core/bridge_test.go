@@ -159,13 +159,13 @@ func TestBridge_HandleWebhook(t *testing.T) {
 			name:     "sendTransaction",
 			clientID: "1001",
 			topic:    "sendTransaction",
-			wantMsgs: []telegram.Message{{UserID: 1, Text: "Transaction for ton.org"}},
+			wantMsgs: []telegram.Message{{UserID: 1, Text: "Transaction for <b>ton.org</b>", ParseMode: telegram.ParseModeHTML}},
 		},
 		{
 			name:     "signData",
 			clientID: "2002",
 			topic:    "signData",
-			wantMsgs: []telegram.Message{{UserID: 2, Text: "Data signature request dns.ton.org"}},
+			wantMsgs: []telegram.Message{{UserID: 2, Text: "Data signature request <b>dns.ton.org</b>", ParseMode: telegram.ParseModeHTML}},
 		},
 		{
 			name:     "no client_id -> no message",
@@ -180,9 +180,10 @@ func TestBridge_HandleWebhook(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			messageCh := make(chan telegram.Message, 1)
+			recorder := &RecordingNotifier{}
 			b := &Bridge{
-				logger: zap.L(),
+				logger:  zap.L(),
+				storage: &mockStorage{},
 				subsPerClientID: map[ClientID]bridgeSubscription{
 					"1001": {Origin: "ton.org", UserID: 1},
 					"1002": {Origin: "dex.ton", UserID: 1},
@@ -192,16 +193,10 @@ func TestBridge_HandleWebhook(t *testing.T) {
 					1: {"1000": {}, "1001": {}, "1002": {}},
 					2: {"2002": {}},
 				},
-				messageCh: messageCh,
+				notifier: recorder,
 			}
 			b.HandleWebhook(tt.clientID, tt.topic)
-			close(messageCh)
-
-			var msgs []telegram.Message
-			for msg := range messageCh {
-				msgs = append(msgs, msg)
-			}
-			require.Equal(t, tt.wantMsgs, msgs)
+			require.Equal(t, tt.wantMsgs, recorder.Messages())
 		})
 	}
 }
@@ -0,0 +1,125 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tonkeeper/tonkeeper-twa-api/pkg/i18n"
+	"github.com/tonkeeper/tonkeeper-twa-api/pkg/telegram"
+)
+
+// CommandRegistry is a bot that routes commands to their handlers.
+type CommandRegistry interface {
+	HandleCommand(command string, handler telegram.CommandHandler)
+}
+
+// Commands implements bot commands that let users manage notifications without opening the TWA.
+type Commands struct {
+	storage     Storage
+	notificator *AccountEventsNotificator
+	bridge      *Bridge
+	webAppURL   string
+}
+
+func NewCommands(storage Storage, notificator *AccountEventsNotificator, bridge *Bridge, opts ...Option) *Commands {
+	options := applyOptions(opts)
+	return &Commands{
+		storage:     storage,
+		notificator: notificator,
+		bridge:      bridge,
+		webAppURL:   options.WebAppURL,
+	}
+}
+
+// Register registers all supported commands in the bot.
+func (c *Commands) Register(registry CommandRegistry) {
+	registry.HandleCommand("start", c.Start)
+	registry.HandleCommand("subscriptions", c.Subscriptions)
+	registry.HandleCommand("mute_incoming", c.Mute)
+	registry.HandleCommand("unmute", c.Unmute)
+	registry.HandleCommand("stop", c.Stop)
+}
+
+// Start shows a welcome message.
+// A user who has started the bot can be messaged by it, so Start also grants the write access.
+func (c *Commands) Start(ctx context.Context, userID telegram.UserID, languageCode string, args string) (telegram.Message, error) {
+	if err := c.storage.GrantWriteAccess(ctx, userID); err != nil {
+		return telegram.Message{}, err
+	}
+	p := i18n.NewPrinter(languageCode)
+	msg := telegram.Message{
+		Text:      p.Sprintf(i18n.WelcomeCommand),
+		ParseMode: telegram.ParseModeHTML,
+	}
+	if len(c.webAppURL) > 0 {
+		msg.Keyboard = [][]telegram.Button{
+			{{Text: p.Sprintf(i18n.OpenTonkeeperButton), WebAppURL: c.webAppURL}},
+		}
+	}
+	return msg, nil
+}
+
+// Subscriptions lists wallets and dApps a user gets notifications about.
+func (c *Commands) Subscriptions(ctx context.Context, userID telegram.UserID, languageCode string, args string) (telegram.Message, error) {
+	p := i18n.NewPrinter(languageCode)
+	var wallets []string
+	for _, account := range c.notificator.Accounts(userID) {
+		wallets = append(wallets, account.ToHuman(true, false))
+	}
+	origins := c.bridge.Origins(userID)
+	if len(wallets) == 0 && len(origins) == 0 {
+		return telegram.Message{Text: p.Sprintf(i18n.NoSubscriptions), ParseMode: telegram.ParseModeHTML}, nil
+	}
+	sort.Strings(wallets)
+	sort.Strings(origins)
+
+	var b strings.Builder
+	if len(wallets) > 0 {
+		fmt.Fprintf(&b, "<b>%v</b>\n", p.Sprintf(i18n.WalletsTitle))
+		for _, wallet := range wallets {
+			fmt.Fprintf(&b, "<code>%v</code>\n", wallet)
+		}
+	}
+	if len(origins) > 0 {
+		if b.Len() > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "<b>%v</b>\n", p.Sprintf(i18n.ConnectedAppsTitle))
+		for _, origin := range origins {
+			fmt.Fprintf(&b, "%v\n", telegram.EscapeHTML(origin))
+		}
+	}
+	return telegram.Message{Text: b.String(), ParseMode: telegram.ParseModeHTML}, nil
+}
+
+// Mute mutes notifications for a user until Unmute, it is registered as /mute_incoming.
+// Like a mute set by the TWA, it doesn't mute notifications of high priority about outgoing transfers,
+// so the command name and the reply don't promise silence.
+func (c *Commands) Mute(ctx context.Context, userID telegram.UserID, languageCode string, args string) (telegram.Message, error) {
+	if err := c.storage.SetMuteUntil(ctx, userID, MuteForever); err != nil {
+		return telegram.Message{}, err
+	}
+	return telegram.Message{Text: i18n.NewPrinter(languageCode).Sprintf(i18n.MutedCommand), ParseMode: telegram.ParseModeHTML}, nil
+}
+
+// Unmute resumes notifications muted by Mute or by a mute set in the TWA.
+func (c *Commands) Unmute(ctx context.Context, userID telegram.UserID, languageCode string, args string) (telegram.Message, error) {
+	if err := c.storage.SetMuteUntil(ctx, userID, time.Time{}); err != nil {
+		return telegram.Message{}, err
+	}
+	return telegram.Message{Text: i18n.NewPrinter(languageCode).Sprintf(i18n.UnmutedCommand), ParseMode: telegram.ParseModeHTML}, nil
+}
+
+// Stop cancels all subscriptions of a user.
+func (c *Commands) Stop(ctx context.Context, userID telegram.UserID, languageCode string, args string) (telegram.Message, error) {
+	if err := c.notificator.Unsubscribe(userID, nil); err != nil {
+		return telegram.Message{}, err
+	}
+	if err := c.bridge.Unsubscribe(userID, nil); err != nil {
+		return telegram.Message{}, err
+	}
+	return telegram.Message{Text: i18n.NewPrinter(languageCode).Sprintf(i18n.UnsubscribedCommand), ParseMode: telegram.ParseModeHTML}, nil
+}
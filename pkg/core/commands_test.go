@@ -0,0 +1,103 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tonkeeper/tongo/ton"
+	"go.uber.org/zap"
+
+	"github.com/tonkeeper/tonkeeper-twa-api/pkg/telegram"
+)
+
+func newTestCommands(s Storage) *Commands {
+	account := ton.MustParseAccountID("0:6ccd325a858c379693fae2bcaab1c2906831a4e10a6c3bb44ee8b615bca1d220")
+	notificator := &AccountEventsNotificator{
+		logger:  zap.L(),
+		storage: s,
+		streams: newAccountStreams(zap.L(), s, "", nil),
+		subsPerUserID: map[telegram.UserID]map[ton.AccountID]struct{}{
+			1: {account: {}},
+		},
+		subsPerAccountID: map[ton.AccountID]map[telegram.UserID]struct{}{
+			account: {1: {}},
+		},
+	}
+	bridge := &Bridge{
+		logger:  zap.L(),
+		storage: s,
+		subsPerClientID: map[ClientID]bridgeSubscription{
+			"1001": {Origin: "ton.org", UserID: 1},
+			"1002": {Origin: "dex.ton", UserID: 1},
+		},
+		clientIDsPerUser: map[telegram.UserID]map[ClientID]struct{}{
+			1: {"1001": {}, "1002": {}},
+		},
+	}
+	return NewCommands(s, notificator, bridge)
+}
+
+func TestCommands_Subscriptions(t *testing.T) {
+	tests := []struct {
+		name         string
+		userID       telegram.UserID
+		languageCode string
+		wantText     string
+	}{
+		{
+			name:     "wallets and apps",
+			userID:   1,
+			wantText: "<b>Wallets</b>\n<code>EQBszTJahYw3lpP64ryqscKQaDGk4QpsO7RO6LYVvKHSINS0</code>\n\n<b>Connected apps</b>\ndex.ton\nton.org\n",
+		},
+		{
+			name:     "no subscriptions",
+			userID:   2,
+			wantText: "You are not subscribed to any notifications.",
+		},
+		{
+			name:         "russian",
+			userID:       1,
+			languageCode: "ru-RU",
+			wantText:     "<b>Кошельки</b>\n<code>EQBszTJahYw3lpP64ryqscKQaDGk4QpsO7RO6LYVvKHSINS0</code>\n\n<b>Подключённые приложения</b>\ndex.ton\nton.org\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestCommands(&mockStorage{})
+			msg, err := c.Subscriptions(context.Background(), tt.userID, tt.languageCode, "")
+			require.Nil(t, err)
+			require.Equal(t, tt.wantText, msg.Text)
+		})
+	}
+}
+
+func TestCommands_Mute(t *testing.T) {
+	var mutedUsers []telegram.UserID
+	s := &mockStorage{
+		OnSetMuteUntil: func(ctx context.Context, userID telegram.UserID, until time.Time) error {
+			require.Equal(t, MuteForever, until)
+			mutedUsers = append(mutedUsers, userID)
+			return nil
+		},
+	}
+	c := newTestCommands(s)
+	_, err := c.Mute(context.Background(), 1, "en", "")
+	require.Nil(t, err)
+	require.Equal(t, []telegram.UserID{1}, mutedUsers)
+}
+
+func TestCommands_Stop(t *testing.T) {
+	s := &mockStorage{
+		OnUnsubscribeFromBridgeEvents: func(ctx context.Context, userID telegram.UserID, clientID *ClientID) error {
+			require.Nil(t, clientID)
+			return nil
+		},
+	}
+	c := newTestCommands(s)
+	_, err := c.Stop(context.Background(), 1, "en", "")
+	require.Nil(t, err)
+	require.Empty(t, c.notificator.Accounts(1))
+	require.Empty(t, c.bridge.Origins(1))
+}
@@ -0,0 +1,122 @@
+package core
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/tonkeeper/tongo/ton"
+
+	"github.com/tonkeeper/tonkeeper-twa-api/pkg/telegram"
+)
+
+const (
+	defaultDeduplicatorSize = 100_000
+	// notificationDedupTTL is how long a sent notification is remembered.
+	// TonAPI replays events of the last hours at most, so a day is enough.
+	notificationDedupTTL = 24 * time.Hour
+	// notificationClaimTTL is how long a notification is reserved while it is being passed to the outbox.
+	// It covers the digest window, so if a replica dies before confirming a notification,
+	// the trace can be notified about again once it is received again.
+	notificationClaimTTL = 10 * time.Minute
+)
+
+// NotificationKey identifies a notification about a trace to a user.
+type NotificationKey struct {
+	TraceHash string
+	Account   ton.AccountID
+	UserID    telegram.UserID
+}
+
+// Deduplicator remembers sent notifications,
+// so a user is notified once even if a trace is received several times.
+// A notification is marked before it is sent and then either confirmed or released,
+// so a failed notification can be sent again.
+type Deduplicator interface {
+	// MarkNotified marks a notification as sent for the given ttl.
+	// It returns false if the notification has been already marked and its ttl hasn't expired yet.
+	MarkNotified(ctx context.Context, key NotificationKey, ttl time.Duration) (bool, error)
+	// ConfirmNotified marks a notification as sent for the given ttl regardless of the current mark.
+	ConfirmNotified(ctx context.Context, key NotificationKey, ttl time.Duration) error
+	// ReleaseNotified removes the mark of a notification, so it can be sent again.
+	ReleaseNotified(ctx context.Context, key NotificationKey) error
+}
+
+// MemoryDeduplicator is a Deduplicator with an LRU list of the last size notifications.
+// When the list is full, the oldest notification is forgotten before its ttl expires,
+// so size should cover the traces received during notificationDedupTTL.
+// It doesn't see notifications sent by other replicas.
+type MemoryDeduplicator struct {
+	size int
+
+	mu sync.Mutex
+	// order contains *dedupEntry, the most recently marked first.
+	order   *list.List
+	entries map[NotificationKey]*list.Element
+}
+
+type dedupEntry struct {
+	key       NotificationKey
+	expiresAt time.Time
+}
+
+// NewMemoryDeduplicator returns a deduplicator remembering up to size notifications.
+func NewMemoryDeduplicator(size int) *MemoryDeduplicator {
+	if size <= 0 {
+		size = defaultDeduplicatorSize
+	}
+	return &MemoryDeduplicator{
+		size:    size,
+		order:   list.New(),
+		entries: map[NotificationKey]*list.Element{},
+	}
+}
+
+func (d *MemoryDeduplicator) MarkNotified(ctx context.Context, key NotificationKey, ttl time.Duration) (bool, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := time.Now()
+	if elem, ok := d.entries[key]; ok && elem.Value.(*dedupEntry).expiresAt.After(now) {
+		return false, nil
+	}
+	d.mark(key, now.Add(ttl))
+	return true, nil
+}
+
+func (d *MemoryDeduplicator) ConfirmNotified(ctx context.Context, key NotificationKey, ttl time.Duration) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.mark(key, time.Now().Add(ttl))
+	return nil
+}
+
+func (d *MemoryDeduplicator) ReleaseNotified(ctx context.Context, key NotificationKey) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if elem, ok := d.entries[key]; ok {
+		d.order.Remove(elem)
+		delete(d.entries, key)
+	}
+	return nil
+}
+
+// mark sets the expiration of a notification and moves it to the front of the list.
+func (d *MemoryDeduplicator) mark(key NotificationKey, expiresAt time.Time) {
+	if elem, ok := d.entries[key]; ok {
+		elem.Value.(*dedupEntry).expiresAt = expiresAt
+		d.order.MoveToFront(elem)
+		return
+	}
+	d.entries[key] = d.order.PushFront(&dedupEntry{key: key, expiresAt: expiresAt})
+	if d.order.Len() > d.size {
+		oldest := d.order.Back()
+		d.order.Remove(oldest)
+		delete(d.entries, oldest.Value.(*dedupEntry).key)
+	}
+}
+
+var _ Deduplicator = (*MemoryDeduplicator)(nil)
@@ -0,0 +1,49 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tonkeeper/tongo/ton"
+
+	"github.com/tonkeeper/tonkeeper-twa-api/pkg/telegram"
+)
+
+func TestMemoryDeduplicator_MarkNotified(t *testing.T) {
+	d := NewMemoryDeduplicator(2)
+	ctx := context.Background()
+	account := ton.MustParseAccountID("0:6ccd325a858c379693fae2bcaab1c2906831a4e10a6c3bb44ee8b615bca1d220")
+	key := func(hash string, userID telegram.UserID) NotificationKey {
+		return NotificationKey{TraceHash: hash, Account: account, UserID: userID}
+	}
+	mark := func(key NotificationKey, ttl time.Duration) bool {
+		ok, err := d.MarkNotified(ctx, key, ttl)
+		require.Nil(t, err)
+		return ok
+	}
+
+	require.True(t, mark(key("a", 1), time.Hour))
+	require.False(t, mark(key("a", 1), time.Hour))
+	// another user is notified about the same trace.
+	require.True(t, mark(key("a", 2), time.Hour))
+
+	// the least recently marked notification is forgotten.
+	require.True(t, mark(key("b", 1), time.Hour))
+	require.True(t, mark(key("a", 1), time.Hour))
+	require.False(t, mark(key("b", 1), time.Hour))
+
+	// expired notifications can be marked again.
+	require.True(t, mark(key("c", 1), -time.Second))
+	require.True(t, mark(key("c", 1), time.Hour))
+	require.False(t, mark(key("c", 1), time.Hour))
+
+	// a released notification can be marked again.
+	require.Nil(t, d.ReleaseNotified(ctx, key("c", 1)))
+	require.True(t, mark(key("c", 1), time.Hour))
+
+	// a confirmed notification gets a new ttl.
+	require.Nil(t, d.ConfirmNotified(ctx, key("c", 1), -time.Second))
+	require.True(t, mark(key("c", 1), time.Hour))
+}
@@ -0,0 +1,380 @@
+package core
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"go.uber.org/zap"
+
+	"github.com/tonkeeper/tonkeeper-twa-api/pkg/telegram"
+)
+
+const (
+	digestSeparator    = "\n\n"
+	digestFlushTimeout = 5 * time.Second
+)
+
+// Digest collects messages to a user over a short window and merges them into one message,
+// so a trace with several actions doesn't spam the user with separate notifications.
+// Fields below ch are accessed by the goroutine running Run only.
+type Digest struct {
+	logger *zap.Logger
+	window time.Duration
+	next   Notifier
+	// stopped is closed when Run returns.
+	stopped chan struct{}
+	ch      chan digestMessage
+
+	// pending contains collected messages per user.
+	pending map[telegram.UserID][]digestMessage
+	// queue contains users with pending messages ordered by the time their window ends.
+	queue []digestDeadline
+}
+
+type digestMessage struct {
+	msg telegram.Message
+	// ack is called with the result of passing the message on, it can be nil.
+	ack func(err error)
+}
+
+type digestDeadline struct {
+	userID   telegram.UserID
+	deadline time.Time
+}
+
+// NewDigest returns a digest that passes merged messages to the next notifier.
+func NewDigest(logger *zap.Logger, window time.Duration, next Notifier) *Digest {
+	return &Digest{
+		logger:  logger,
+		window:  window,
+		next:    next,
+		ch:      make(chan digestMessage),
+		stopped: make(chan struct{}),
+		pending: map[telegram.UserID][]digestMessage{},
+	}
+}
+
+// Notify adds a message to a digest of the message's user.
+// If the window is not positive or Run has returned, the message is passed to the next notifier as is.
+func (d *Digest) Notify(ctx context.Context, msg telegram.Message) error {
+	return d.add(ctx, digestMessage{msg: msg})
+}
+
+// NotifyAck works like Notify and calls ack once the message has been passed to the next notifier,
+// ack gets the error of the next notifier.
+func (d *Digest) NotifyAck(ctx context.Context, msg telegram.Message, ack func(err error)) {
+	if err := d.add(ctx, digestMessage{msg: msg, ack: ack}); err != nil {
+		ack(err)
+	}
+}
+
+// add adds a message to a digest or passes it to the next notifier right away.
+// It returns an error only if the message hasn't been accepted, otherwise the result goes to the message's ack.
+func (d *Digest) add(ctx context.Context, msg digestMessage) error {
+	if d.window <= 0 {
+		return d.pass(ctx, msg)
+	}
+	select {
+	case d.ch <- msg:
+		return nil
+	case <-d.stopped:
+		return d.pass(ctx, msg)
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (d *Digest) pass(ctx context.Context, msg digestMessage) error {
+	err := d.next.Notify(ctx, msg.msg)
+	if msg.ack != nil {
+		msg.ack(err)
+		return nil
+	}
+	return err
+}
+
+// Run collects messages until ctx is done.
+// When ctx is done, all collected messages are flushed immediately.
+func (d *Digest) Run(ctx context.Context) {
+	defer close(d.stopped)
+	timer := time.NewTimer(d.window)
+	stopTimer(timer)
+	for {
+		select {
+		case <-ctx.Done():
+			d.flush(time.Time{})
+			return
+		case msg := <-d.ch:
+			d.collect(msg, time.Now())
+		case now := <-timer.C:
+			d.flush(now)
+		}
+		stopTimer(timer)
+		if deadline, ok := d.nextDeadline(); ok {
+			timer.Reset(time.Until(deadline))
+		}
+	}
+}
+
+func stopTimer(timer *time.Timer) {
+	if !timer.Stop() {
+		select {
+		case <-timer.C:
+		default:
+		}
+	}
+}
+
+func (d *Digest) collect(msg digestMessage, now time.Time) {
+	userID := msg.msg.UserID
+	if _, ok := d.pending[userID]; !ok {
+		d.queue = append(d.queue, digestDeadline{userID: userID, deadline: now.Add(d.window)})
+	}
+	d.pending[userID] = append(d.pending[userID], msg)
+}
+
+func (d *Digest) nextDeadline() (time.Time, bool) {
+	if len(d.queue) == 0 {
+		return time.Time{}, false
+	}
+	return d.queue[0].deadline, true
+}
+
+// flush sends digests to users whose window has ended by now.
+// Zero now flushes everything.
+func (d *Digest) flush(now time.Time) {
+	var ready [][]digestMessage
+	for len(d.queue) > 0 && (now.IsZero() || !d.queue[0].deadline.After(now)) {
+		userID := d.queue[0].userID
+		d.queue = d.queue[1:]
+		ready = append(ready, d.pending[userID])
+		delete(d.pending, userID)
+	}
+
+	for _, collected := range ready {
+		messages := make([]telegram.Message, 0, len(collected))
+		for _, msg := range collected {
+			messages = append(messages, msg.msg)
+		}
+		// a digest is passed on as a whole, so all its messages get the first error.
+		var err error
+		for _, msg := range mergeMessages(messages) {
+			if sendErr := d.send(msg); sendErr != nil && err == nil {
+				err = sendErr
+			}
+		}
+		for _, msg := range collected {
+			if msg.ack != nil {
+				msg.ack(err)
+			}
+		}
+	}
+}
+
+func (d *Digest) send(msg telegram.Message) error {
+	// ctx of Run can be already done, so flushing gets its own timeout.
+	ctx, cancel := context.WithTimeout(context.Background(), digestFlushTimeout)
+	defer cancel()
+	err := d.next.Notify(ctx, msg)
+	if err != nil {
+		d.logger.Error("failed to flush digest",
+			zap.Int64("user_id", int64(msg.UserID)),
+			zap.Error(err))
+	}
+	return err
+}
+
+// mergeMessages merges messages to a user into as few messages as possible
+// keeping each of them within telegram.MaxMessageLength.
+// Messages with different parse modes or priorities are never merged,
+// so a normal message doesn't skip quiet hours along with an important one.
+func mergeMessages(messages []telegram.Message) []telegram.Message {
+	type group struct {
+		parseMode telegram.ParseMode
+		priority  telegram.Priority
+	}
+	var groups []group
+	texts := map[group][]string{}
+	keyboards := map[group][][]telegram.Button{}
+	sameKeyboard := map[group]bool{}
+	for _, msg := range messages {
+		g := group{parseMode: msg.ParseMode, priority: msg.Priority}
+		if _, ok := texts[g]; !ok {
+			groups = append(groups, g)
+			keyboards[g] = msg.Keyboard
+			sameKeyboard[g] = true
+		}
+		texts[g] = append(texts[g], msg.Text)
+		if !reflect.DeepEqual(keyboards[g], msg.Keyboard) {
+			sameKeyboard[g] = false
+		}
+	}
+	var result []telegram.Message
+	for _, g := range groups {
+		// buttons pointing to different transactions would be misleading in a merged message.
+		var keyboard [][]telegram.Button
+		if sameKeyboard[g] {
+			keyboard = keyboards[g]
+		}
+		for _, text := range joinTexts(texts[g], digestSeparator, telegram.MaxMessageLength, g.parseMode) {
+			result = append(result, telegram.Message{
+				UserID:    messages[0].UserID,
+				Text:      text,
+				ParseMode: g.parseMode,
+				Keyboard:  keyboard,
+				Priority:  g.priority,
+			})
+		}
+	}
+	return result
+}
+
+// joinTexts joins texts with the separator into chunks of at most limit characters.
+// Chunks are split only between texts, a text longer than limit is split according to its parse mode.
+func joinTexts(texts []string, separator string, limit int, parseMode telegram.ParseMode) []string {
+	var chunks []string
+	var current strings.Builder
+	currentLen := 0
+	separatorLen := utf8.RuneCountInString(separator)
+	for _, text := range texts {
+		for _, part := range splitText(text, limit, parseMode) {
+			partLen := utf8.RuneCountInString(part)
+			if currentLen > 0 && currentLen+separatorLen+partLen > limit {
+				chunks = append(chunks, current.String())
+				current.Reset()
+				currentLen = 0
+			}
+			if currentLen > 0 {
+				current.WriteString(separator)
+				currentLen += separatorLen
+			}
+			current.WriteString(part)
+			currentLen += partLen
+		}
+	}
+	if currentLen > 0 {
+		chunks = append(chunks, current.String())
+	}
+	return chunks
+}
+
+// splitText splits a text into parts of at most limit characters.
+// HTML is split between tags and entities, and tags open at a split are closed and reopened.
+// MarkdownV2 is never split because its entities can't be told from text without a parser,
+// telegram rejects such a text and it fails alone instead of failing the whole digest.
+func splitText(text string, limit int, parseMode telegram.ParseMode) []string {
+	if utf8.RuneCountInString(text) <= limit {
+		return []string{text}
+	}
+	switch parseMode {
+	case telegram.ParseModeHTML:
+		return splitHTML(text, limit)
+	case telegram.ParseModeMarkdownV2:
+		return []string{text}
+	}
+	runes := []rune(text)
+	var parts []string
+	for len(runes) > limit {
+		parts = append(parts, string(runes[:limit]))
+		runes = runes[limit:]
+	}
+	return append(parts, string(runes))
+}
+
+// htmlTag is a tag open at some point of an HTML text.
+type htmlTag struct {
+	name string
+	// open is the opening tag as it is written in the text.
+	open string
+}
+
+func (t htmlTag) closing() string {
+	return "</" + t.name + ">"
+}
+
+// splitHTML splits an HTML text into parts of at most limit characters.
+func splitHTML(text string, limit int) []string {
+	var parts []string
+	var current strings.Builder
+	var open []htmlTag
+	// currentLen and closingLen are lengths of the current part and of the tags needed to close it.
+	currentLen, closingLen := 0, 0
+	// reopenedLen is the length of the tags reopened at the beginning of the current part.
+	reopenedLen := 0
+	for _, token := range htmlTokens(text) {
+		tokenLen := utf8.RuneCountInString(token)
+		nextOpen, nextClosingLen := open, closingLen
+		if tag, closing, ok := parseHTMLTag(token); ok {
+			if closing {
+				for i := len(open) - 1; i >= 0; i-- {
+					if open[i].name == tag.name {
+						nextOpen = append(open[:i:i], open[i+1:]...)
+						nextClosingLen -= utf8.RuneCountInString(tag.closing())
+						break
+					}
+				}
+			} else {
+				nextOpen = append(open[:len(open):len(open)], tag)
+				nextClosingLen += utf8.RuneCountInString(tag.closing())
+			}
+		}
+		if currentLen > reopenedLen && currentLen+tokenLen+nextClosingLen > limit {
+			for i := len(open) - 1; i >= 0; i-- {
+				current.WriteString(open[i].closing())
+			}
+			parts = append(parts, current.String())
+			current.Reset()
+			currentLen = 0
+			for _, tag := range open {
+				current.WriteString(tag.open)
+				currentLen += utf8.RuneCountInString(tag.open)
+			}
+			reopenedLen = currentLen
+		}
+		current.WriteString(token)
+		currentLen += tokenLen
+		open, closingLen = nextOpen, nextClosingLen
+	}
+	return append(parts, current.String())
+}
+
+// htmlTokens splits an HTML text into tags, entities and single characters.
+func htmlTokens(text string) []string {
+	var tokens []string
+	for len(text) > 0 {
+		end := 0
+		switch text[0] {
+		case '<':
+			end = strings.IndexByte(text, '>') + 1
+		case '&':
+			if semicolon := strings.IndexByte(text, ';'); semicolon > 0 && !strings.ContainsAny(text[1:semicolon], " \n<&") {
+				end = semicolon + 1
+			}
+		}
+		if end <= 0 {
+			_, end = utf8.DecodeRuneInString(text)
+		}
+		tokens = append(tokens, text[:end])
+		text = text[end:]
+	}
+	return tokens
+}
+
+// parseHTMLTag returns the tag of a token and whether it is a closing tag.
+func parseHTMLTag(token string) (htmlTag, bool, bool) {
+	if len(token) < 3 || token[0] != '<' || token[len(token)-1] != '>' {
+		return htmlTag{}, false, false
+	}
+	closing := token[1] == '/'
+	name := strings.TrimPrefix(token[1:len(token)-1], "/")
+	if i := strings.IndexAny(name, " \t\n"); i >= 0 {
+		name = name[:i]
+	}
+	if len(name) == 0 {
+		return htmlTag{}, false, false
+	}
+	return htmlTag{name: name, open: token}, closing, true
+}
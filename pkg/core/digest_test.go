@@ -0,0 +1,182 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/tonkeeper/tonkeeper-twa-api/pkg/telegram"
+)
+
+func Test_mergeMessages(t *testing.T) {
+	openButton := [][]telegram.Button{{{Text: "Open", WebAppURL: "https://wallet.tonkeeper.com"}}}
+	tests := []struct {
+		name     string
+		messages []telegram.Message
+		want     []telegram.Message
+	}{
+		{
+			name: "same keyboard",
+			messages: []telegram.Message{
+				{UserID: 1, Text: "Received <b>1 TON</b>", ParseMode: telegram.ParseModeHTML, Keyboard: openButton},
+				{UserID: 1, Text: "Received <b>NFT</b>", ParseMode: telegram.ParseModeHTML, Keyboard: openButton},
+			},
+			want: []telegram.Message{
+				{UserID: 1, Text: "Received <b>1 TON</b>\n\nReceived <b>NFT</b>", ParseMode: telegram.ParseModeHTML, Keyboard: openButton},
+			},
+		},
+		{
+			name: "different keyboards and parse modes",
+			messages: []telegram.Message{
+				{UserID: 1, Text: "Received <b>1 TON</b>", ParseMode: telegram.ParseModeHTML, Keyboard: openButton},
+				{UserID: 1, Text: "plain"},
+				{UserID: 1, Text: "Received <b>NFT</b>", ParseMode: telegram.ParseModeHTML},
+			},
+			want: []telegram.Message{
+				{UserID: 1, Text: "Received <b>1 TON</b>\n\nReceived <b>NFT</b>", ParseMode: telegram.ParseModeHTML},
+				{UserID: 1, Text: "plain"},
+			},
+		},
+		{
+			name: "different priorities",
+			messages: []telegram.Message{
+				{UserID: 1, Text: "Sent <b>1 TON</b>", ParseMode: telegram.ParseModeHTML, Priority: telegram.PriorityHigh},
+				{UserID: 1, Text: "Received <b>NFT</b>", ParseMode: telegram.ParseModeHTML},
+			},
+			want: []telegram.Message{
+				{UserID: 1, Text: "Sent <b>1 TON</b>", ParseMode: telegram.ParseModeHTML, Priority: telegram.PriorityHigh},
+				{UserID: 1, Text: "Received <b>NFT</b>", ParseMode: telegram.ParseModeHTML},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, mergeMessages(tt.messages))
+		})
+	}
+}
+
+func Test_joinTexts(t *testing.T) {
+	tests := []struct {
+		name      string
+		texts     []string
+		limit     int
+		parseMode telegram.ParseMode
+		want      []string
+	}{
+		{
+			name:  "fits into one chunk",
+			texts: []string{"aaa", "bbb"},
+			limit: 10,
+			want:  []string{"aaa\n\nbbb"},
+		},
+		{
+			name:  "split at message boundary",
+			texts: []string{"aaa", "bbb", "ccc"},
+			limit: 8,
+			want:  []string{"aaa\n\nbbb", "ccc"},
+		},
+		{
+			name:  "too long message",
+			texts: []string{"ab", strings.Repeat("ж", 7)},
+			limit: 5,
+			want:  []string{"ab", "жжжжж", "жж"},
+		},
+		{
+			name:      "too long html message",
+			texts:     []string{"<b>1 TON</b> &amp; <a href=\"u\">link</a>"},
+			limit:     20,
+			parseMode: telegram.ParseModeHTML,
+			want:      []string{"<b>1 TON</b> &amp; ", "<a href=\"u\">link</a>"},
+		},
+		{
+			name:      "tags are closed and reopened at a split",
+			texts:     []string{"<b>aaaaaaa</b>"},
+			limit:     10,
+			parseMode: telegram.ParseModeHTML,
+			want:      []string{"<b>aaa</b>", "<b>aaa</b>", "<b>a</b>"},
+		},
+		{
+			name:      "html is split between entities",
+			texts:     []string{"<b>&lt;&gt;&lt;</b>"},
+			limit:     12,
+			parseMode: telegram.ParseModeHTML,
+			want:      []string{"<b>&lt;</b>", "<b>&gt;</b>", "<b>&lt;</b>"},
+		},
+		{
+			name:      "markdown is never split",
+			texts:     []string{"*bold*", "_italic_"},
+			limit:     6,
+			parseMode: telegram.ParseModeMarkdownV2,
+			want:      []string{"*bold*", "_italic_"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, joinTexts(tt.texts, "\n\n", tt.limit, tt.parseMode))
+		})
+	}
+}
+
+func TestDigest_Run(t *testing.T) {
+	recorder := &RecordingNotifier{}
+	ctx, cancel := context.WithCancel(context.Background())
+	digest := NewDigest(zap.L(), 50*time.Millisecond, recorder)
+	done := make(chan struct{})
+	go func() {
+		digest.Run(ctx)
+		close(done)
+	}()
+
+	require.Nil(t, digest.Notify(ctx, telegram.Message{UserID: 1, Text: "a"}))
+	require.Nil(t, digest.Notify(ctx, telegram.Message{UserID: 2, Text: "b"}))
+	require.Nil(t, digest.Notify(ctx, telegram.Message{UserID: 1, Text: "c"}))
+
+	require.Eventually(t, func() bool { return len(recorder.Messages()) == 2 }, time.Second, 10*time.Millisecond)
+	require.Equal(t, []telegram.Message{
+		{UserID: 1, Text: "a\n\nc"},
+		{UserID: 2, Text: "b"},
+	}, recorder.Messages())
+
+	// pending messages are flushed on shutdown.
+	require.Nil(t, digest.Notify(ctx, telegram.Message{UserID: 3, Text: "d"}))
+	cancel()
+	<-done
+	require.Equal(t, telegram.Message{UserID: 3, Text: "d"}, recorder.Messages()[2])
+
+	// messages are passed as is once Run has returned.
+	require.Nil(t, digest.Notify(context.Background(), telegram.Message{UserID: 4, Text: "e"}))
+	require.Equal(t, telegram.Message{UserID: 4, Text: "e"}, recorder.Messages()[3])
+}
+
+func TestDigest_NotifyAck(t *testing.T) {
+	failing := &mockNotifier{OnNotify: func(ctx context.Context, msg telegram.Message) error {
+		return errors.New("outbox is unavailable")
+	}}
+	ctx, cancel := context.WithCancel(context.Background())
+	digest := NewDigest(zap.L(), 10*time.Millisecond, failing)
+	done := make(chan struct{})
+	go func() {
+		digest.Run(ctx)
+		close(done)
+	}()
+
+	acks := make(chan error, 2)
+	ack := func(err error) { acks <- err }
+	digest.NotifyAck(ctx, telegram.Message{UserID: 1, Text: "a"}, ack)
+	digest.NotifyAck(ctx, telegram.Message{UserID: 1, Text: "b"}, ack)
+	// both messages are merged into one, so both get its error.
+	require.EqualError(t, <-acks, "outbox is unavailable")
+	require.EqualError(t, <-acks, "outbox is unavailable")
+
+	cancel()
+	<-done
+	// messages are acked right away once Run has returned.
+	digest.NotifyAck(context.Background(), telegram.Message{UserID: 2, Text: "c"}, ack)
+	require.EqualError(t, <-acks, "outbox is unavailable")
+}
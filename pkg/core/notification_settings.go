@@ -0,0 +1,99 @@
+package core
+
+import (
+	"fmt"
+	"time"
+)
+
+const minutesPerDay = 24 * 60
+
+// MuteForever is MuteUntil of a user who has muted notifications until unmuting them explicitly.
+var MuteForever = time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC)
+
+// QuietHours is a daily window when notifications are held and delivered later as one summary.
+// Start and End are minutes since midnight in the user's timezone.
+// The window spans midnight if Start is greater than End.
+type QuietHours struct {
+	Start int
+	End   int
+}
+
+// NotificationSettings contains per-user preferences of how notifications are delivered.
+type NotificationSettings struct {
+	// QuietHours is nil if the user doesn't have quiet hours.
+	QuietHours *QuietHours
+	// Timezone is an IANA timezone name used to interpret QuietHours, empty means UTC.
+	Timezone string
+	// MuteUntil is a moment until which notifications of normal priority are dropped, zero means not muted.
+	MuteUntil time.Time
+}
+
+// Validate checks that the settings can be applied.
+func (s NotificationSettings) Validate() error {
+	if _, err := time.LoadLocation(s.Timezone); err != nil {
+		return fmt.Errorf("unknown timezone %q", s.Timezone)
+	}
+	if s.QuietHours == nil {
+		return nil
+	}
+	for _, minutes := range []int{s.QuietHours.Start, s.QuietHours.End} {
+		if minutes < 0 || minutes >= minutesPerDay {
+			return fmt.Errorf("quiet hours must be within a day")
+		}
+	}
+	if s.QuietHours.Start == s.QuietHours.End {
+		return fmt.Errorf("quiet hours must not be empty")
+	}
+	return nil
+}
+
+// IsMuted returns true if all notifications to the user are muted at the moment.
+func (s NotificationSettings) IsMuted(now time.Time) bool {
+	return now.Before(s.MuteUntil)
+}
+
+// QuietHoursEnd returns the end of quiet hours if now is within them.
+func (s NotificationSettings) QuietHoursEnd(now time.Time) (time.Time, bool) {
+	if s.QuietHours == nil || s.QuietHours.Start == s.QuietHours.End {
+		return time.Time{}, false
+	}
+	location, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		location = time.UTC
+	}
+	local := now.In(location)
+	minutes := local.Hour()*60 + local.Minute()
+	start, end := s.QuietHours.Start, s.QuietHours.End
+
+	var inside bool
+	endDay := local
+	switch {
+	case start < end:
+		inside = minutes >= start && minutes < end
+	case minutes >= start:
+		// the window spans midnight and ends tomorrow.
+		inside = true
+		endDay = local.AddDate(0, 0, 1)
+	default:
+		inside = minutes < end
+	}
+	if !inside {
+		return time.Time{}, false
+	}
+	year, month, day := endDay.Date()
+	return time.Date(year, month, day, end/60, end%60, 0, 0, location), true
+}
+
+// ParseTimeOfDay parses a time of day in the "15:04" format and returns minutes since midnight.
+func ParseTimeOfDay(value string) (int, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time of day %q, expected HH:MM", value)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// FormatTimeOfDay formats minutes since midnight in the "15:04" format.
+func FormatTimeOfDay(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
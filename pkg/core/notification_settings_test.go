@@ -0,0 +1,66 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationSettings_QuietHoursEnd(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.Nil(t, err)
+	tests := []struct {
+		name     string
+		settings NotificationSettings
+		now      time.Time
+		wantEnd  time.Time
+		wantOk   bool
+	}{
+		{
+			name: "no quiet hours",
+			now:  time.Date(2024, 1, 1, 3, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "within a day",
+			settings: NotificationSettings{QuietHours: &QuietHours{Start: 60, End: 7 * 60}},
+			now:      time.Date(2024, 1, 1, 3, 0, 0, 0, time.UTC),
+			wantEnd:  time.Date(2024, 1, 1, 7, 0, 0, 0, time.UTC),
+			wantOk:   true,
+		},
+		{
+			name:     "outside",
+			settings: NotificationSettings{QuietHours: &QuietHours{Start: 60, End: 7 * 60}},
+			now:      time.Date(2024, 1, 1, 7, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "spans midnight, before midnight",
+			settings: NotificationSettings{QuietHours: &QuietHours{Start: 23 * 60, End: 8*60 + 30}},
+			now:      time.Date(2024, 1, 1, 23, 15, 0, 0, time.UTC),
+			wantEnd:  time.Date(2024, 1, 2, 8, 30, 0, 0, time.UTC),
+			wantOk:   true,
+		},
+		{
+			name:     "spans midnight, after midnight",
+			settings: NotificationSettings{QuietHours: &QuietHours{Start: 23 * 60, End: 8*60 + 30}},
+			now:      time.Date(2024, 1, 2, 2, 0, 0, 0, time.UTC),
+			wantEnd:  time.Date(2024, 1, 2, 8, 30, 0, 0, time.UTC),
+			wantOk:   true,
+		},
+		{
+			name:     "timezone",
+			settings: NotificationSettings{QuietHours: &QuietHours{Start: 23 * 60, End: 7 * 60}, Timezone: "Europe/Berlin"},
+			// it is 01:00 in Berlin.
+			now:     time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+			wantEnd: time.Date(2024, 1, 2, 7, 0, 0, 0, berlin),
+			wantOk:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			end, ok := tt.settings.QuietHoursEnd(tt.now)
+			require.Equal(t, tt.wantOk, ok)
+			require.True(t, tt.wantEnd.Equal(end), "want %v, got %v", tt.wantEnd, end)
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/tonkeeper/tonkeeper-twa-api/pkg/telegram"
+)
+
+// Notifier delivers notifications to users.
+type Notifier interface {
+	Notify(ctx context.Context, msg telegram.Message) error
+}
+
+// AckNotifier is a Notifier which can pass a notification on later.
+// ack is called once with the result, so a caller can learn whether the notification has been stored.
+type AckNotifier interface {
+	NotifyAck(ctx context.Context, msg telegram.Message, ack func(err error))
+}
+
+// notifyAck passes a notification to notifier and calls ack with the result,
+// a notifier without acks is considered done once Notify returns.
+func notifyAck(ctx context.Context, notifier Notifier, msg telegram.Message, ack func(err error)) {
+	if n, ok := notifier.(AckNotifier); ok {
+		n.NotifyAck(ctx, msg, ack)
+		return
+	}
+	ack(notifier.Notify(ctx, msg))
+}
+
+// Sender delivers a message to a telegram user and reports the result.
+type Sender interface {
+	Deliver(ctx context.Context, msg telegram.Message) error
+}
+
+// TelegramNotifier sends notifications to users with a telegram bot.
+type TelegramNotifier struct {
+	sender Sender
+}
+
+func NewTelegramNotifier(sender Sender) *TelegramNotifier {
+	return &TelegramNotifier{sender: sender}
+}
+
+func (n *TelegramNotifier) Notify(ctx context.Context, msg telegram.Message) error {
+	return n.sender.Deliver(ctx, msg)
+}
+
+// LogNotifier only logs notifications instead of sending them.
+// It is useful for dry runs.
+type LogNotifier struct {
+	logger *zap.Logger
+}
+
+func NewLogNotifier(logger *zap.Logger) *LogNotifier {
+	return &LogNotifier{logger: logger}
+}
+
+func (n *LogNotifier) Notify(ctx context.Context, msg telegram.Message) error {
+	n.logger.Info("dry-run notification",
+		zap.Int64("user_id", int64(msg.UserID)),
+		zap.String("text", msg.Text))
+	return nil
+}
+
+// RecordingNotifier keeps notifications in memory.
+// It is useful in tests to check what would be sent.
+type RecordingNotifier struct {
+	mu       sync.Mutex
+	messages []telegram.Message
+}
+
+func (n *RecordingNotifier) Notify(ctx context.Context, msg telegram.Message) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.messages = append(n.messages, msg)
+	return nil
+}
+
+// Messages returns all recorded notifications in the order they were received.
+func (n *RecordingNotifier) Messages() []telegram.Message {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.messages == nil {
+		return nil
+	}
+	return append([]telegram.Message{}, n.messages...)
+}
+
+// MultiNotifier sends every notification to all of its notifiers.
+type MultiNotifier struct {
+	notifiers []Notifier
+}
+
+func NewMultiNotifier(notifiers ...Notifier) *MultiNotifier {
+	return &MultiNotifier{notifiers: notifiers}
+}
+
+// Notify sends a notification to all notifiers even if some of them fail
+// and returns all errors joined.
+func (n *MultiNotifier) Notify(ctx context.Context, msg telegram.Message) error {
+	var errs []error
+	for _, notifier := range n.notifiers {
+		if err := notifier.Notify(ctx, msg); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+var (
+	_ Notifier = (*TelegramNotifier)(nil)
+	_ Notifier = (*LogNotifier)(nil)
+	_ Notifier = (*RecordingNotifier)(nil)
+	_ Notifier = (*MultiNotifier)(nil)
+	_ Notifier = (*Outbox)(nil)
+	_ Notifier = (*Digest)(nil)
+
+	_ AckNotifier = (*Digest)(nil)
+)
@@ -0,0 +1,29 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tonkeeper/tonkeeper-twa-api/pkg/telegram"
+)
+
+func TestMultiNotifier_Notify(t *testing.T) {
+	first := &RecordingNotifier{}
+	second := &RecordingNotifier{}
+	broken := &mockNotifier{
+		OnNotify: func(ctx context.Context, msg telegram.Message) error {
+			return errors.New("broken")
+		},
+	}
+	n := NewMultiNotifier(first, broken, second)
+
+	msg := telegram.Message{UserID: 1, Text: "hello"}
+	err := n.Notify(context.Background(), msg)
+	require.EqualError(t, err, "broken")
+	// a failing notifier doesn't prevent the others from getting the message.
+	require.Equal(t, []telegram.Message{msg}, first.Messages())
+	require.Equal(t, []telegram.Message{msg}, second.Messages())
+}
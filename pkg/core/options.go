@@ -0,0 +1,70 @@
+package core
+
+const (
+	defaultWebAppURL = "https://wallet.tonkeeper.com"
+	// explorerTransactionURL is a link to a transaction in the explorer.
+	explorerTransactionURL = "https://tonviewer.com/transaction/"
+)
+
+type Options struct {
+	// WebAppURL is a URL of the Tonkeeper TWA opened by "Open in Tonkeeper" buttons.
+	WebAppURL string
+	// Deduplicator prevents notifying a user about the same trace twice.
+	// It is used by AccountEventsNotificator only, default is MemoryDeduplicator.
+	Deduplicator Deduplicator
+	// TraceWorkers, TraceQueueSize and TraceOverflow configure a pool of workers
+	// processing traces received by AccountEventsNotificator.
+	TraceWorkers   int
+	TraceQueueSize int
+	TraceOverflow  TraceOverflow
+	// ReplicaID distinguishes the positions of TonAPI SSE streams of replicas sharing storage.
+	// It must be unique and stable across restarts, empty is fine for a single replica.
+	ReplicaID string
+}
+
+// Option configures AccountEventsNotificator and Bridge.
+type Option func(o *Options)
+
+// WithWebAppURL sets a URL of the Tonkeeper TWA.
+func WithWebAppURL(url string) Option {
+	return func(o *Options) {
+		o.WebAppURL = url
+	}
+}
+
+// WithDeduplicator sets a deduplicator of trace notifications.
+func WithDeduplicator(d Deduplicator) Option {
+	return func(o *Options) {
+		o.Deduplicator = d
+	}
+}
+
+// WithTraceWorkers sets how many traces are processed at the same time,
+// how many traces can wait in the queue and what to do with a trace when the queue is full.
+func WithTraceWorkers(workers, queueSize int, overflow TraceOverflow) Option {
+	return func(o *Options) {
+		o.TraceWorkers = workers
+		o.TraceQueueSize = queueSize
+		o.TraceOverflow = overflow
+	}
+}
+
+// WithReplicaID sets an ID of the replica, so several replicas keep their SSE stream positions apart.
+func WithReplicaID(id string) Option {
+	return func(o *Options) {
+		o.ReplicaID = id
+	}
+}
+
+func applyOptions(opts []Option) *Options {
+	options := &Options{
+		WebAppURL:      defaultWebAppURL,
+		TraceWorkers:   defaultTraceWorkers,
+		TraceQueueSize: defaultTraceQueueSize,
+		TraceOverflow:  TraceOverflowBlock,
+	}
+	for _, o := range opts {
+		o(options)
+	}
+	return options
+}
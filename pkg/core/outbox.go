@@ -0,0 +1,267 @@
+package core
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+
+	"github.com/tonkeeper/tonkeeper-twa-api/pkg/telegram"
+)
+
+var (
+	outboxCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "twa_api_outbox_messages_counter",
+		Help: "Number of outbox messages by status",
+	}, []string{"status"})
+)
+
+const (
+	outboxBatchSize    = 100
+	outboxPollInterval = time.Second
+	// outboxLease is how long a claimed message stays invisible to other dispatchers.
+	outboxLease       = 5 * time.Minute
+	outboxMaxAttempts = 10
+	outboxMaxDelay    = 10 * time.Minute
+	// outboxSweepInterval is how often sent and failed messages older than the retention are deleted.
+	outboxSweepInterval = time.Hour
+	// outboxReleaseTimeout limits returning a message to the outbox after its delivery has been canceled.
+	outboxReleaseTimeout = 5 * time.Second
+)
+
+// Outbox persists outgoing messages in storage before they are sent,
+// so messages survive restarts and crashes of the service.
+// Several replicas can run Dispatch on the same storage and share the work.
+type Outbox struct {
+	logger  *zap.Logger
+	storage OutboxStorage
+	next    Notifier
+
+	// stopping is closed by Shutdown to stop claiming new messages.
+	stopping chan struct{}
+	// abort is closed by Shutdown to cancel deliveries which haven't finished in time.
+	abort     chan struct{}
+	stopped   chan struct{}
+	stopOnce  sync.Once
+	abortOnce sync.Once
+}
+
+// NewOutbox returns an outbox that eventually delivers saved messages with the next notifier.
+func NewOutbox(logger *zap.Logger, storage OutboxStorage, next Notifier) *Outbox {
+	return &Outbox{
+		logger:   logger,
+		storage:  storage,
+		next:     next,
+		stopping: make(chan struct{}),
+		abort:    make(chan struct{}),
+		stopped:  make(chan struct{}),
+	}
+}
+
+// Notify saves a message to the outbox.
+// Messages to users who haven't allowed the bot to write to them are dropped,
+// telegram would reject them anyway.
+// Messages of normal priority are dropped while the recipient is muted
+// and held until the end of the recipient's quiet hours.
+func (o *Outbox) Notify(ctx context.Context, msg telegram.Message) error {
+	allowed, err := o.storage.HasWriteAccess(ctx, msg.UserID)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		outboxCounter.WithLabelValues("no_write_access").Inc()
+		return nil
+	}
+	var hold time.Duration
+	if msg.Priority < telegram.PriorityHigh {
+		settings, err := o.storage.GetNotificationSettings(ctx, msg.UserID)
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		if settings.IsMuted(now) {
+			outboxCounter.WithLabelValues("muted").Inc()
+			return nil
+		}
+		if end, ok := settings.QuietHoursEnd(now); ok {
+			hold = end.Sub(now)
+		}
+	}
+	if err := o.storage.AddToOutbox(ctx, msg, hold); err != nil {
+		return err
+	}
+	if hold > 0 {
+		outboxCounter.WithLabelValues("held").Inc()
+		return nil
+	}
+	outboxCounter.WithLabelValues("pending").Inc()
+	return nil
+}
+
+// Dispatch claims pending messages from the outbox and delivers them until ctx is done or Shutdown is called.
+// When ctx is done, deliveries in progress are canceled.
+func (o *Outbox) Dispatch(ctx context.Context) {
+	defer close(o.stopped)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-o.abort:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	for {
+		claimed, err := o.dispatchBatch(ctx)
+		if err != nil {
+			o.logger.Error("failed to dispatch outbox messages", zap.Error(err))
+		}
+		if claimed == outboxBatchSize {
+			select {
+			case <-o.stopping:
+				return
+			default:
+				continue
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-o.stopping:
+			return
+		case <-time.After(outboxPollInterval):
+		}
+	}
+}
+
+// Shutdown stops claiming new messages and waits until the claimed ones are delivered and Dispatch returns.
+// If ctx is done before, the deliveries are canceled and Shutdown returns ctx.Err().
+// Messages whose delivery was canceled are returned to the outbox to be dispatched again.
+// Shutdown must be called after Dispatch is started.
+func (o *Outbox) Shutdown(ctx context.Context) error {
+	o.stopOnce.Do(func() { close(o.stopping) })
+	select {
+	case <-o.stopped:
+		return nil
+	case <-ctx.Done():
+		o.abortOnce.Do(func() { close(o.abort) })
+		<-o.stopped
+		return ctx.Err()
+	}
+}
+
+func (o *Outbox) dispatchBatch(ctx context.Context) (int, error) {
+	messages, err := o.storage.ClaimOutboxMessages(ctx, outboxBatchSize, outboxLease)
+	if err != nil {
+		return 0, err
+	}
+	var wg sync.WaitGroup
+	for _, group := range groupHeldMessages(messages) {
+		wg.Add(1)
+		go func(group []OutboxMessage) {
+			defer wg.Done()
+			o.deliver(ctx, group)
+		}(group)
+	}
+	wg.Wait()
+	return len(messages), nil
+}
+
+// groupHeldMessages puts messages held during quiet hours of the same user into one group
+// to be delivered as a summary. Every other message gets its own group.
+func groupHeldMessages(messages []OutboxMessage) [][]OutboxMessage {
+	var groups [][]OutboxMessage
+	heldGroups := map[telegram.UserID]int{}
+	for _, msg := range messages {
+		if !msg.Held {
+			groups = append(groups, []OutboxMessage{msg})
+			continue
+		}
+		if i, ok := heldGroups[msg.Message.UserID]; ok {
+			groups[i] = append(groups[i], msg)
+			continue
+		}
+		heldGroups[msg.Message.UserID] = len(groups)
+		groups = append(groups, []OutboxMessage{msg})
+	}
+	return groups
+}
+
+// deliver delivers a group of messages and updates their state in the outbox.
+// A group of several messages is merged into a summary and succeeds or fails as a whole.
+func (o *Outbox) deliver(ctx context.Context, group []OutboxMessage) {
+	messages := make([]telegram.Message, 0, len(group))
+	for _, msg := range group {
+		messages = append(messages, msg.Message)
+	}
+	if len(messages) > 1 {
+		messages = mergeMessages(messages)
+	}
+	var err error
+	for _, msg := range messages {
+		if err = o.next.Notify(ctx, msg); err != nil {
+			break
+		}
+	}
+	for _, msg := range group {
+		o.complete(ctx, msg, err)
+	}
+}
+
+// Sweep deletes sent and failed messages older than retention every outboxSweepInterval until ctx is done,
+// so the outbox doesn't grow forever.
+func (o *Outbox) Sweep(ctx context.Context, retention time.Duration) {
+	ticker := time.NewTicker(outboxSweepInterval)
+	defer ticker.Stop()
+	for {
+		if deleted, err := o.storage.DeleteCompletedOutboxMessages(ctx, retention); err != nil {
+			o.logger.Error("failed to delete completed outbox messages", zap.Error(err))
+		} else if deleted > 0 {
+			o.logger.Info("completed outbox messages deleted", zap.Int64("count", deleted))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// complete updates the state of a message in the outbox according to the delivery result.
+func (o *Outbox) complete(ctx context.Context, msg OutboxMessage, err error) {
+	switch {
+	case err != nil && ctx.Err() != nil:
+		// the delivery has been canceled by a shutdown, the message is returned to the outbox right away
+		// instead of waiting for its lease to expire. ctx is done, so we use a fresh one.
+		outboxCounter.WithLabelValues("released").Inc()
+		releaseCtx, cancel := context.WithTimeout(context.Background(), outboxReleaseTimeout)
+		defer cancel()
+		err = o.storage.RescheduleOutboxMessage(releaseCtx, msg.ID, err.Error(), 0)
+	case err == nil:
+		outboxCounter.WithLabelValues("sent").Inc()
+		err = o.storage.MarkOutboxMessageSent(ctx, msg.ID)
+	case telegram.IsPermanent(err) || msg.Attempts >= outboxMaxAttempts:
+		outboxCounter.WithLabelValues("failed").Inc()
+		err = o.storage.MarkOutboxMessageFailed(ctx, msg.ID, err.Error())
+	default:
+		outboxCounter.WithLabelValues("rescheduled").Inc()
+		err = o.storage.RescheduleOutboxMessage(ctx, msg.ID, err.Error(), outboxRetryDelay(msg.Attempts))
+	}
+	if err != nil {
+		o.logger.Error("failed to update outbox message",
+			zap.Int64("id", msg.ID),
+			zap.Error(err))
+	}
+}
+
+// outboxRetryDelay returns a delay before the next delivery attempt.
+func outboxRetryDelay(attempts int) time.Duration {
+	delay := time.Duration(attempts*attempts) * 10 * time.Second
+	if delay > outboxMaxDelay {
+		return outboxMaxDelay
+	}
+	return delay
+}
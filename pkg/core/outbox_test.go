@@ -0,0 +1,254 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/tonkeeper/tonkeeper-twa-api/pkg/telegram"
+)
+
+type mockOutboxStorage struct {
+	mu          sync.Mutex
+	noAccess    bool
+	settings    NotificationSettings
+	holds       []time.Duration
+	messages    []OutboxMessage
+	sent        []int64
+	failed      []int64
+	rescheduled map[int64]time.Duration
+}
+
+func (m *mockOutboxStorage) AddToOutbox(ctx context.Context, msg telegram.Message, hold time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.messages = append(m.messages, OutboxMessage{ID: int64(len(m.messages) + 1), Message: msg, Held: hold > 0})
+	m.holds = append(m.holds, hold)
+	return nil
+}
+
+func (m *mockOutboxStorage) ClaimOutboxMessages(ctx context.Context, limit int, lease time.Duration) ([]OutboxMessage, error) {
+	return m.messages, nil
+}
+
+func (m *mockOutboxStorage) MarkOutboxMessageSent(ctx context.Context, id int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sent = append(m.sent, id)
+	return nil
+}
+
+func (m *mockOutboxStorage) RescheduleOutboxMessage(ctx context.Context, id int64, reason string, delay time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.rescheduled[id] = delay
+	return nil
+}
+
+func (m *mockOutboxStorage) MarkOutboxMessageFailed(ctx context.Context, id int64, reason string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.failed = append(m.failed, id)
+	return nil
+}
+
+func (m *mockOutboxStorage) DeleteCompletedOutboxMessages(ctx context.Context, olderThan time.Duration) (int64, error) {
+	return 0, nil
+}
+
+func (m *mockOutboxStorage) GetNotificationSettings(ctx context.Context, userID telegram.UserID) (NotificationSettings, error) {
+	return m.settings, nil
+}
+
+func (m *mockOutboxStorage) HasWriteAccess(ctx context.Context, userID telegram.UserID) (bool, error) {
+	return !m.noAccess, nil
+}
+
+var _ OutboxStorage = (*mockOutboxStorage)(nil)
+
+type mockNotifier struct {
+	OnNotify func(ctx context.Context, msg telegram.Message) error
+}
+
+func (m *mockNotifier) Notify(ctx context.Context, msg telegram.Message) error {
+	return m.OnNotify(ctx, msg)
+}
+
+func TestOutbox_dispatchBatch(t *testing.T) {
+	s := &mockOutboxStorage{
+		messages: []OutboxMessage{
+			{ID: 1, Message: telegram.Message{UserID: 1, Text: "sent"}, Attempts: 1},
+			{ID: 2, Message: telegram.Message{UserID: 2, Text: "blocked"}, Attempts: 1},
+			{ID: 3, Message: telegram.Message{UserID: 3, Text: "timeout"}, Attempts: 2},
+			{ID: 4, Message: telegram.Message{UserID: 4, Text: "timeout"}, Attempts: outboxMaxAttempts},
+		},
+		rescheduled: map[int64]time.Duration{},
+	}
+	notifier := &mockNotifier{
+		OnNotify: func(ctx context.Context, msg telegram.Message) error {
+			switch msg.Text {
+			case "blocked":
+				return &telegram.DeliveryError{
+					Kind: telegram.ErrorKindPermanent,
+					Err:  &tgbotapi.Error{Code: 403, Message: "Forbidden: bot was blocked by the user"},
+				}
+			case "timeout":
+				return &telegram.DeliveryError{Kind: telegram.ErrorKindTransient, Err: errors.New("timeout")}
+			}
+			return nil
+		},
+	}
+	outbox := NewOutbox(zap.L(), s, notifier)
+	claimed, err := outbox.dispatchBatch(context.Background())
+	require.Nil(t, err)
+	require.Equal(t, 4, claimed)
+	require.Equal(t, []int64{1}, s.sent)
+	require.ElementsMatch(t, []int64{2, 4}, s.failed)
+	require.Equal(t, map[int64]time.Duration{3: 40 * time.Second}, s.rescheduled)
+}
+
+func TestOutbox_dispatchBatch_heldSummary(t *testing.T) {
+	s := &mockOutboxStorage{
+		messages: []OutboxMessage{
+			{ID: 1, Message: telegram.Message{UserID: 1, Text: "a"}, Attempts: 1, Held: true},
+			{ID: 2, Message: telegram.Message{UserID: 2, Text: "b"}, Attempts: 1},
+			{ID: 3, Message: telegram.Message{UserID: 1, Text: "c"}, Attempts: 1, Held: true},
+		},
+		rescheduled: map[int64]time.Duration{},
+	}
+	recorder := &RecordingNotifier{}
+	outbox := NewOutbox(zap.L(), s, recorder)
+	claimed, err := outbox.dispatchBatch(context.Background())
+	require.Nil(t, err)
+	require.Equal(t, 3, claimed)
+	require.ElementsMatch(t, []int64{1, 2, 3}, s.sent)
+	require.ElementsMatch(t, []telegram.Message{
+		{UserID: 1, Text: "a\n\nc"},
+		{UserID: 2, Text: "b"},
+	}, recorder.Messages())
+}
+
+func TestOutbox_Shutdown(t *testing.T) {
+	tests := []struct {
+		name            string
+		release         bool
+		wantErr         error
+		wantSent        []int64
+		wantRescheduled map[int64]time.Duration
+	}{
+		{
+			name:            "claimed messages are delivered",
+			release:         true,
+			wantSent:        []int64{1},
+			wantRescheduled: map[int64]time.Duration{},
+		},
+		{
+			name:            "canceled deliveries are returned to the outbox",
+			wantErr:         context.DeadlineExceeded,
+			wantRescheduled: map[int64]time.Duration{1: 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &mockOutboxStorage{
+				messages:    []OutboxMessage{{ID: 1, Message: telegram.Message{UserID: 1, Text: "a"}, Attempts: 1}},
+				rescheduled: map[int64]time.Duration{},
+			}
+			started := make(chan struct{})
+			release := make(chan struct{})
+			notifier := &mockNotifier{
+				OnNotify: func(ctx context.Context, msg telegram.Message) error {
+					close(started)
+					select {
+					case <-release:
+						return nil
+					case <-ctx.Done():
+						return ctx.Err()
+					}
+				},
+			}
+			outbox := NewOutbox(zap.L(), s, notifier)
+			go outbox.Dispatch(context.Background())
+			<-started
+
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+			if tt.release {
+				close(release)
+			}
+			err := outbox.Shutdown(ctx)
+			require.Equal(t, tt.wantErr, err)
+			require.Equal(t, tt.wantSent, s.sent)
+			require.Equal(t, tt.wantRescheduled, s.rescheduled)
+		})
+	}
+}
+
+func TestOutbox_Notify(t *testing.T) {
+	now := time.Now().UTC()
+	start := now.Add(-time.Hour)
+	// quiet hours from an hour ago till two hours later.
+	quietHours := &QuietHours{Start: start.Hour()*60 + start.Minute(), End: (now.Hour()+2)%24*60 + now.Minute()}
+	tests := []struct {
+		name      string
+		noAccess  bool
+		settings  NotificationSettings
+		priority  telegram.Priority
+		wantAdded bool
+		wantHeld  bool
+	}{
+		{
+			name:      "no settings",
+			wantAdded: true,
+		},
+		{
+			name:     "no write access",
+			noAccess: true,
+			priority: telegram.PriorityHigh,
+		},
+		{
+			name:     "muted",
+			settings: NotificationSettings{MuteUntil: now.Add(time.Hour)},
+		},
+		{
+			name:      "mute is over",
+			settings:  NotificationSettings{MuteUntil: now.Add(-time.Hour)},
+			wantAdded: true,
+		},
+		{
+			name:      "quiet hours",
+			settings:  NotificationSettings{QuietHours: quietHours},
+			wantAdded: true,
+			wantHeld:  true,
+		},
+		{
+			name:      "high priority bypasses quiet hours",
+			settings:  NotificationSettings{QuietHours: quietHours, MuteUntil: now.Add(time.Hour)},
+			priority:  telegram.PriorityHigh,
+			wantAdded: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &mockOutboxStorage{noAccess: tt.noAccess, settings: tt.settings}
+			outbox := NewOutbox(zap.L(), s, nil)
+			err := outbox.Notify(context.Background(), telegram.Message{UserID: 1, Text: "a", Priority: tt.priority})
+			require.Nil(t, err)
+			if !tt.wantAdded {
+				require.Empty(t, s.messages)
+				return
+			}
+			require.Len(t, s.messages, 1)
+			require.Equal(t, tt.wantHeld, s.messages[0].Held)
+			if tt.wantHeld {
+				require.InDelta(t, 2*time.Hour, s.holds[0], float64(time.Minute))
+			}
+		})
+	}
+}
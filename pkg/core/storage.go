@@ -2,6 +2,7 @@ package core
 
 import (
 	"context"
+	"time"
 
 	"github.com/tonkeeper/tongo/ton"
 	"github.com/tonkeeper/tonkeeper-twa-api/pkg/telegram"
@@ -10,21 +11,101 @@ import (
 type AccountEventsSubscription struct {
 	TelegramUserID telegram.UserID
 	Account        ton.AccountID
+	// CreatedAt is set only by methods listing subscriptions of a single user.
+	CreatedAt time.Time
 }
 
 type BridgeSubscription struct {
 	TelegramUserID telegram.UserID
 	ClientID       ClientID
 	Origin         string
+	// CreatedAt is set only by methods listing subscriptions of a single user.
+	CreatedAt time.Time
 }
 
 type Storage interface {
 	SubscribeToAccountEvents(ctx context.Context, userID telegram.UserID, account ton.Address) error
 	GetAccountEventsSubscriptions(ctx context.Context) ([]AccountEventsSubscription, error)
-	UnsubscribeAccountEvents(ctx context.Context, userID telegram.UserID) error
+	// GetUserAccountEventsSubscriptions returns account-events subscriptions of a user, the oldest first.
+	GetUserAccountEventsSubscriptions(ctx context.Context, userID telegram.UserID) ([]AccountEventsSubscription, error)
+	// GetSubscriptionFilters returns filters of a user's subscription to an account.
+	// It returns ErrSubscriptionNotFound if the user isn't subscribed to the account.
+	GetSubscriptionFilters(ctx context.Context, userID telegram.UserID, account ton.AccountID) (SubscriptionFilters, error)
+	// SaveSubscriptionFilters replaces filters of a user's subscription to an account.
+	// It returns ErrSubscriptionNotFound if the user isn't subscribed to the account.
+	SaveSubscriptionFilters(ctx context.Context, userID telegram.UserID, account ton.AccountID, filters SubscriptionFilters) error
+	// GetAccountSubscriptionFilters returns filters of the given subscribers of an account.
+	// Subscribers without filters get zero SubscriptionFilters or are missing in the result.
+	GetAccountSubscriptionFilters(ctx context.Context, account ton.AccountID, userIDs []telegram.UserID) (map[telegram.UserID]SubscriptionFilters, error)
+	// UnsubscribeAccountEvents removes a subscription to the given account or, if account is nil, all subscriptions of a user.
+	UnsubscribeAccountEvents(ctx context.Context, userID telegram.UserID, account *ton.AccountID) error
 
 	SubscribeToBridgeEvents(ctx context.Context, userID telegram.UserID, clientID ClientID, origin string) error
 	UnsubscribeFromBridgeEvents(ctx context.Context, userID telegram.UserID, clientID *ClientID) error
 
 	GetBridgeSubscriptions(ctx context.Context) ([]BridgeSubscription, error)
+	// GetUserBridgeSubscriptions returns bridge subscriptions of a user, the oldest first.
+	GetUserBridgeSubscriptions(ctx context.Context, userID telegram.UserID) ([]BridgeSubscription, error)
+
+	// SaveUnreachableUser records why we can't send messages to a user anymore
+	// and revokes the write access of the user.
+	SaveUnreachableUser(ctx context.Context, userID telegram.UserID, reason string) error
+
+	// SetMuteUntil mutes notifications for a user until the given moment, zero until unmutes them.
+	// Other notification settings of the user are kept.
+	SetMuteUntil(ctx context.Context, userID telegram.UserID, until time.Time) error
+	// GetNotificationSettings returns notification settings of a user.
+	// Users without settings get zero NotificationSettings.
+	GetNotificationSettings(ctx context.Context, userID telegram.UserID) (NotificationSettings, error)
+	SaveNotificationSettings(ctx context.Context, userID telegram.UserID, settings NotificationSettings) error
+
+	// SaveUser creates or updates a telegram user.
+	// A write access granted earlier is kept even if the user doesn't allow writing to PM in the TWA.
+	SaveUser(ctx context.Context, user telegram.User) error
+	// TouchUser updates when a user was last seen.
+	// Requests with a session token don't carry a profile, so only last_seen changes.
+	TouchUser(ctx context.Context, userID telegram.UserID) error
+	// GrantWriteAccess records that the bot is allowed to send messages to a user,
+	// for example, because the user has started the bot.
+	GrantWriteAccess(ctx context.Context, userID telegram.UserID) error
+	// HasWriteAccess returns true if the bot is allowed to send messages to a user.
+	HasWriteAccess(ctx context.Context, userID telegram.UserID) (bool, error)
+	// GetLanguageCodes returns language codes of the given users.
+	// Users we know nothing about are missing in the result.
+	GetLanguageCodes(ctx context.Context, userIDs []telegram.UserID) (map[telegram.UserID]string, error)
+
+	// GetStreamPosition returns the last processed event of an SSE stream.
+	// Streams without a saved position get zero StreamPosition.
+	GetStreamPosition(ctx context.Context, stream string) (StreamPosition, error)
+	SaveStreamPosition(ctx context.Context, stream string, position StreamPosition) error
+	DeleteStreamPosition(ctx context.Context, stream string) error
+	// SaveStreamGap records a period of an SSE stream whose events were lost, so a backfill can process them.
+	SaveStreamGap(ctx context.Context, gap StreamGap) error
+}
+
+// OutboxMessage is a message waiting in the outbox to be delivered.
+type OutboxMessage struct {
+	ID       int64
+	Message  telegram.Message
+	Attempts int
+	// Held is true if the message was held during the recipient's quiet hours.
+	Held bool
+}
+
+type OutboxStorage interface {
+	// AddToOutbox adds a message to the outbox.
+	// A positive hold postpones the delivery and marks the message as held.
+	AddToOutbox(ctx context.Context, msg telegram.Message, hold time.Duration) error
+	// ClaimOutboxMessages returns up to limit pending messages and locks them for the given lease duration,
+	// so other replicas won't pick them up while we are delivering them.
+	ClaimOutboxMessages(ctx context.Context, limit int, lease time.Duration) ([]OutboxMessage, error)
+	MarkOutboxMessageSent(ctx context.Context, id int64) error
+	// RescheduleOutboxMessage returns a message to the outbox to be delivered again after the given delay.
+	RescheduleOutboxMessage(ctx context.Context, id int64, reason string, delay time.Duration) error
+	MarkOutboxMessageFailed(ctx context.Context, id int64, reason string) error
+	// DeleteCompletedOutboxMessages deletes sent and failed messages created more than olderThan ago
+	// and returns how many messages have been deleted.
+	DeleteCompletedOutboxMessages(ctx context.Context, olderThan time.Duration) (int64, error)
+	GetNotificationSettings(ctx context.Context, userID telegram.UserID) (NotificationSettings, error)
+	HasWriteAccess(ctx context.Context, userID telegram.UserID) (bool, error)
 }
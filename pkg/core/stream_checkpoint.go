@@ -0,0 +1,159 @@
+package core
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	streamCheckpointInterval = time.Second
+	streamFlushTimeout       = 5 * time.Second
+)
+
+// StreamPosition is the last processed event of an SSE stream.
+type StreamPosition struct {
+	EventID string
+	// EventAt is when the event was processed.
+	EventAt time.Time
+}
+
+// StreamGap is a period of an SSE stream whose events were lost and need a backfill.
+type StreamGap struct {
+	Stream string
+	// LastEventID is the last event processed before the gap.
+	LastEventID string
+	From        time.Time
+	To          time.Time
+}
+
+// streamCheckpoint remembers the last processed event of an SSE stream.
+// Events are processed concurrently and can finish out of order,
+// so the position moves to an event only when it and all events received before it are processed.
+// Saving every event would put the whole stream into storage,
+// so the position is saved periodically and a few events can be processed twice after a restart.
+type streamCheckpoint struct {
+	logger  *zap.Logger
+	storage Storage
+	stream  string
+
+	mu       sync.Mutex
+	position StreamPosition
+	saved    string
+	// pending contains received events in the order of the stream starting from the oldest unprocessed one.
+	pending []pendingEvent
+	// firstSeq is the sequence number of pending[0].
+	firstSeq uint64
+}
+
+type pendingEvent struct {
+	id        string
+	processed bool
+}
+
+func newStreamCheckpoint(logger *zap.Logger, storage Storage, stream string) *streamCheckpoint {
+	return &streamCheckpoint{
+		logger:  logger,
+		storage: storage,
+		stream:  stream,
+	}
+}
+
+// load restores the position saved by a previous run.
+func (c *streamCheckpoint) load(ctx context.Context) error {
+	position, err := c.storage.GetStreamPosition(ctx, c.stream)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.position = position
+	c.saved = position.EventID
+	return nil
+}
+
+// seed starts the stream from a position saved under another name.
+// The position is saved under the stream's name by the next flush.
+func (c *streamCheckpoint) seed(position StreamPosition) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.position = position
+}
+
+// received remembers a received event and returns its sequence number to be passed to processed.
+func (c *streamCheckpoint) received(eventID string) uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.pending = append(c.pending, pendingEvent{id: eventID})
+	return c.firstSeq + uint64(len(c.pending)-1)
+}
+
+// processed marks the received event with the given sequence number as processed
+// and moves the position to the last event processed along with all events before it.
+func (c *streamCheckpoint) processed(seq uint64, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if seq < c.firstSeq || seq-c.firstSeq >= uint64(len(c.pending)) {
+		return
+	}
+	c.pending[seq-c.firstSeq].processed = true
+	for len(c.pending) > 0 && c.pending[0].processed {
+		if len(c.pending[0].id) > 0 {
+			c.position = StreamPosition{EventID: c.pending[0].id, EventAt: now}
+		}
+		c.pending = c.pending[1:]
+		c.firstSeq++
+	}
+}
+
+// reset starts the stream from now when it can't be resumed from the position.
+// The events received before are forgotten, so they don't move the position back to them.
+func (c *streamCheckpoint) reset(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.position = StreamPosition{EventAt: now}
+	c.firstSeq += uint64(len(c.pending))
+	c.pending = nil
+}
+
+func (c *streamCheckpoint) last() StreamPosition {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.position
+}
+
+// flush saves the position if it has changed since the last flush.
+func (c *streamCheckpoint) flush(ctx context.Context) error {
+	c.mu.Lock()
+	position, saved := c.position, c.saved
+	c.mu.Unlock()
+	if position.EventID == saved {
+		return nil
+	}
+	if err := c.storage.SaveStreamPosition(ctx, c.stream, position); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	c.saved = position.EventID
+	c.mu.Unlock()
+	return nil
+}
+
+// run flushes the position periodically until ctx is done.
+// The final position is saved with flush once the received events are processed.
+func (c *streamCheckpoint) run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := c.flush(ctx); err != nil {
+				c.logger.Error("failed to save sse stream position", zap.Error(err))
+			}
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func Test_streamCheckpoint_flush(t *testing.T) {
+	var saved []StreamPosition
+	s := &mockStorage{
+		OnSaveStreamPosition: func(ctx context.Context, stream string, position StreamPosition) error {
+			require.Equal(t, "stream", stream)
+			saved = append(saved, position)
+			return nil
+		},
+	}
+	c := newStreamCheckpoint(zap.L(), s, "stream")
+	ctx := context.Background()
+
+	// nothing has been processed yet.
+	require.Nil(t, c.flush(ctx))
+	require.Empty(t, saved)
+
+	now := time.Now()
+	c.processed(c.received("1"), now)
+	c.processed(c.received(""), now)
+	c.processed(c.received("2"), now)
+	require.Nil(t, c.flush(ctx))
+	require.Nil(t, c.flush(ctx))
+	require.Equal(t, []StreamPosition{{EventID: "2", EventAt: now}}, saved)
+}
+
+func Test_streamCheckpoint_processed(t *testing.T) {
+	c := newStreamCheckpoint(zap.L(), &mockStorage{}, "stream")
+	now := time.Now()
+
+	first := c.received("1")
+	second := c.received("2")
+	third := c.received("3")
+
+	// "1" is still being processed, so "2" can't be saved as the position.
+	c.processed(second, now)
+	require.Equal(t, StreamPosition{}, c.last())
+
+	c.processed(first, now)
+	require.Equal(t, StreamPosition{EventID: "2", EventAt: now}, c.last())
+
+	c.processed(third, now)
+	require.Equal(t, StreamPosition{EventID: "3", EventAt: now}, c.last())
+	// an event processed twice doesn't move the position.
+	c.processed(first, now)
+	require.Equal(t, StreamPosition{EventID: "3", EventAt: now}, c.last())
+}
+
+func Test_streamCheckpoint_reset(t *testing.T) {
+	var saved []StreamPosition
+	s := &mockStorage{
+		OnSaveStreamPosition: func(ctx context.Context, stream string, position StreamPosition) error {
+			saved = append(saved, position)
+			return nil
+		},
+	}
+	c := newStreamCheckpoint(zap.L(), s, "stream")
+	ctx := context.Background()
+	now := time.Now()
+
+	c.processed(c.received("1"), now)
+	require.Nil(t, c.flush(ctx))
+	inFlight := c.received("2")
+
+	resetAt := now.Add(time.Minute)
+	c.reset(resetAt)
+	require.Equal(t, StreamPosition{EventAt: resetAt}, c.last())
+	// an event received before the reset doesn't move the position back.
+	c.processed(inFlight, now)
+	require.Equal(t, StreamPosition{EventAt: resetAt}, c.last())
+
+	c.processed(c.received("10"), resetAt)
+	require.Equal(t, StreamPosition{EventID: "10", EventAt: resetAt}, c.last())
+
+	c.reset(resetAt)
+	require.Nil(t, c.flush(ctx))
+	require.Equal(t, []StreamPosition{{EventID: "1", EventAt: now}, {EventAt: resetAt}}, saved)
+}
@@ -0,0 +1,110 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+
+	tonapiClient "github.com/tonkeeper/opentonapi/client"
+	"github.com/tonkeeper/tongo/ton"
+	"golang.org/x/exp/slices"
+)
+
+// ErrSubscriptionNotFound means a user isn't subscribed to an account.
+var ErrSubscriptionNotFound = errors.New("subscription not found")
+
+// ActionType is a type of action a user can be notified about.
+type ActionType string
+
+const (
+	ActionTypeTonTransfer    ActionType = "ton_transfer"
+	ActionTypeJettonTransfer ActionType = "jetton_transfer"
+	ActionTypeJettonMint     ActionType = "jetton_mint"
+	ActionTypeNftTransfer    ActionType = "nft_transfer"
+	ActionTypeNftPurchase    ActionType = "nft_purchase"
+	ActionTypeJettonSwap     ActionType = "jetton_swap"
+)
+
+var actionTypes = map[tonapiClient.ActionType]ActionType{
+	tonapiClient.ActionTypeTonTransfer:     ActionTypeTonTransfer,
+	tonapiClient.ActionTypeJettonTransfer:  ActionTypeJettonTransfer,
+	tonapiClient.ActionTypeJettonMint:      ActionTypeJettonMint,
+	tonapiClient.ActionTypeNftItemTransfer: ActionTypeNftTransfer,
+	tonapiClient.ActionTypeNftPurchase:     ActionTypeNftPurchase,
+	tonapiClient.ActionTypeJettonSwap:      ActionTypeJettonSwap,
+}
+
+// SubscriptionFilters decide which actions of an account a subscriber is notified about.
+// Zero SubscriptionFilters let everything through.
+// Outgoing transfers aren't filtered, so a user notices an unauthorized spend.
+type SubscriptionFilters struct {
+	// Actions are enabled action types, empty means all types.
+	Actions []ActionType
+	// MinTonAmount is the minimum amount of TON transfers in nanotons.
+	MinTonAmount int64
+	// JettonAllowlist contains jetton masters, if it is not empty, other jettons are filtered out.
+	JettonAllowlist []ton.AccountID
+	// JettonDenylist contains jetton masters which are always filtered out.
+	JettonDenylist      []ton.AccountID
+	OnlyVerifiedJettons bool
+}
+
+// Validate checks that the filters can be applied.
+func (f SubscriptionFilters) Validate() error {
+	for _, actionType := range f.Actions {
+		switch actionType {
+		case ActionTypeTonTransfer, ActionTypeJettonTransfer, ActionTypeJettonMint,
+			ActionTypeNftTransfer, ActionTypeNftPurchase, ActionTypeJettonSwap:
+		default:
+			return fmt.Errorf("unknown action type %q", actionType)
+		}
+	}
+	if f.MinTonAmount < 0 {
+		return fmt.Errorf("min ton amount must not be negative")
+	}
+	return nil
+}
+
+// IsZero returns true if the filters let everything through.
+func (f SubscriptionFilters) IsZero() bool {
+	return len(f.Actions) == 0 && f.MinTonAmount == 0 &&
+		len(f.JettonAllowlist) == 0 && len(f.JettonDenylist) == 0 && !f.OnlyVerifiedJettons
+}
+
+// Allows returns true if a subscriber should be notified about the action.
+func (f SubscriptionFilters) Allows(action tonapiClient.Action) bool {
+	if len(f.Actions) > 0 && !slices.Contains(f.Actions, actionTypes[action.Type]) {
+		return false
+	}
+	switch {
+	case action.Type == tonapiClient.ActionTypeTonTransfer && action.TonTransfer.IsSet():
+		return action.TonTransfer.Value.Amount >= f.MinTonAmount
+	case action.Type == tonapiClient.ActionTypeJettonTransfer && action.JettonTransfer.IsSet():
+		return f.allowsJetton(action.JettonTransfer.Value.Jetton)
+	case action.Type == tonapiClient.ActionTypeJettonMint && action.JettonMint.IsSet():
+		return f.allowsJetton(action.JettonMint.Value.Jetton)
+	case action.Type == tonapiClient.ActionTypeJettonSwap && action.JettonSwap.IsSet():
+		// a swap is filtered out if any of its jettons is.
+		swap := action.JettonSwap.Value
+		for _, jetton := range []tonapiClient.OptJettonPreview{swap.JettonMasterIn, swap.JettonMasterOut} {
+			if jetton.IsSet() && !f.allowsJetton(jetton.Value) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (f SubscriptionFilters) allowsJetton(jetton tonapiClient.JettonPreview) bool {
+	if f.OnlyVerifiedJettons && jetton.Verification != tonapiClient.JettonVerificationTypeWhitelist {
+		return false
+	}
+	master, err := ton.ParseAccountID(jetton.Address)
+	if err != nil {
+		// we can't match an unknown jetton against the lists.
+		return len(f.JettonAllowlist) == 0
+	}
+	if slices.Contains(f.JettonDenylist, master) {
+		return false
+	}
+	return len(f.JettonAllowlist) == 0 || slices.Contains(f.JettonAllowlist, master)
+}
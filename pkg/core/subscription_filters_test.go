@@ -0,0 +1,112 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	tonapiClient "github.com/tonkeeper/opentonapi/client"
+	"github.com/tonkeeper/tongo/ton"
+)
+
+func TestSubscriptionFilters_Allows(t *testing.T) {
+	usdt := ton.MustParseAccountID("0:b113a994b5024a16719f69139328eb759596c38a25f59028b146fecdc3621dfe")
+	scam := ton.MustParseAccountID("0:6ccd325a858c379693fae2bcaab1c2906831a4e10a6c3bb44ee8b615bca1d220")
+	tonTransfer := func(amount int64) tonapiClient.Action {
+		return tonapiClient.Action{
+			Type:        tonapiClient.ActionTypeTonTransfer,
+			TonTransfer: tonapiClient.NewOptTonTransferAction(tonapiClient.TonTransferAction{Amount: amount}),
+		}
+	}
+	jettonTransfer := func(master ton.AccountID, verification tonapiClient.JettonVerificationType) tonapiClient.Action {
+		return tonapiClient.Action{
+			Type: tonapiClient.ActionTypeJettonTransfer,
+			JettonTransfer: tonapiClient.NewOptJettonTransferAction(tonapiClient.JettonTransferAction{
+				Jetton: tonapiClient.JettonPreview{Address: master.ToRaw(), Verification: verification},
+			}),
+		}
+	}
+	swap := tonapiClient.Action{
+		Type: tonapiClient.ActionTypeJettonSwap,
+		JettonSwap: tonapiClient.NewOptJettonSwapAction(tonapiClient.JettonSwapAction{
+			TonIn:           tonapiClient.NewOptInt64(1_000_000_000),
+			JettonMasterOut: tonapiClient.NewOptJettonPreview(tonapiClient.JettonPreview{Address: scam.ToRaw()}),
+		}),
+	}
+	tests := []struct {
+		name    string
+		filters SubscriptionFilters
+		action  tonapiClient.Action
+		want    bool
+	}{
+		{
+			name:   "zero filters allow everything",
+			action: jettonTransfer(scam, tonapiClient.JettonVerificationTypeNone),
+			want:   true,
+		},
+		{
+			name:    "disabled action type",
+			filters: SubscriptionFilters{Actions: []ActionType{ActionTypeJettonTransfer}},
+			action:  tonTransfer(1_000_000_000),
+			want:    false,
+		},
+		{
+			name:    "enabled action type",
+			filters: SubscriptionFilters{Actions: []ActionType{ActionTypeTonTransfer}},
+			action:  tonTransfer(1_000_000_000),
+			want:    true,
+		},
+		{
+			name:    "tiny ton transfer",
+			filters: SubscriptionFilters{MinTonAmount: 100_000_000},
+			action:  tonTransfer(1_000),
+			want:    false,
+		},
+		{
+			name:    "ton transfer above minimum",
+			filters: SubscriptionFilters{MinTonAmount: 100_000_000},
+			action:  tonTransfer(100_000_000),
+			want:    true,
+		},
+		{
+			name:    "unverified jetton",
+			filters: SubscriptionFilters{OnlyVerifiedJettons: true},
+			action:  jettonTransfer(scam, tonapiClient.JettonVerificationTypeNone),
+			want:    false,
+		},
+		{
+			name:    "verified jetton",
+			filters: SubscriptionFilters{OnlyVerifiedJettons: true},
+			action:  jettonTransfer(usdt, tonapiClient.JettonVerificationTypeWhitelist),
+			want:    true,
+		},
+		{
+			name:    "jetton not in allowlist",
+			filters: SubscriptionFilters{JettonAllowlist: []ton.AccountID{usdt}},
+			action:  jettonTransfer(scam, tonapiClient.JettonVerificationTypeNone),
+			want:    false,
+		},
+		{
+			name:    "jetton in allowlist",
+			filters: SubscriptionFilters{JettonAllowlist: []ton.AccountID{usdt}},
+			action:  jettonTransfer(usdt, tonapiClient.JettonVerificationTypeNone),
+			want:    true,
+		},
+		{
+			name:    "jetton in denylist",
+			filters: SubscriptionFilters{JettonDenylist: []ton.AccountID{scam}},
+			action:  jettonTransfer(scam, tonapiClient.JettonVerificationTypeNone),
+			want:    false,
+		},
+		{
+			name:    "swap for denied jetton",
+			filters: SubscriptionFilters{JettonDenylist: []ton.AccountID{scam}},
+			action:  swap,
+			want:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.filters.Allows(tt.action))
+		})
+	}
+}
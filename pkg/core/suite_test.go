@@ -2,6 +2,7 @@ package core
 
 import (
 	"context"
+	"time"
 
 	"github.com/tonkeeper/tongo/ton"
 
@@ -12,6 +13,14 @@ type mockStorage struct {
 	OnUnsubscribeFromBridgeEvents func(ctx context.Context, userID telegram.UserID, clientID *ClientID) error
 	OnSubscribeToBridgeEvents     func(ctx context.Context, userID telegram.UserID, clientID ClientID, origin string) error
 	OnGetBridgeSubscriptions      func(ctx context.Context) ([]BridgeSubscription, error)
+	OnSaveUnreachableUser         func(ctx context.Context, userID telegram.UserID, reason string) error
+	OnSetMuteUntil                func(ctx context.Context, userID telegram.UserID, until time.Time) error
+	OnGetLanguageCodes            func(ctx context.Context, userIDs []telegram.UserID) (map[telegram.UserID]string, error)
+	OnGrantWriteAccess            func(ctx context.Context, userID telegram.UserID) error
+	OnGetStreamPosition           func(ctx context.Context, stream string) (StreamPosition, error)
+	OnSaveStreamPosition          func(ctx context.Context, stream string, position StreamPosition) error
+	OnDeleteStreamPosition        func(ctx context.Context, stream string) error
+	OnSaveStreamGap               func(ctx context.Context, gap StreamGap) error
 }
 
 func (m *mockStorage) SubscribeToAccountEvents(ctx context.Context, userID telegram.UserID, account ton.Address) error {
@@ -22,7 +31,15 @@ func (m *mockStorage) GetAccountEventsSubscriptions(ctx context.Context) ([]Acco
 	return nil, nil
 }
 
-func (m *mockStorage) UnsubscribeAccountEvents(ctx context.Context, userID telegram.UserID) error {
+func (m *mockStorage) GetUserAccountEventsSubscriptions(ctx context.Context, userID telegram.UserID) ([]AccountEventsSubscription, error) {
+	return nil, nil
+}
+
+func (m *mockStorage) GetUserBridgeSubscriptions(ctx context.Context, userID telegram.UserID) ([]BridgeSubscription, error) {
+	return nil, nil
+}
+
+func (m *mockStorage) UnsubscribeAccountEvents(ctx context.Context, userID telegram.UserID, account *ton.AccountID) error {
 	return nil
 }
 
@@ -38,4 +55,86 @@ func (m *mockStorage) GetBridgeSubscriptions(ctx context.Context) ([]BridgeSubsc
 	return m.OnGetBridgeSubscriptions(ctx)
 }
 
+func (m *mockStorage) SaveUnreachableUser(ctx context.Context, userID telegram.UserID, reason string) error {
+	return m.OnSaveUnreachableUser(ctx, userID, reason)
+}
+
+func (m *mockStorage) SetMuteUntil(ctx context.Context, userID telegram.UserID, until time.Time) error {
+	return m.OnSetMuteUntil(ctx, userID, until)
+}
+
+func (m *mockStorage) SaveUser(ctx context.Context, user telegram.User) error {
+	return nil
+}
+
+func (m *mockStorage) TouchUser(ctx context.Context, userID telegram.UserID) error {
+	return nil
+}
+
+func (m *mockStorage) GrantWriteAccess(ctx context.Context, userID telegram.UserID) error {
+	if m.OnGrantWriteAccess == nil {
+		return nil
+	}
+	return m.OnGrantWriteAccess(ctx, userID)
+}
+
+func (m *mockStorage) HasWriteAccess(ctx context.Context, userID telegram.UserID) (bool, error) {
+	return true, nil
+}
+
+func (m *mockStorage) GetStreamPosition(ctx context.Context, stream string) (StreamPosition, error) {
+	if m.OnGetStreamPosition == nil {
+		return StreamPosition{}, nil
+	}
+	return m.OnGetStreamPosition(ctx, stream)
+}
+
+func (m *mockStorage) SaveStreamPosition(ctx context.Context, stream string, position StreamPosition) error {
+	if m.OnSaveStreamPosition == nil {
+		return nil
+	}
+	return m.OnSaveStreamPosition(ctx, stream, position)
+}
+
+func (m *mockStorage) DeleteStreamPosition(ctx context.Context, stream string) error {
+	if m.OnDeleteStreamPosition == nil {
+		return nil
+	}
+	return m.OnDeleteStreamPosition(ctx, stream)
+}
+
+func (m *mockStorage) SaveStreamGap(ctx context.Context, gap StreamGap) error {
+	if m.OnSaveStreamGap == nil {
+		return nil
+	}
+	return m.OnSaveStreamGap(ctx, gap)
+}
+
+func (m *mockStorage) GetSubscriptionFilters(ctx context.Context, userID telegram.UserID, account ton.AccountID) (SubscriptionFilters, error) {
+	return SubscriptionFilters{}, nil
+}
+
+func (m *mockStorage) SaveSubscriptionFilters(ctx context.Context, userID telegram.UserID, account ton.AccountID, filters SubscriptionFilters) error {
+	return nil
+}
+
+func (m *mockStorage) GetAccountSubscriptionFilters(ctx context.Context, account ton.AccountID, userIDs []telegram.UserID) (map[telegram.UserID]SubscriptionFilters, error) {
+	return nil, nil
+}
+
+func (m *mockStorage) GetLanguageCodes(ctx context.Context, userIDs []telegram.UserID) (map[telegram.UserID]string, error) {
+	if m.OnGetLanguageCodes == nil {
+		return nil, nil
+	}
+	return m.OnGetLanguageCodes(ctx, userIDs)
+}
+
+func (m *mockStorage) GetNotificationSettings(ctx context.Context, userID telegram.UserID) (NotificationSettings, error) {
+	return NotificationSettings{}, nil
+}
+
+func (m *mockStorage) SaveNotificationSettings(ctx context.Context, userID telegram.UserID, settings NotificationSettings) error {
+	return nil
+}
+
 var _ Storage = (*mockStorage)(nil)
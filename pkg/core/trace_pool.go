@@ -0,0 +1,114 @@
+package core
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/tonkeeper/tongo/ton"
+)
+
+var (
+	traceQueueLength = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "twa_api_trace_queue_length",
+		Help: "Number of traces waiting to be processed",
+	})
+	tracesInFlight = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "twa_api_traces_in_flight",
+		Help: "Number of traces being processed",
+	})
+	droppedTracesCounter = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "twa_api_dropped_traces_counter",
+		Help: "Number of traces dropped because the queue was full",
+	})
+)
+
+const (
+	defaultTraceWorkers   = 16
+	defaultTraceQueueSize = 1000
+)
+
+// TraceOverflow defines what happens to a trace received when the queue of traces is full.
+type TraceOverflow string
+
+const (
+	// TraceOverflowBlock makes the SSE reader wait until there is room in the queue.
+	// Nothing is lost, but the stream falls behind while the queue is full.
+	TraceOverflowBlock TraceOverflow = "block"
+	// TraceOverflowDrop drops the trace, its subscribers aren't notified.
+	TraceOverflowDrop TraceOverflow = "drop"
+)
+
+// traceJob is a trace of subscribed accounts to notify about.
+type traceJob struct {
+	accounts []ton.AccountID
+	hash     string
+	// done is called once the subscribers have been notified.
+	done func()
+}
+
+// tracePool processes traces with a fixed number of workers,
+// so a spike of traces doesn't start a goroutine per trace.
+type tracePool struct {
+	workers  int
+	overflow TraceOverflow
+	queue    chan traceJob
+	wg       sync.WaitGroup
+}
+
+func newTracePool(workers, queueSize int, overflow TraceOverflow) *tracePool {
+	if workers <= 0 {
+		workers = defaultTraceWorkers
+	}
+	if queueSize < 0 {
+		queueSize = defaultTraceQueueSize
+	}
+	if overflow != TraceOverflowDrop {
+		overflow = TraceOverflowBlock
+	}
+	return &tracePool{
+		workers:  workers,
+		overflow: overflow,
+		queue:    make(chan traceJob, queueSize),
+	}
+}
+
+// start starts the workers processing queued traces with process.
+func (p *tracePool) start(process func(job traceJob)) {
+	for i := 0; i < p.workers; i++ {
+		p.wg.Add(1)
+		go func() {
+			defer p.wg.Done()
+			for job := range p.queue {
+				traceQueueLength.Set(float64(len(p.queue)))
+				tracesInFlight.Inc()
+				process(job)
+				tracesInFlight.Dec()
+			}
+		}()
+	}
+}
+
+// submit queues a trace and returns false if it has been dropped.
+func (p *tracePool) submit(job traceJob) bool {
+	if p.overflow == TraceOverflowBlock {
+		p.queue <- job
+		traceQueueLength.Set(float64(len(p.queue)))
+		return true
+	}
+	select {
+	case p.queue <- job:
+		traceQueueLength.Set(float64(len(p.queue)))
+		return true
+	default:
+		droppedTracesCounter.Inc()
+		return false
+	}
+}
+
+// stop waits until the queued traces are processed and stops the workers.
+// Traces can't be submitted after stop.
+func (p *tracePool) stop() {
+	close(p.queue)
+	p.wg.Wait()
+}
@@ -0,0 +1,60 @@
+package core
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_tracePool(t *testing.T) {
+	tests := []struct {
+		name     string
+		overflow TraceOverflow
+		want     []string
+	}{
+		{
+			name:     "overflow is dropped",
+			overflow: TraceOverflowDrop,
+			want:     []string{"a", "b"},
+		},
+		{
+			name:     "reader waits for room",
+			overflow: TraceOverflowBlock,
+			want:     []string{"a", "b", "c"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool := newTracePool(1, 1, tt.overflow)
+			started := make(chan struct{})
+			release := make(chan struct{})
+			var mu sync.Mutex
+			var processed []string
+			pool.start(func(job traceJob) {
+				if job.hash == "a" {
+					close(started)
+					<-release
+				}
+				mu.Lock()
+				processed = append(processed, job.hash)
+				mu.Unlock()
+			})
+
+			pool.submit(traceJob{hash: "a"})
+			<-started
+			// "a" is being processed and "b" fills the queue.
+			require.True(t, pool.submit(traceJob{hash: "b"}))
+			if tt.overflow == TraceOverflowDrop {
+				require.False(t, pool.submit(traceJob{hash: "c"}))
+				close(release)
+			} else {
+				go close(release)
+				require.True(t, pool.submit(traceJob{hash: "c"}))
+			}
+			// stop waits for the queued traces.
+			pool.stop()
+			require.Equal(t, tt.want, processed)
+		})
+	}
+}
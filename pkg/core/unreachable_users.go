@@ -0,0 +1,39 @@
+package core
+
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+
+	"github.com/tonkeeper/tonkeeper-twa-api/pkg/telegram"
+)
+
+var (
+	unreachableUsersCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "twa_api_unreachable_users_counter",
+		Help: "Number of users unsubscribed because they can't receive messages from the bot",
+	}, []string{"reason"})
+)
+
+// UnsubscribeUnreachableUsers returns a callback that cancels all subscriptions of a user
+// who can't receive messages from the bot anymore, for example, because the user blocked the bot.
+func UnsubscribeUnreachableUsers(logger *zap.Logger, storage Storage, notificator *AccountEventsNotificator, bridge *Bridge) telegram.UnreachableUserHandler {
+	return func(userID telegram.UserID, reason string) {
+		logger.Info("unsubscribing unreachable user",
+			zap.Int64("user_id", int64(userID)),
+			zap.String("reason", reason))
+		unreachableUsersCounter.WithLabelValues(reason).Inc()
+
+		if err := notificator.Unsubscribe(userID, nil); err != nil {
+			logger.Error("failed to cancel account-events subscriptions", zap.Error(err))
+		}
+		if err := bridge.Unsubscribe(userID, nil); err != nil {
+			logger.Error("failed to cancel bridge subscriptions", zap.Error(err))
+		}
+		if err := storage.SaveUnreachableUser(context.TODO(), userID, reason); err != nil {
+			logger.Error("failed to save unreachable user", zap.Error(err))
+		}
+	}
+}
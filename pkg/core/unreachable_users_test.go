@@ -0,0 +1,62 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tonkeeper/tongo/ton"
+	"go.uber.org/zap"
+
+	"github.com/tonkeeper/tonkeeper-twa-api/pkg/telegram"
+)
+
+func TestUnsubscribeUnreachableUsers(t *testing.T) {
+	var savedReason string
+	s := &mockStorage{
+		OnUnsubscribeFromBridgeEvents: func(ctx context.Context, userID telegram.UserID, clientID *ClientID) error {
+			require.Equal(t, telegram.UserID(1), userID)
+			require.Nil(t, clientID)
+			return nil
+		},
+		OnSaveUnreachableUser: func(ctx context.Context, userID telegram.UserID, reason string) error {
+			require.Equal(t, telegram.UserID(1), userID)
+			savedReason = reason
+			return nil
+		},
+	}
+	account := ton.MustParseAccountID("0:6ccd325a858c379693fae2bcaab1c2906831a4e10a6c3bb44ee8b615bca1d220")
+	notificator := &AccountEventsNotificator{
+		logger:  zap.L(),
+		storage: s,
+		streams: newAccountStreams(zap.L(), s, "", nil),
+		subsPerUserID: map[telegram.UserID]map[ton.AccountID]struct{}{
+			1: {account: {}},
+			2: {account: {}},
+		},
+		subsPerAccountID: map[ton.AccountID]map[telegram.UserID]struct{}{
+			account: {1: {}, 2: {}},
+		},
+	}
+	bridge := &Bridge{
+		logger:  zap.L(),
+		storage: s,
+		subsPerClientID: map[ClientID]bridgeSubscription{
+			"1001": {Origin: "ton.org", UserID: 1},
+			"2002": {Origin: "dns.ton.org", UserID: 2},
+		},
+		clientIDsPerUser: map[telegram.UserID]map[ClientID]struct{}{
+			1: {"1001": {}},
+			2: {"2002": {}},
+		},
+	}
+	handler := UnsubscribeUnreachableUsers(zap.L(), s, notificator, bridge)
+	handler(1, telegram.ReasonBotBlocked)
+
+	require.Equal(t, telegram.ReasonBotBlocked, savedReason)
+	require.False(t, notificator.IsSubscribed(1, account))
+	require.True(t, notificator.IsSubscribed(2, account))
+	require.Equal(t, map[ClientID]bridgeSubscription{
+		"2002": {Origin: "dns.ton.org", UserID: 2},
+	}, bridge.subsPerClientID)
+}
@@ -0,0 +1,181 @@
+// Package i18n contains translations of texts we send to telegram users.
+package i18n
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// Key identifies a text in the catalog.
+type Key string
+
+const (
+	ReceivedTon           Key = "received_ton"
+	ReceivedJetton        Key = "received_jetton"
+	ReceivedNft           Key = "received_nft"
+	SentTon               Key = "sent_ton"
+	SentJetton            Key = "sent_jetton"
+	SentNft               Key = "sent_nft"
+	SoldNft               Key = "sold_nft"
+	Fee                   Key = "fee"
+	Swap                  Key = "swap"
+	TransactionRequest    Key = "transaction_request"
+	SignDataRequest       Key = "sign_data_request"
+	OpenTonkeeperButton   Key = "open_tonkeeper_button"
+	ViewTransactionButton Key = "view_transaction_button"
+
+	// texts of bot command replies.
+	WelcomeCommand      Key = "welcome_command"
+	NoSubscriptions     Key = "no_subscriptions"
+	WalletsTitle        Key = "wallets_title"
+	ConnectedAppsTitle  Key = "connected_apps_title"
+	MutedCommand        Key = "muted_command"
+	UnmutedCommand      Key = "unmuted_command"
+	UnsubscribedCommand Key = "unsubscribed_command"
+	CommandFailed       Key = "command_failed"
+)
+
+const (
+	English = "en"
+	Russian = "ru"
+
+	DefaultLanguage = English
+)
+
+type locale struct {
+	texts            map[Key]string
+	decimalSeparator string
+	groupSeparator   string
+}
+
+// catalog contains texts formatted with telegram.ParseModeHTML.
+var catalog = map[string]locale{
+	English: {
+		texts: map[Key]string{
+			ReceivedTon:           "Received <b>%v TON</b>",
+			ReceivedJetton:        "Received <b>%v %v</b>",
+			ReceivedNft:           "Received <b>NFT</b>",
+			SentTon:               "Sent <b>%v TON</b> to %v",
+			SentJetton:            "Sent <b>%v %v</b>",
+			SentNft:               "Sent <b>NFT</b>",
+			SoldNft:               "Sold <b>NFT</b>",
+			Fee:                   "Fee <b>%v TON</b>",
+			Swap:                  "Swapping <b>%v</b> for <b>%v</b>",
+			TransactionRequest:    "Transaction for <b>%v</b>",
+			SignDataRequest:       "Data signature request <b>%v</b>",
+			OpenTonkeeperButton:   "Open in Tonkeeper",
+			ViewTransactionButton: "View transaction",
+			WelcomeCommand: "Welcome to <b>Tonkeeper</b>!\n\n" +
+				"Open the wallet and turn on notifications to learn about incoming transfers and dApp requests.\n\n" +
+				"/subscriptions – show what you are subscribed to\n" +
+				"/mute_incoming – mute notifications except for outgoing transfers\n" +
+				"/unmute – unmute notifications\n" +
+				"/stop – turn off all notifications",
+			NoSubscriptions:     "You are not subscribed to any notifications.",
+			WalletsTitle:        "Wallets",
+			ConnectedAppsTitle:  "Connected apps",
+			MutedCommand:        "Notifications are muted, you will still be notified about outgoing transfers. Send /unmute to unmute them.",
+			UnmutedCommand:      "Notifications are unmuted.",
+			UnsubscribedCommand: "You have been unsubscribed from all notifications.",
+			CommandFailed:       "Something went wrong, please try again later.",
+		},
+		decimalSeparator: ".",
+		groupSeparator:   ",",
+	},
+	Russian: {
+		texts: map[Key]string{
+			ReceivedTon:           "Получено <b>%v TON</b>",
+			ReceivedJetton:        "Получено <b>%v %v</b>",
+			ReceivedNft:           "Получен <b>NFT</b>",
+			SentTon:               "Отправлено <b>%v TON</b> на %v",
+			SentJetton:            "Отправлено <b>%v %v</b>",
+			SentNft:               "Отправлен <b>NFT</b>",
+			SoldNft:               "Продан <b>NFT</b>",
+			Fee:                   "Комиссия <b>%v TON</b>",
+			Swap:                  "Обмен <b>%v</b> на <b>%v</b>",
+			TransactionRequest:    "Запрос на транзакцию от <b>%v</b>",
+			SignDataRequest:       "Запрос на подпись данных от <b>%v</b>",
+			OpenTonkeeperButton:   "Открыть в Tonkeeper",
+			ViewTransactionButton: "Посмотреть транзакцию",
+			WelcomeCommand: "Добро пожаловать в <b>Tonkeeper</b>!\n\n" +
+				"Откройте кошелёк и включите уведомления, чтобы узнавать о входящих переводах и запросах dApp.\n\n" +
+				"/subscriptions – показать подписки\n" +
+				"/mute_incoming – отключить уведомления, кроме исходящих переводов\n" +
+				"/unmute – включить уведомления\n" +
+				"/stop – отписаться от всех уведомлений",
+			NoSubscriptions:     "У вас нет подписок на уведомления.",
+			WalletsTitle:        "Кошельки",
+			ConnectedAppsTitle:  "Подключённые приложения",
+			MutedCommand:        "Уведомления отключены, об исходящих переводах мы всё равно сообщим. Отправьте /unmute, чтобы включить их.",
+			UnmutedCommand:      "Уведомления включены.",
+			UnsubscribedCommand: "Вы отписались от всех уведомлений.",
+			CommandFailed:       "Что-то пошло не так, попробуйте позже.",
+		},
+		decimalSeparator: ",",
+		// a non-breaking space keeps a number on one line.
+		groupSeparator: "\u00a0",
+	},
+}
+
+// Printer renders texts in a particular language.
+type Printer struct {
+	language string
+	locale   locale
+}
+
+// NewPrinter returns a Printer for a language code like "en" or "ru-RU".
+// Unsupported languages fall back to DefaultLanguage.
+func NewPrinter(languageCode string) Printer {
+	language := Language(languageCode)
+	return Printer{language: language, locale: catalog[language]}
+}
+
+// Language returns a supported language matching the given language code.
+func Language(languageCode string) string {
+	language := strings.ToLower(languageCode)
+	if i := strings.IndexAny(language, "-_"); i >= 0 {
+		language = language[:i]
+	}
+	if _, ok := catalog[language]; ok {
+		return language
+	}
+	return DefaultLanguage
+}
+
+func (p Printer) Language() string {
+	return p.language
+}
+
+// Sprintf renders a text identified by key with the given arguments.
+func (p Printer) Sprintf(key Key, args ...interface{}) string {
+	text, ok := p.locale.texts[key]
+	if !ok {
+		text = catalog[DefaultLanguage].texts[key]
+	}
+	return fmt.Sprintf(text, args...)
+}
+
+// Decimal formats a number according to the locale, for example, 1,234.5 in English and 1 234,5 in Russian.
+func (p Printer) Decimal(value decimal.Decimal) string {
+	str := value.String()
+	sign := ""
+	if strings.HasPrefix(str, "-") {
+		sign, str = "-", str[1:]
+	}
+	integer, fraction, hasFraction := strings.Cut(str, ".")
+	var b strings.Builder
+	b.WriteString(sign)
+	for i, digit := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			b.WriteString(p.locale.groupSeparator)
+		}
+		b.WriteRune(digit)
+	}
+	if hasFraction {
+		b.WriteString(p.locale.decimalSeparator)
+		b.WriteString(fraction)
+	}
+	return b.String()
+}
@@ -0,0 +1,41 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLanguage(t *testing.T) {
+	require.Equal(t, English, Language("en"))
+	require.Equal(t, Russian, Language("ru"))
+	require.Equal(t, Russian, Language("ru-RU"))
+	require.Equal(t, English, Language("de"))
+	require.Equal(t, English, Language(""))
+}
+
+func TestPrinter_Decimal(t *testing.T) {
+	tests := []struct {
+		name     string
+		language string
+		value    decimal.Decimal
+		want     string
+	}{
+		{name: "en small", language: "en", value: decimal.RequireFromString("0.05"), want: "0.05"},
+		{name: "en thousands", language: "en", value: decimal.RequireFromString("1234567.891"), want: "1,234,567.891"},
+		{name: "en negative", language: "en", value: decimal.RequireFromString("-1234"), want: "-1,234"},
+		{name: "ru thousands", language: "ru", value: decimal.RequireFromString("1234567.891"), want: "1 234 567,891"},
+		{name: "ru hundreds", language: "ru", value: decimal.RequireFromString("100"), want: "100"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, NewPrinter(tt.language).Decimal(tt.value))
+		})
+	}
+}
+
+func TestPrinter_Sprintf(t *testing.T) {
+	require.Equal(t, "Received <b>1,000 TON</b>", NewPrinter("en").Sprintf(ReceivedTon, "1,000"))
+	require.Equal(t, "Получено <b>1,5 TON</b>", NewPrinter("ru").Sprintf(ReceivedTon, "1,5"))
+}
@@ -0,0 +1,44 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/tonkeeper/tonkeeper-twa-api/pkg/core"
+)
+
+var _ core.Deduplicator = (*storage)(nil)
+
+// MarkNotified marks a notification about a trace as sent,
+// so it can be shared by several replicas to notify a user about a trace once.
+// It returns false if the notification has been already marked and its ttl hasn't expired yet.
+// Expired marks are deleted by RunExpiry.
+func (s *storage) MarkNotified(ctx context.Context, key core.NotificationKey, ttl time.Duration) (bool, error) {
+	tag, err := s.pool.Exec(ctx, `
+		INSERT INTO twa.sent_notifications (trace_hash, account, telegram_user_id, expires_at)
+		VALUES ($1, $2, $3, now() + $4 * interval '1 second')
+		ON CONFLICT (trace_hash, account, telegram_user_id)
+		DO UPDATE SET expires_at = excluded.expires_at WHERE twa.sent_notifications.expires_at <= now()`,
+		key.TraceHash, key.Account.ToRaw(), key.UserID, ttl.Seconds())
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+func (s *storage) ConfirmNotified(ctx context.Context, key core.NotificationKey, ttl time.Duration) error {
+	_, err := s.pool.Exec(ctx, `
+		INSERT INTO twa.sent_notifications (trace_hash, account, telegram_user_id, expires_at)
+		VALUES ($1, $2, $3, now() + $4 * interval '1 second')
+		ON CONFLICT (trace_hash, account, telegram_user_id)
+		DO UPDATE SET expires_at = excluded.expires_at`,
+		key.TraceHash, key.Account.ToRaw(), key.UserID, ttl.Seconds())
+	return err
+}
+
+func (s *storage) ReleaseNotified(ctx context.Context, key core.NotificationKey) error {
+	_, err := s.pool.Exec(ctx, `
+		DELETE FROM twa.sent_notifications WHERE trace_hash = $1 AND account = $2 AND telegram_user_id = $3`,
+		key.TraceHash, key.Account.ToRaw(), key.UserID)
+	return err
+}
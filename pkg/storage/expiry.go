@@ -0,0 +1,45 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// expiringTables are tables of rows with a ttl in the expires_at column.
+var expiringTables = []string{
+	"twa.used_init_data",
+	"twa.sent_notifications",
+}
+
+// DeleteExpired deletes rows whose ttl has expired.
+func (s *storage) DeleteExpired(ctx context.Context) error {
+	for _, table := range expiringTables {
+		tag, err := s.pool.Exec(ctx, "DELETE FROM "+table+" WHERE expires_at <= now()")
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() > 0 {
+			s.logger.Debug("expired rows deleted", zap.String("table", table), zap.Int64("count", tag.RowsAffected()))
+		}
+	}
+	return nil
+}
+
+// RunExpiry deletes expired rows every interval until ctx is done,
+// so checking a key doesn't have to clean up the whole table.
+func (s *storage) RunExpiry(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if err := s.DeleteExpired(ctx); err != nil {
+			s.logger.Error("failed to delete expired rows", zap.Error(err))
+		}
+	}
+}
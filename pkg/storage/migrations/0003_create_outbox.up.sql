@@ -0,0 +1,20 @@
+BEGIN;
+
+create table twa.outbox
+(
+    id bigserial
+        constraint outbox_pkey
+            primary key,
+    telegram_user_id bigint not null,
+    payload          jsonb not null,
+    status           text default 'pending' not null,
+    attempts         integer default 0 not null,
+    last_error       text,
+    locked_until     timestamp,
+    created_at       timestamp default now() not null,
+    sent_at          timestamp
+);
+
+create index outbox_pending_idx on twa.outbox (id) where status = 'pending';
+
+COMMIT;
@@ -0,0 +1,12 @@
+BEGIN;
+
+create table twa.unreachable_users
+(
+    telegram_user_id bigint
+        constraint unreachable_user_pkey
+            primary key,
+    reason           text not null,
+    created_at       timestamp default now() not null
+);
+
+COMMIT;
@@ -0,0 +1,12 @@
+BEGIN;
+
+create table twa.notification_settings
+(
+    telegram_user_id bigint
+        constraint notification_settings_pkey
+            primary key,
+    muted            boolean default false not null,
+    updated_at       timestamp default now() not null
+);
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+drop table if exists twa.users;
+
+COMMIT;
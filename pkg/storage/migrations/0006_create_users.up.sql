@@ -0,0 +1,12 @@
+BEGIN;
+
+create table twa.users
+(
+    telegram_user_id bigint
+        constraint user_pkey
+            primary key,
+    language_code    text default '' not null,
+    updated_at       timestamp default now() not null
+);
+
+COMMIT;
@@ -0,0 +1,12 @@
+BEGIN;
+
+alter table twa.outbox
+    drop column if exists held;
+
+alter table twa.notification_settings
+    drop column if exists quiet_hours_start,
+    drop column if exists quiet_hours_end,
+    drop column if exists timezone,
+    drop column if exists mute_until;
+
+COMMIT;
@@ -0,0 +1,12 @@
+BEGIN;
+
+alter table twa.notification_settings
+    add column quiet_hours_start smallint,
+    add column quiet_hours_end   smallint,
+    add column timezone          text default '' not null,
+    add column mute_until        timestamp;
+
+alter table twa.outbox
+    add column held boolean default false not null;
+
+COMMIT;
@@ -0,0 +1,13 @@
+BEGIN;
+
+create table twa.used_init_data
+(
+    key        text
+        constraint used_init_data_pkey
+            primary key,
+    expires_at timestamp not null
+);
+
+create index used_init_data_expires_at_idx on twa.used_init_data (expires_at);
+
+COMMIT;
@@ -0,0 +1,16 @@
+BEGIN;
+
+alter table twa.bridge_subscriptions drop constraint if exists bridge_subscriptions_telegram_user_id_fkey;
+alter table twa.subscriptions drop constraint if exists subscriptions_telegram_user_id_fkey;
+
+alter table twa.users
+    drop column if exists username,
+    drop column if exists first_name,
+    drop column if exists last_name,
+    drop column if exists is_premium,
+    drop column if exists allows_write_to_pm,
+    drop column if exists first_seen;
+
+alter table twa.users rename column last_seen to updated_at;
+
+COMMIT;
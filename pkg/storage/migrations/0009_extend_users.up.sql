@@ -0,0 +1,30 @@
+BEGIN;
+
+alter table twa.users rename column updated_at to last_seen;
+
+alter table twa.users
+    add column username           text default '' not null,
+    add column first_name         text default '' not null,
+    add column last_name          text default '' not null,
+    add column is_premium         boolean default false not null,
+    add column allows_write_to_pm boolean default false not null,
+    add column first_seen         timestamp default now() not null;
+
+update twa.users set first_seen = last_seen;
+
+-- users subscribed before we started to save them.
+insert into twa.users (telegram_user_id)
+select telegram_user_id from twa.subscriptions
+union
+select telegram_user_id from twa.bridge_subscriptions
+on conflict do nothing;
+
+alter table twa.subscriptions
+    add constraint subscriptions_telegram_user_id_fkey
+        foreign key (telegram_user_id) references twa.users (telegram_user_id);
+
+alter table twa.bridge_subscriptions
+    add constraint bridge_subscriptions_telegram_user_id_fkey
+        foreign key (telegram_user_id) references twa.users (telegram_user_id);
+
+COMMIT;
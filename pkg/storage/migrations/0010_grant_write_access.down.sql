@@ -0,0 +1,5 @@
+BEGIN;
+
+-- there is no way to tell which permissions were granted by 0010_grant_write_access.up.sql.
+
+COMMIT;
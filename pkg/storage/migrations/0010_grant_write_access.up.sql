@@ -0,0 +1,10 @@
+BEGIN;
+
+-- users who have subscribed so far have been receiving notifications,
+-- so the bot is allowed to write to them unless they have blocked it.
+update twa.users set allows_write_to_pm = true
+where telegram_user_id not in (select telegram_user_id from twa.unreachable_users)
+  and (telegram_user_id in (select telegram_user_id from twa.subscriptions)
+    or telegram_user_id in (select telegram_user_id from twa.bridge_subscriptions));
+
+COMMIT;
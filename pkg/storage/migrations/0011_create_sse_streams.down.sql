@@ -0,0 +1,6 @@
+BEGIN;
+
+drop table if exists twa.sse_gaps;
+drop table if exists twa.sse_positions;
+
+COMMIT;
@@ -0,0 +1,26 @@
+BEGIN;
+
+create table twa.sse_positions
+(
+    stream     text
+        constraint sse_positions_pkey
+            primary key,
+    event_id   text                    not null,
+    event_at   timestamp               not null,
+    updated_at timestamp default now() not null
+);
+
+create table twa.sse_gaps
+(
+    id            bigserial
+        constraint sse_gaps_pkey
+            primary key,
+    stream        text                    not null,
+    last_event_id text                    not null,
+    gap_start     timestamp               not null,
+    gap_end       timestamp               not null,
+    backfilled_at timestamp,
+    created_at    timestamp default now() not null
+);
+
+COMMIT;
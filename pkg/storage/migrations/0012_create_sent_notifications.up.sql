@@ -0,0 +1,15 @@
+BEGIN;
+
+create table twa.sent_notifications
+(
+    trace_hash       text      not null,
+    account          text      not null,
+    telegram_user_id bigint    not null,
+    expires_at       timestamp not null,
+    constraint sent_notifications_pkey
+        primary key (trace_hash, account, telegram_user_id)
+);
+
+create index sent_notifications_expires_at_idx on twa.sent_notifications (expires_at);
+
+COMMIT;
@@ -0,0 +1,10 @@
+BEGIN;
+
+alter table twa.subscriptions
+    drop column if exists actions,
+    drop column if exists min_ton_amount,
+    drop column if exists jetton_allowlist,
+    drop column if exists jetton_denylist,
+    drop column if exists only_verified_jettons;
+
+COMMIT;
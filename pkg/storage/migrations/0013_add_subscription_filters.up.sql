@@ -0,0 +1,10 @@
+BEGIN;
+
+alter table twa.subscriptions
+    add column actions               text[],
+    add column min_ton_amount        bigint  default 0 not null,
+    add column jetton_allowlist      text[],
+    add column jetton_denylist       text[],
+    add column only_verified_jettons boolean default false not null;
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+drop index if exists twa.outbox_completed_idx;
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+-- the retention sweep deletes completed messages by age.
+create index outbox_completed_idx on twa.outbox (created_at) where status in ('sent', 'failed');
+
+COMMIT;
@@ -0,0 +1,10 @@
+BEGIN;
+
+alter table twa.notification_settings
+    add column muted boolean default false not null;
+
+update twa.notification_settings
+set muted = true, mute_until = null
+where mute_until >= '9999-12-31';
+
+COMMIT;
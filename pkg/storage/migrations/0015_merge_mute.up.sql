@@ -0,0 +1,10 @@
+BEGIN;
+
+update twa.notification_settings
+set mute_until = '9999-12-31'
+where muted;
+
+alter table twa.notification_settings
+    drop column muted;
+
+COMMIT;
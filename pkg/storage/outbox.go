@@ -0,0 +1,83 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/tonkeeper/tonkeeper-twa-api/pkg/core"
+	"github.com/tonkeeper/tonkeeper-twa-api/pkg/telegram"
+)
+
+var _ core.OutboxStorage = (*storage)(nil)
+
+func (s *storage) AddToOutbox(ctx context.Context, msg telegram.Message, hold time.Duration) error {
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	var lockedUntil *float64
+	if hold > 0 {
+		seconds := hold.Seconds()
+		lockedUntil = &seconds
+	}
+	_, err = s.pool.Exec(ctx, `
+		INSERT INTO twa.outbox (telegram_user_id, payload, held, locked_until)
+		VALUES ($1, $2, $3::float8 IS NOT NULL, now() + $3::float8 * interval '1 second')`, msg.UserID, payload, lockedUntil)
+	return err
+}
+
+func (s *storage) ClaimOutboxMessages(ctx context.Context, limit int, lease time.Duration) ([]core.OutboxMessage, error) {
+	rows, err := s.pool.Query(ctx, `
+		UPDATE twa.outbox SET locked_until = now() + $2 * interval '1 second', attempts = attempts + 1
+		WHERE id IN (
+			SELECT id FROM twa.outbox
+			WHERE status = 'pending' AND (locked_until IS NULL OR locked_until <= now())
+			ORDER BY id
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED)
+		RETURNING id, payload, attempts, held`, limit, lease.Seconds())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []core.OutboxMessage
+	for rows.Next() {
+		var msg core.OutboxMessage
+		var payload []byte
+		if err := rows.Scan(&msg.ID, &payload, &msg.Attempts, &msg.Held); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(payload, &msg.Message); err != nil {
+			return nil, err
+		}
+		result = append(result, msg)
+	}
+	return result, rows.Err()
+}
+
+func (s *storage) MarkOutboxMessageSent(ctx context.Context, id int64) error {
+	_, err := s.pool.Exec(ctx, "UPDATE twa.outbox SET status = 'sent', sent_at = now(), locked_until = NULL WHERE id = $1", id)
+	return err
+}
+
+func (s *storage) RescheduleOutboxMessage(ctx context.Context, id int64, reason string, delay time.Duration) error {
+	_, err := s.pool.Exec(ctx, "UPDATE twa.outbox SET last_error = $2, locked_until = now() + $3 * interval '1 second' WHERE id = $1", id, reason, delay.Seconds())
+	return err
+}
+
+func (s *storage) MarkOutboxMessageFailed(ctx context.Context, id int64, reason string) error {
+	_, err := s.pool.Exec(ctx, "UPDATE twa.outbox SET status = 'failed', last_error = $2, locked_until = NULL WHERE id = $1", id, reason)
+	return err
+}
+
+func (s *storage) DeleteCompletedOutboxMessages(ctx context.Context, olderThan time.Duration) (int64, error) {
+	tag, err := s.pool.Exec(ctx, `
+		DELETE FROM twa.outbox
+		WHERE status IN ('sent', 'failed') AND created_at < now() - $1 * interval '1 second'`, olderThan.Seconds())
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
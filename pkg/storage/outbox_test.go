@@ -0,0 +1,108 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/tonkeeper/tonkeeper-twa-api/pkg/core"
+	"github.com/tonkeeper/tonkeeper-twa-api/pkg/telegram"
+)
+
+func Test_storage_Outbox(t *testing.T) {
+	pool := createDB(t)
+	s := &storage{logger: zap.L(), pool: pool}
+	ctx := context.Background()
+
+	for _, msg := range []telegram.Message{
+		{UserID: 1, Text: "first"},
+		{UserID: 2, Text: "second"},
+		{UserID: 1, Text: "third"},
+	} {
+		require.Nil(t, s.AddToOutbox(ctx, msg, 0))
+	}
+
+	claimed, err := s.ClaimOutboxMessages(ctx, 2, time.Minute)
+	require.Nil(t, err)
+	require.Equal(t, []core.OutboxMessage{
+		{ID: 1, Message: telegram.Message{UserID: 1, Text: "first"}, Attempts: 1},
+		{ID: 2, Message: telegram.Message{UserID: 2, Text: "second"}, Attempts: 1},
+	}, claimed)
+
+	// claimed messages are locked.
+	claimed, err = s.ClaimOutboxMessages(ctx, 10, time.Minute)
+	require.Nil(t, err)
+	require.Equal(t, []core.OutboxMessage{
+		{ID: 3, Message: telegram.Message{UserID: 1, Text: "third"}, Attempts: 1},
+	}, claimed)
+
+	require.Nil(t, s.MarkOutboxMessageSent(ctx, 1))
+	require.Nil(t, s.MarkOutboxMessageFailed(ctx, 2, "bot was blocked by the user"))
+	require.Nil(t, s.RescheduleOutboxMessage(ctx, 3, "timeout", 0))
+
+	claimed, err = s.ClaimOutboxMessages(ctx, 10, time.Minute)
+	require.Nil(t, err)
+	require.Equal(t, []core.OutboxMessage{
+		{ID: 3, Message: telegram.Message{UserID: 1, Text: "third"}, Attempts: 2},
+	}, claimed)
+
+	var statuses []string
+	rows, err := pool.Query(ctx, "SELECT status FROM twa.outbox ORDER BY id")
+	require.Nil(t, err)
+	defer rows.Close()
+	for rows.Next() {
+		var status string
+		require.Nil(t, rows.Scan(&status))
+		statuses = append(statuses, status)
+	}
+	require.Equal(t, []string{"sent", "failed", "pending"}, statuses)
+
+	deleted, err := s.DeleteCompletedOutboxMessages(ctx, time.Hour)
+	require.Nil(t, err)
+	require.Equal(t, int64(0), deleted)
+	// pending messages are kept whatever their age.
+	deleted, err = s.DeleteCompletedOutboxMessages(ctx, 0)
+	require.Nil(t, err)
+	require.Equal(t, int64(2), deleted)
+}
+
+func Test_storage_NotificationSettings(t *testing.T) {
+	pool := createDB(t)
+	s := &storage{logger: zap.L(), pool: pool}
+	ctx := context.Background()
+
+	settings, err := s.GetNotificationSettings(ctx, 1)
+	require.Nil(t, err)
+	require.Equal(t, core.NotificationSettings{}, settings)
+
+	muteUntil := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	want := core.NotificationSettings{
+		QuietHours: &core.QuietHours{Start: 23 * 60, End: 8 * 60},
+		Timezone:   "Europe/Berlin",
+		MuteUntil:  muteUntil,
+	}
+	require.Nil(t, s.SaveNotificationSettings(ctx, 1, want))
+	settings, err = s.GetNotificationSettings(ctx, 1)
+	require.Nil(t, err)
+	require.Equal(t, want, settings)
+
+	// /mute_incoming and /unmute change the same mute the settings API reports and keep other settings.
+	require.Nil(t, s.SetMuteUntil(ctx, 1, core.MuteForever))
+	settings, err = s.GetNotificationSettings(ctx, 1)
+	require.Nil(t, err)
+	require.Equal(t, core.MuteForever, settings.MuteUntil)
+	require.Equal(t, want.QuietHours, settings.QuietHours)
+	require.Nil(t, s.SetMuteUntil(ctx, 1, time.Time{}))
+	settings, err = s.GetNotificationSettings(ctx, 1)
+	require.Nil(t, err)
+	require.True(t, settings.MuteUntil.IsZero())
+
+	// held messages are not claimed until the hold is over.
+	require.Nil(t, s.AddToOutbox(ctx, telegram.Message{UserID: 1, Text: "held"}, time.Hour))
+	claimed, err := s.ClaimOutboxMessages(ctx, 10, time.Minute)
+	require.Nil(t, err)
+	require.Empty(t, claimed)
+}
@@ -0,0 +1,20 @@
+package storage
+
+import (
+	"context"
+	"time"
+)
+
+// UseOnce marks TWA init data as used, so it can be shared by several replicas to reject replayed init data.
+// It returns false if the key has been already used and its ttl hasn't expired yet.
+// Expired keys are deleted by RunExpiry, until then they are reused in place.
+func (s *storage) UseOnce(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	tag, err := s.pool.Exec(ctx, `
+		INSERT INTO twa.used_init_data (key, expires_at) VALUES ($1, now() + $2 * interval '1 second')
+		ON CONFLICT (key) DO UPDATE SET expires_at = excluded.expires_at
+		WHERE twa.used_init_data.expires_at <= now()`, key, ttl.Seconds())
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
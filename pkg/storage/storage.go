@@ -2,8 +2,11 @@ package storage
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
+	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
 	"github.com/tonkeeper/tongo/ton"
 	"github.com/tonkeeper/tonkeeper-twa-api/pkg/core"
@@ -86,8 +89,36 @@ func (s *storage) GetAccountEventsSubscriptions(ctx context.Context) ([]core.Acc
 	return result, nil
 }
 
-func (s *storage) UnsubscribeAccountEvents(ctx context.Context, userID telegram.UserID) error {
-	_, err := s.pool.Exec(ctx, "DELETE FROM twa.subscriptions WHERE telegram_user_id = $1", userID)
+func (s *storage) GetUserAccountEventsSubscriptions(ctx context.Context, userID telegram.UserID) ([]core.AccountEventsSubscription, error) {
+	rows, err := s.pool.Query(ctx, "SELECT account, created_at FROM twa.subscriptions WHERE telegram_user_id = $1 ORDER BY created_at, account", userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []core.AccountEventsSubscription
+	for rows.Next() {
+		sub := core.AccountEventsSubscription{TelegramUserID: userID}
+		var accountID string
+		if err := rows.Scan(&accountID, &sub.CreatedAt); err != nil {
+			return nil, err
+		}
+		account, err := ton.ParseAccountID(accountID)
+		if err != nil {
+			return nil, err
+		}
+		sub.Account = account
+		result = append(result, sub)
+	}
+	return result, rows.Err()
+}
+
+func (s *storage) UnsubscribeAccountEvents(ctx context.Context, userID telegram.UserID, account *ton.AccountID) error {
+	if account == nil {
+		_, err := s.pool.Exec(ctx, "DELETE FROM twa.subscriptions WHERE telegram_user_id = $1", userID)
+		return err
+	}
+	_, err := s.pool.Exec(ctx, "DELETE FROM twa.subscriptions WHERE telegram_user_id = $1 AND account = $2", userID, account.ToRaw())
 	return err
 }
 
@@ -138,3 +169,144 @@ func (s *storage) GetBridgeSubscriptions(ctx context.Context) ([]core.BridgeSubs
 	}
 	return result, nil
 }
+
+func (s *storage) GetUserBridgeSubscriptions(ctx context.Context, userID telegram.UserID) ([]core.BridgeSubscription, error) {
+	rows, err := s.pool.Query(ctx, "SELECT client_id, origin, created_at FROM twa.bridge_subscriptions WHERE telegram_user_id = $1 ORDER BY created_at, origin", userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []core.BridgeSubscription
+	for rows.Next() {
+		sub := core.BridgeSubscription{TelegramUserID: userID}
+		if err := rows.Scan(&sub.ClientID, &sub.Origin, &sub.CreatedAt); err != nil {
+			return nil, err
+		}
+		result = append(result, sub)
+	}
+	return result, rows.Err()
+}
+
+func (s *storage) SaveUnreachableUser(ctx context.Context, userID telegram.UserID, reason string) error {
+	_, err := s.pool.Exec(ctx, `
+		WITH revoked AS (
+			UPDATE twa.users SET allows_write_to_pm = false WHERE telegram_user_id = $1
+		)
+		INSERT INTO twa.unreachable_users (telegram_user_id, reason) VALUES ($1, $2)
+		ON CONFLICT (telegram_user_id)
+		DO UPDATE SET reason = $2, created_at = now()`, userID, reason)
+	return err
+}
+
+func (s *storage) SetMuteUntil(ctx context.Context, userID telegram.UserID, until time.Time) error {
+	var muteUntil *time.Time
+	if !until.IsZero() {
+		// mute_until is stored as a timestamp without timezone in UTC.
+		t := until.UTC()
+		muteUntil = &t
+	}
+	_, err := s.pool.Exec(ctx, `
+		INSERT INTO twa.notification_settings (telegram_user_id, mute_until) VALUES ($1, $2)
+		ON CONFLICT (telegram_user_id)
+		DO UPDATE SET mute_until = $2, updated_at = now()`, userID, muteUntil)
+	return err
+}
+
+func (s *storage) GetNotificationSettings(ctx context.Context, userID telegram.UserID) (core.NotificationSettings, error) {
+	var settings core.NotificationSettings
+	var start, end *int
+	var muteUntil *time.Time
+	err := s.pool.QueryRow(ctx, `
+		SELECT quiet_hours_start, quiet_hours_end, timezone, mute_until
+		FROM twa.notification_settings WHERE telegram_user_id = $1`, userID).Scan(&start, &end, &settings.Timezone, &muteUntil)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return settings, nil
+	}
+	if err != nil {
+		return settings, err
+	}
+	if start != nil && end != nil {
+		settings.QuietHours = &core.QuietHours{Start: *start, End: *end}
+	}
+	if muteUntil != nil {
+		settings.MuteUntil = *muteUntil
+	}
+	return settings, nil
+}
+
+func (s *storage) SaveNotificationSettings(ctx context.Context, userID telegram.UserID, settings core.NotificationSettings) error {
+	var start, end *int
+	if settings.QuietHours != nil {
+		start, end = &settings.QuietHours.Start, &settings.QuietHours.End
+	}
+	var muteUntil *time.Time
+	if !settings.MuteUntil.IsZero() {
+		// mute_until is stored as a timestamp without timezone in UTC.
+		t := settings.MuteUntil.UTC()
+		muteUntil = &t
+	}
+	_, err := s.pool.Exec(ctx, `
+		INSERT INTO twa.notification_settings (telegram_user_id, quiet_hours_start, quiet_hours_end, timezone, mute_until)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (telegram_user_id)
+		DO UPDATE SET quiet_hours_start = $2, quiet_hours_end = $3, timezone = $4, mute_until = $5, updated_at = now()`,
+		userID, start, end, settings.Timezone, muteUntil)
+	return err
+}
+
+func (s *storage) SaveUser(ctx context.Context, user telegram.User) error {
+	_, err := s.pool.Exec(ctx, `
+		INSERT INTO twa.users (telegram_user_id, username, first_name, last_name, language_code, is_premium, allows_write_to_pm)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (telegram_user_id)
+		DO UPDATE SET username = $2, first_name = $3, last_name = $4, language_code = $5,
+		              is_premium = $6, allows_write_to_pm = twa.users.allows_write_to_pm OR $7, last_seen = now()`,
+		user.ID, user.Username, user.FirstName, user.LastName, user.LanguageCode, user.IsPremium, user.AllowsWriteToPM)
+	return err
+}
+
+func (s *storage) TouchUser(ctx context.Context, userID telegram.UserID) error {
+	_, err := s.pool.Exec(ctx, "UPDATE twa.users SET last_seen = now() WHERE telegram_user_id = $1", userID)
+	return err
+}
+
+func (s *storage) GrantWriteAccess(ctx context.Context, userID telegram.UserID) error {
+	_, err := s.pool.Exec(ctx, `
+		INSERT INTO twa.users (telegram_user_id, allows_write_to_pm) VALUES ($1, true)
+		ON CONFLICT (telegram_user_id)
+		DO UPDATE SET allows_write_to_pm = true, last_seen = now()`, userID)
+	return err
+}
+
+func (s *storage) HasWriteAccess(ctx context.Context, userID telegram.UserID) (bool, error) {
+	var allowed bool
+	err := s.pool.QueryRow(ctx, "SELECT allows_write_to_pm FROM twa.users WHERE telegram_user_id = $1", userID).Scan(&allowed)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	return allowed, err
+}
+
+func (s *storage) GetLanguageCodes(ctx context.Context, userIDs []telegram.UserID) (map[telegram.UserID]string, error) {
+	ids := make([]int64, 0, len(userIDs))
+	for _, userID := range userIDs {
+		ids = append(ids, int64(userID))
+	}
+	rows, err := s.pool.Query(ctx, "SELECT telegram_user_id, language_code FROM twa.users WHERE telegram_user_id = ANY($1)", ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make(map[telegram.UserID]string, len(userIDs))
+	for rows.Next() {
+		var userID telegram.UserID
+		var languageCode string
+		if err := rows.Scan(&userID, &languageCode); err != nil {
+			return nil, err
+		}
+		result[userID] = languageCode
+	}
+	return result, rows.Err()
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/jackc/pgx/v4/pgxpool"
 	"github.com/stretchr/testify/require"
@@ -15,7 +16,10 @@ import (
 )
 
 func initDatabase(pool *pgxpool.Pool, t *testing.T) {
-	_, err := pool.Exec(context.Background(), "INSERT INTO twa.bridge_subscriptions (telegram_user_id, client_id, origin) VALUES (1, '1000', 'dns.ton.org')")
+	_, err := pool.Exec(context.Background(), "INSERT INTO twa.users (telegram_user_id) VALUES (1), (2), (3)")
+	require.Nil(t, err)
+
+	_, err = pool.Exec(context.Background(), "INSERT INTO twa.bridge_subscriptions (telegram_user_id, client_id, origin) VALUES (1, '1000', 'dns.ton.org')")
 	require.Nil(t, err)
 	_, err = pool.Exec(context.Background(), "INSERT INTO twa.bridge_subscriptions (telegram_user_id, client_id, origin) VALUES (1, '1001', 'ton.org')")
 	require.Nil(t, err)
@@ -235,3 +239,251 @@ func Test_storage_SubscribeToAccountEvents(t *testing.T) {
 		})
 	}
 }
+
+func Test_storage_SubscriptionFilters(t *testing.T) {
+	pool := createDB(t)
+	initDatabase(pool, t)
+	s := &storage{logger: zap.L(), pool: pool}
+	ctx := context.Background()
+	account := ton.MustParseAccountID("0:bdf3fa8098d129b54b4f73b5bac5d1e1fd91eb054169c3916dfc8ccd536d1000")
+
+	filters, err := s.GetSubscriptionFilters(ctx, 1, account)
+	require.Nil(t, err)
+	require.True(t, filters.IsZero())
+
+	want := core.SubscriptionFilters{
+		Actions:             []core.ActionType{core.ActionTypeTonTransfer, core.ActionTypeJettonTransfer},
+		MinTonAmount:        100_000_000,
+		JettonAllowlist:     []ton.AccountID{ton.MustParseAccountID("0:b113a994b5024a16719f69139328eb759596c38a25f59028b146fecdc3621dfe")},
+		OnlyVerifiedJettons: true,
+	}
+	require.Nil(t, s.SaveSubscriptionFilters(ctx, 1, account, want))
+	filters, err = s.GetSubscriptionFilters(ctx, 1, account)
+	require.Nil(t, err)
+	require.Equal(t, want, filters)
+
+	perUser, err := s.GetAccountSubscriptionFilters(ctx, account, []telegram.UserID{1, 2})
+	require.Nil(t, err)
+	require.Equal(t, map[telegram.UserID]core.SubscriptionFilters{1: want}, perUser)
+
+	// user 2 isn't subscribed to the account.
+	_, err = s.GetSubscriptionFilters(ctx, 2, account)
+	require.ErrorIs(t, err, core.ErrSubscriptionNotFound)
+	require.ErrorIs(t, s.SaveSubscriptionFilters(ctx, 2, account, want), core.ErrSubscriptionNotFound)
+}
+
+func Test_storage_UnsubscribeAccountEvents(t *testing.T) {
+	account := ton.MustParseAccountID("0:bdf3fa8098d129b54b4f73b5bac5d1e1fd91eb054169c3916dfc8ccd536d1000")
+	tests := []struct {
+		name              string
+		userID            telegram.UserID
+		account           *ton.AccountID
+		wantSubscriptions []core.AccountEventsSubscription
+	}{
+		{
+			name:   "remove all subscriptions for user",
+			userID: 1,
+		},
+		{
+			name:    "remove specific subscription for user",
+			userID:  1,
+			account: &account,
+			wantSubscriptions: []core.AccountEventsSubscription{
+				{TelegramUserID: 1, Account: ton.MustParseAccountID("0:6ccd325a858c379693fae2bcaab1c2906831a4e10a6c3bb44ee8b615bca1d220")},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool := createDB(t)
+			initDatabase(pool, t)
+			s := &storage{logger: zap.L(), pool: pool}
+			err := s.UnsubscribeAccountEvents(context.Background(), tt.userID, tt.account)
+			require.Nil(t, err)
+
+			subs, err := s.GetAccountEventsSubscriptions(context.Background())
+			require.Nil(t, err)
+			require.Equal(t, tt.wantSubscriptions, subs)
+		})
+	}
+}
+
+func Test_storage_GetUserSubscriptions(t *testing.T) {
+	pool := createDB(t)
+	initDatabase(pool, t)
+	s := &storage{logger: zap.L(), pool: pool}
+	ctx := context.Background()
+
+	accountSubs, err := s.GetUserAccountEventsSubscriptions(ctx, 1)
+	require.Nil(t, err)
+	require.Len(t, accountSubs, 2)
+	for _, sub := range accountSubs {
+		require.Equal(t, telegram.UserID(1), sub.TelegramUserID)
+		require.False(t, sub.CreatedAt.IsZero())
+	}
+
+	bridgeSubs, err := s.GetUserBridgeSubscriptions(ctx, 2)
+	require.Nil(t, err)
+	require.Len(t, bridgeSubs, 1)
+	require.Equal(t, core.ClientID("2002"), bridgeSubs[0].ClientID)
+	require.Equal(t, "dns.ton.org", bridgeSubs[0].Origin)
+
+	accountSubs, err = s.GetUserAccountEventsSubscriptions(ctx, 3)
+	require.Nil(t, err)
+	require.Empty(t, accountSubs)
+}
+
+func Test_storage_UseOnce(t *testing.T) {
+	pool := createDB(t)
+	s := &storage{logger: zap.L(), pool: pool}
+	ctx := context.Background()
+
+	ok, err := s.UseOnce(ctx, "hash", time.Hour)
+	require.Nil(t, err)
+	require.True(t, ok)
+
+	ok, err = s.UseOnce(ctx, "hash", time.Hour)
+	require.Nil(t, err)
+	require.False(t, ok)
+
+	// expired keys can be used again.
+	_, err = pool.Exec(ctx, "UPDATE twa.used_init_data SET expires_at = now() - interval '1 second'")
+	require.Nil(t, err)
+	ok, err = s.UseOnce(ctx, "hash", time.Hour)
+	require.Nil(t, err)
+	require.True(t, ok)
+
+	_, err = pool.Exec(ctx, "UPDATE twa.used_init_data SET expires_at = now() - interval '1 second'")
+	require.Nil(t, err)
+	require.Nil(t, s.DeleteExpired(ctx))
+	var count int
+	require.Nil(t, pool.QueryRow(ctx, "SELECT count(*) FROM twa.used_init_data").Scan(&count))
+	require.Equal(t, 0, count)
+}
+
+func Test_storage_MarkNotified(t *testing.T) {
+	pool := createDB(t)
+	s := &storage{logger: zap.L(), pool: pool}
+	ctx := context.Background()
+	key := core.NotificationKey{
+		TraceHash: "hash",
+		Account:   ton.MustParseAccountID("0:6ccd325a858c379693fae2bcaab1c2906831a4e10a6c3bb44ee8b615bca1d220"),
+		UserID:    1,
+	}
+
+	ok, err := s.MarkNotified(ctx, key, time.Hour)
+	require.Nil(t, err)
+	require.True(t, ok)
+
+	ok, err = s.MarkNotified(ctx, key, time.Hour)
+	require.Nil(t, err)
+	require.False(t, ok)
+
+	// another user is notified about the same trace.
+	ok, err = s.MarkNotified(ctx, core.NotificationKey{TraceHash: key.TraceHash, Account: key.Account, UserID: 2}, time.Hour)
+	require.Nil(t, err)
+	require.True(t, ok)
+
+	// expired notifications can be marked again.
+	_, err = pool.Exec(ctx, "UPDATE twa.sent_notifications SET expires_at = now() - interval '1 second'")
+	require.Nil(t, err)
+	ok, err = s.MarkNotified(ctx, key, time.Hour)
+	require.Nil(t, err)
+	require.True(t, ok)
+
+	// a released notification can be marked again.
+	require.Nil(t, s.ReleaseNotified(ctx, key))
+	ok, err = s.MarkNotified(ctx, key, time.Hour)
+	require.Nil(t, err)
+	require.True(t, ok)
+
+	// expired notifications are deleted periodically.
+	require.Nil(t, s.ConfirmNotified(ctx, key, -time.Second))
+	require.Nil(t, s.DeleteExpired(ctx))
+	var count int
+	require.Nil(t, pool.QueryRow(ctx, "SELECT count(*) FROM twa.sent_notifications").Scan(&count))
+	require.Equal(t, 1, count)
+}
+
+func Test_storage_SaveUser(t *testing.T) {
+	pool := createDB(t)
+	s := &storage{logger: zap.L(), pool: pool, maxWalletsPerUser: 10}
+	ctx := context.Background()
+
+	user := telegram.User{ID: 1, Username: "durov", FirstName: "Pavel", LanguageCode: "en", AllowsWriteToPM: true}
+	require.Nil(t, s.SaveUser(ctx, user))
+	user.LanguageCode = "ru"
+	require.Nil(t, s.SaveUser(ctx, user))
+
+	var username, languageCode string
+	var allowsWriteToPM bool
+	var firstSeen, lastSeen time.Time
+	err := pool.QueryRow(ctx, "SELECT username, language_code, allows_write_to_pm, first_seen, last_seen FROM twa.users WHERE telegram_user_id = 1").
+		Scan(&username, &languageCode, &allowsWriteToPM, &firstSeen, &lastSeen)
+	require.Nil(t, err)
+	require.Equal(t, "durov", username)
+	require.Equal(t, "ru", languageCode)
+	require.True(t, allowsWriteToPM)
+	require.False(t, lastSeen.Before(firstSeen))
+
+	previousLastSeen := lastSeen
+	require.Nil(t, s.TouchUser(ctx, 1))
+	require.Nil(t, pool.QueryRow(ctx, "SELECT last_seen FROM twa.users WHERE telegram_user_id = 1").Scan(&lastSeen))
+	require.False(t, lastSeen.Before(previousLastSeen))
+
+	// subscriptions reference users.
+	addr := ton.MustParseAccountID("0:6ccd325a858c379693fae2bcaab1c2906831a4e10a6c3bb44ee8b615bca1d220")
+	require.NotNil(t, s.SubscribeToAccountEvents(ctx, 2, ton.Address{ID: addr}))
+}
+
+func Test_storage_WriteAccess(t *testing.T) {
+	pool := createDB(t)
+	s := &storage{logger: zap.L(), pool: pool}
+	ctx := context.Background()
+
+	allowed, err := s.HasWriteAccess(ctx, 100)
+	require.Nil(t, err)
+	require.False(t, allowed)
+
+	// the user has started the bot but doesn't allow writing to PM in the TWA.
+	require.Nil(t, s.GrantWriteAccess(ctx, 100))
+	require.Nil(t, s.SaveUser(ctx, telegram.User{ID: 100, AllowsWriteToPM: false}))
+	allowed, err = s.HasWriteAccess(ctx, 100)
+	require.Nil(t, err)
+	require.True(t, allowed)
+
+	// the user has blocked the bot.
+	require.Nil(t, s.SaveUnreachableUser(ctx, 100, "blocked"))
+	allowed, err = s.HasWriteAccess(ctx, 100)
+	require.Nil(t, err)
+	require.False(t, allowed)
+}
+
+func Test_storage_StreamPosition(t *testing.T) {
+	pool := createDB(t)
+	s := &storage{logger: zap.L(), pool: pool}
+	ctx := context.Background()
+
+	position, err := s.GetStreamPosition(ctx, "stream")
+	require.Nil(t, err)
+	require.Equal(t, core.StreamPosition{}, position)
+
+	eventAt := time.Date(2023, 11, 14, 22, 13, 20, 0, time.UTC)
+	require.Nil(t, s.SaveStreamPosition(ctx, "stream", core.StreamPosition{EventID: "1", EventAt: eventAt}))
+	require.Nil(t, s.SaveStreamPosition(ctx, "stream", core.StreamPosition{EventID: "2", EventAt: eventAt}))
+	position, err = s.GetStreamPosition(ctx, "stream")
+	require.Nil(t, err)
+	require.Equal(t, "2", position.EventID)
+	require.True(t, eventAt.Equal(position.EventAt))
+
+	require.Nil(t, s.DeleteStreamPosition(ctx, "stream"))
+	position, err = s.GetStreamPosition(ctx, "stream")
+	require.Nil(t, err)
+	require.Equal(t, core.StreamPosition{}, position)
+
+	gap := core.StreamGap{Stream: "stream", LastEventID: "2", From: eventAt, To: eventAt.Add(time.Minute)}
+	require.Nil(t, s.SaveStreamGap(ctx, gap))
+	var gaps int
+	require.Nil(t, pool.QueryRow(ctx, "SELECT count(*) FROM twa.sse_gaps WHERE backfilled_at IS NULL").Scan(&gaps))
+	require.Equal(t, 1, gaps)
+}
@@ -0,0 +1,40 @@
+package storage
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v4"
+
+	"github.com/tonkeeper/tonkeeper-twa-api/pkg/core"
+)
+
+func (s *storage) GetStreamPosition(ctx context.Context, stream string) (core.StreamPosition, error) {
+	var position core.StreamPosition
+	err := s.pool.QueryRow(ctx, "SELECT event_id, event_at FROM twa.sse_positions WHERE stream = $1", stream).
+		Scan(&position.EventID, &position.EventAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return core.StreamPosition{}, nil
+	}
+	return position, err
+}
+
+func (s *storage) SaveStreamPosition(ctx context.Context, stream string, position core.StreamPosition) error {
+	_, err := s.pool.Exec(ctx, `
+		INSERT INTO twa.sse_positions (stream, event_id, event_at) VALUES ($1, $2, $3)
+		ON CONFLICT (stream)
+		DO UPDATE SET event_id = $2, event_at = $3, updated_at = now()`, stream, position.EventID, position.EventAt.UTC())
+	return err
+}
+
+func (s *storage) DeleteStreamPosition(ctx context.Context, stream string) error {
+	_, err := s.pool.Exec(ctx, "DELETE FROM twa.sse_positions WHERE stream = $1", stream)
+	return err
+}
+
+func (s *storage) SaveStreamGap(ctx context.Context, gap core.StreamGap) error {
+	_, err := s.pool.Exec(ctx, `
+		INSERT INTO twa.sse_gaps (stream, last_event_id, gap_start, gap_end) VALUES ($1, $2, $3, $4)`,
+		gap.Stream, gap.LastEventID, gap.From.UTC(), gap.To.UTC())
+	return err
+}
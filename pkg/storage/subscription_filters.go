@@ -0,0 +1,109 @@
+package storage
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/tonkeeper/tongo/ton"
+
+	"github.com/tonkeeper/tonkeeper-twa-api/pkg/core"
+	"github.com/tonkeeper/tonkeeper-twa-api/pkg/telegram"
+)
+
+const subscriptionFiltersColumns = "actions, min_ton_amount, jetton_allowlist, jetton_denylist, only_verified_jettons"
+
+func (s *storage) GetSubscriptionFilters(ctx context.Context, userID telegram.UserID, account ton.AccountID) (core.SubscriptionFilters, error) {
+	row := s.pool.QueryRow(ctx, "SELECT "+subscriptionFiltersColumns+" FROM twa.subscriptions WHERE telegram_user_id = $1 AND account = $2",
+		userID, account.ToRaw())
+	filters, err := scanSubscriptionFilters(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return core.SubscriptionFilters{}, core.ErrSubscriptionNotFound
+	}
+	return filters, err
+}
+
+func (s *storage) SaveSubscriptionFilters(ctx context.Context, userID telegram.UserID, account ton.AccountID, filters core.SubscriptionFilters) error {
+	var actions []string
+	for _, actionType := range filters.Actions {
+		actions = append(actions, string(actionType))
+	}
+	tag, err := s.pool.Exec(ctx, `
+		UPDATE twa.subscriptions
+		SET actions = $3, min_ton_amount = $4, jetton_allowlist = $5, jetton_denylist = $6, only_verified_jettons = $7
+		WHERE telegram_user_id = $1 AND account = $2`,
+		userID, account.ToRaw(), actions, filters.MinTonAmount,
+		rawAccounts(filters.JettonAllowlist), rawAccounts(filters.JettonDenylist), filters.OnlyVerifiedJettons)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return core.ErrSubscriptionNotFound
+	}
+	return nil
+}
+
+func (s *storage) GetAccountSubscriptionFilters(ctx context.Context, account ton.AccountID, userIDs []telegram.UserID) (map[telegram.UserID]core.SubscriptionFilters, error) {
+	ids := make([]int64, 0, len(userIDs))
+	for _, userID := range userIDs {
+		ids = append(ids, int64(userID))
+	}
+	rows, err := s.pool.Query(ctx, "SELECT telegram_user_id, "+subscriptionFiltersColumns+" FROM twa.subscriptions WHERE account = $1 AND telegram_user_id = ANY($2)",
+		account.ToRaw(), ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make(map[telegram.UserID]core.SubscriptionFilters, len(userIDs))
+	for rows.Next() {
+		var userID telegram.UserID
+		filters, err := scanSubscriptionFilters(rows, &userID)
+		if err != nil {
+			return nil, err
+		}
+		result[userID] = filters
+	}
+	return result, rows.Err()
+}
+
+// scanSubscriptionFilters scans subscriptionFiltersColumns preceded by the given destinations.
+func scanSubscriptionFilters(row pgx.Row, dest ...any) (core.SubscriptionFilters, error) {
+	var filters core.SubscriptionFilters
+	var actions, allowlist, denylist []string
+	dest = append(dest, &actions, &filters.MinTonAmount, &allowlist, &denylist, &filters.OnlyVerifiedJettons)
+	if err := row.Scan(dest...); err != nil {
+		return core.SubscriptionFilters{}, err
+	}
+	for _, actionType := range actions {
+		filters.Actions = append(filters.Actions, core.ActionType(actionType))
+	}
+	var err error
+	if filters.JettonAllowlist, err = parseAccounts(allowlist); err != nil {
+		return core.SubscriptionFilters{}, err
+	}
+	if filters.JettonDenylist, err = parseAccounts(denylist); err != nil {
+		return core.SubscriptionFilters{}, err
+	}
+	return filters, nil
+}
+
+func rawAccounts(accounts []ton.AccountID) []string {
+	var result []string
+	for _, account := range accounts {
+		result = append(result, account.ToRaw())
+	}
+	return result
+}
+
+func parseAccounts(rawAccounts []string) ([]ton.AccountID, error) {
+	var result []ton.AccountID
+	for _, raw := range rawAccounts {
+		account, err := ton.ParseAccountID(raw)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, account)
+	}
+	return result, nil
+}
@@ -2,6 +2,7 @@ package telegram
 
 import (
 	"context"
+	"sync"
 
 	"github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"github.com/prometheus/client_golang/prometheus"
@@ -20,44 +21,150 @@ var (
 type UserID int64
 
 type bot struct {
-	logger *zap.Logger
-	bot    *tgbotapi.BotAPI
+	logger    *zap.Logger
+	bot       *tgbotapi.BotAPI
+	delivery  *delivery
+	scheduler *scheduler
+
+	onUnreachableUser UnreachableUserHandler
+	commands          map[string]CommandHandler
+
+	// abort is closed by Shutdown to cancel messages which haven't been sent in time.
+	abort     chan struct{}
+	abortOnce sync.Once
+	stopped   chan struct{}
+}
+
+type options struct {
+	globalRateLimit  float64
+	perChatRateLimit float64
 }
 
-func NewBot(logger *zap.Logger, botSecretKey string) (*bot, error) {
+// Option configures a bot.
+type Option func(o *options)
+
+// WithRateLimits configures how many messages per second the bot sends in total and to a single chat.
+// A non-positive value disables the corresponding limit.
+func WithRateLimits(global, perChat float64) Option {
+	return func(o *options) {
+		o.globalRateLimit = global
+		o.perChatRateLimit = perChat
+	}
+}
+
+func NewBot(logger *zap.Logger, botSecretKey string, opts ...Option) (*bot, error) {
+	options := &options{
+		globalRateLimit:  defaultGlobalRateLimit,
+		perChatRateLimit: defaultPerChatRateLimit,
+	}
+	for _, o := range opts {
+		o(options)
+	}
 	tgbot, err := tgbotapi.NewBotAPI(botSecretKey)
 	if err != nil {
 		return nil, err
 	}
-	return &bot{logger: logger, bot: tgbot}, nil
+	return &bot{
+		logger: logger,
+		bot:    tgbot,
+		delivery: &delivery{
+			logger: logger,
+			sender: tgbot,
+			policy: defaultDeliveryPolicy,
+		},
+		scheduler: newScheduler(options.globalRateLimit, options.perChatRateLimit),
+		commands:  map[string]CommandHandler{},
+		abort:     make(chan struct{}),
+		stopped:   make(chan struct{}),
+	}, nil
 }
 
-type Message struct {
-	UserID UserID
-	Text   string
+// SetUnreachableUserHandler sets a callback to be called when a user can't receive messages from the bot anymore.
+// It must be called before Run.
+func (b *bot) SetUnreachableUserHandler(fn UnreachableUserHandler) {
+	b.onUnreachableUser = fn
 }
 
-func (b *bot) Run(ctx context.Context) chan Message {
-	ch := make(chan Message)
+// Run starts sending messages queued with Send and Deliver until ctx is done or Shutdown is called.
+func (b *bot) Run(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	go func() {
+		select {
+		case <-b.abort:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
 	go func() {
+		defer close(b.stopped)
+		defer cancel()
+		var wg sync.WaitGroup
+		defer wg.Wait()
 		for {
-			select {
-			case <-ctx.Done():
+			j, ok := b.scheduler.next(ctx)
+			if !ok {
 				return
-			case msg := <-ch:
-				go b.sendTextMessage(msg.UserID, msg.Text)
 			}
+			wg.Add(1)
+			go func(j job) {
+				defer wg.Done()
+				err := b.sendMessage(ctx, j.msg)
+				if j.done != nil {
+					j.done <- err
+				}
+			}(j)
 		}
 	}()
-	return ch
 }
 
-func (b *bot) sendTextMessage(userID UserID, text string) {
+// Shutdown waits until the queued messages are sent and stops the bot.
+// The rate limits still apply, so a long queue takes a while.
+// If ctx is done before, the messages left are dropped and Shutdown returns ctx.Err().
+// Shutdown must be called after Run.
+func (b *bot) Shutdown(ctx context.Context) error {
+	b.scheduler.close()
+	select {
+	case <-b.stopped:
+		return nil
+	case <-ctx.Done():
+		b.abortOnce.Do(func() { close(b.abort) })
+		<-b.stopped
+		return ctx.Err()
+	}
+}
+
+// Send queues a message to be sent to a telegram user.
+// Errors are only logged, use Deliver to get the result of the delivery.
+func (b *bot) Send(msg Message) {
+	b.scheduler.enqueue(job{msg: msg})
+}
+
+// Deliver queues a message and waits until it is sent or fails permanently.
+// If ctx is done while the message is still queued, the message is dropped from the queue,
+// so the caller can safely send it again later.
+func (b *bot) Deliver(ctx context.Context, msg Message) error {
+	done := make(chan error, 1)
+	b.scheduler.enqueue(job{msg: msg, done: done})
+	select {
+	case <-ctx.Done():
+		b.scheduler.remove(msg.UserID, done)
+		return ctx.Err()
+	case err := <-done:
+		return err
+	}
+}
+
+func (b *bot) sendMessage(ctx context.Context, msg Message) error {
 	messageCounter.Inc()
 
-	message := tgbotapi.NewMessage(int64(userID), text)
-	if _, err := b.bot.Send(message); err != nil {
-		// TODO: maybe we should retry sending a message?
-		b.logger.Error("failed to send message", zap.Error(err))
+	if err := b.delivery.send(ctx, msg.messageConfig()); err != nil {
+		b.logger.Error("failed to send message",
+			zap.Int64("user_id", int64(msg.UserID)),
+			zap.Error(err))
+		if reason, ok := unreachableReason(err); ok && b.onUnreachableUser != nil {
+			b.onUnreachableUser(msg.UserID, reason)
+		}
+		return err
 	}
+	return nil
 }
@@ -0,0 +1,240 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/avast/retry-go"
+	"github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+)
+
+var (
+	deliveryCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "twa_api_message_delivery_counter",
+		Help: "Number of telegram message delivery attempts by outcome",
+	}, []string{"outcome"})
+)
+
+const (
+	outcomeSent        = "sent"
+	outcomeRetried     = "retried"
+	outcomeRateLimited = "rate_limited"
+	outcomePermanent   = "failed_permanent"
+	outcomeTransient   = "failed_transient"
+	outcomeUnknown     = "failed_unknown"
+)
+
+// ErrorKind describes how a failed delivery should be treated.
+type ErrorKind int
+
+const (
+	// ErrorKindTransient is a temporary failure like a network error or a 5xx response,
+	// a message can be sent again later.
+	ErrorKindTransient ErrorKind = iota
+	// ErrorKindRateLimited means telegram asked us to slow down and to wait for RetryAfter.
+	ErrorKindRateLimited
+	// ErrorKindPermanent means there is no point in sending the message again.
+	ErrorKindPermanent
+	// ErrorKindUnknown is a rejection we don't recognize, a message is sent again a limited number of times.
+	ErrorKindUnknown
+)
+
+func (k ErrorKind) String() string {
+	switch k {
+	case ErrorKindTransient:
+		return "transient"
+	case ErrorKindRateLimited:
+		return "rate_limited"
+	case ErrorKindPermanent:
+		return "permanent"
+	case ErrorKindUnknown:
+		return "unknown"
+	default:
+		return "unknown"
+	}
+}
+
+// DeliveryError is returned when a message can't be delivered to a telegram user.
+type DeliveryError struct {
+	Kind       ErrorKind
+	Code       int
+	RetryAfter time.Duration
+	Err        error
+}
+
+func (e *DeliveryError) Error() string {
+	return fmt.Sprintf("%v delivery error: %v", e.Kind, e.Err)
+}
+
+func (e *DeliveryError) Unwrap() error {
+	return e.Err
+}
+
+// isUnknown returns true if err is a DeliveryError telegram has rejected the message with for an unknown reason.
+func isUnknown(err error) bool {
+	var deliveryErr *DeliveryError
+	if errors.As(err, &deliveryErr) {
+		return deliveryErr.Kind == ErrorKindUnknown
+	}
+	return false
+}
+
+// IsPermanent returns true if err is a DeliveryError that won't go away after a retry.
+func IsPermanent(err error) bool {
+	var deliveryErr *DeliveryError
+	if errors.As(err, &deliveryErr) {
+		return deliveryErr.Kind == ErrorKindPermanent
+	}
+	return false
+}
+
+// permanentBadRequests are descriptions of 400 responses about a message or a chat,
+// sending the same message again gives the same response.
+var permanentBadRequests = []string{
+	"chat not found",
+	"user not found",
+	"peer_id_invalid",
+	"message is too long",
+	"message text is empty",
+	"can't parse entities",
+	"reply markup is too long",
+	"button_url_invalid",
+	"button_data_invalid",
+	"wrong http url",
+	"chat_write_forbidden",
+}
+
+// classifyError converts an error returned by the Bot API into a DeliveryError.
+// Telegram uses 400 for a variety of problems, so they are told apart by the description.
+func classifyError(err error) *DeliveryError {
+	var apiErr *tgbotapi.Error
+	if !errors.As(err, &apiErr) {
+		// network errors, timeouts and broken responses.
+		return &DeliveryError{Kind: ErrorKindTransient, Err: err}
+	}
+	deliveryErr := &DeliveryError{Kind: ErrorKindTransient, Code: apiErr.Code, Err: err}
+	switch {
+	case apiErr.Code == http.StatusTooManyRequests:
+		deliveryErr.Kind = ErrorKindRateLimited
+		deliveryErr.RetryAfter = time.Duration(apiErr.RetryAfter) * time.Second
+	case apiErr.Code == http.StatusForbidden:
+		// the bot can't write to the user: the bot is blocked, the user is deactivated and so on.
+		deliveryErr.Kind = ErrorKindPermanent
+	case apiErr.Code == http.StatusBadRequest && isPermanentBadRequest(apiErr.Message):
+		deliveryErr.Kind = ErrorKindPermanent
+	case apiErr.Code >= 400 && apiErr.Code < 500:
+		deliveryErr.Kind = ErrorKindUnknown
+	}
+	return deliveryErr
+}
+
+func isPermanentBadRequest(description string) bool {
+	description = strings.ToLower(description)
+	for _, known := range permanentBadRequests {
+		if strings.Contains(description, known) {
+			return true
+		}
+	}
+	return false
+}
+
+type sender interface {
+	Send(c tgbotapi.Chattable) (tgbotapi.Message, error)
+}
+
+// deliveryPolicy configures how many times and how often we retry a message.
+type deliveryPolicy struct {
+	Attempts uint
+	// UnknownAttempts limits attempts to send a message rejected with an unknown error.
+	UnknownAttempts uint
+	BaseDelay       time.Duration
+	MaxDelay        time.Duration
+}
+
+var defaultDeliveryPolicy = deliveryPolicy{
+	Attempts:        5,
+	UnknownAttempts: 2,
+	BaseDelay:       500 * time.Millisecond,
+	MaxDelay:        30 * time.Second,
+}
+
+// delivery sends messages to telegram and retries transient failures
+// with a capped exponential backoff honoring retry_after of 429 responses.
+type delivery struct {
+	logger *zap.Logger
+	sender sender
+	policy deliveryPolicy
+}
+
+func (d *delivery) delay(n uint, err error, config *retry.Config) time.Duration {
+	var deliveryErr *DeliveryError
+	if errors.As(err, &deliveryErr) && deliveryErr.Kind == ErrorKindRateLimited && deliveryErr.RetryAfter > 0 {
+		return deliveryErr.RetryAfter
+	}
+	delay := retry.BackOffDelay(n, err, config)
+	if delay > d.policy.MaxDelay {
+		return d.policy.MaxDelay
+	}
+	return delay
+}
+
+// send delivers a message and returns a DeliveryError if all attempts fail.
+func (d *delivery) send(ctx context.Context, c tgbotapi.Chattable) error {
+	var unknown uint
+	err := retry.Do(func() error {
+		if _, err := d.sender.Send(c); err != nil {
+			return classifyError(err)
+		}
+		return nil
+	},
+		retry.Context(ctx),
+		retry.Attempts(d.policy.Attempts),
+		retry.Delay(d.policy.BaseDelay),
+		retry.DelayType(d.delay),
+		retry.LastErrorOnly(true),
+		retry.RetryIf(func(err error) bool {
+			if isUnknown(err) {
+				unknown++
+				return unknown < d.policy.UnknownAttempts
+			}
+			return !IsPermanent(err)
+		}),
+		retry.OnRetry(func(n uint, err error) {
+			if IsPermanent(err) || n+1 == d.policy.Attempts {
+				return
+			}
+			var deliveryErr *DeliveryError
+			if errors.As(err, &deliveryErr) && deliveryErr.Kind == ErrorKindRateLimited {
+				deliveryCounter.WithLabelValues(outcomeRateLimited).Inc()
+			} else {
+				deliveryCounter.WithLabelValues(outcomeRetried).Inc()
+			}
+			d.logger.Warn("failed to send message, retrying", zap.Uint("attempt", n+1), zap.Error(err))
+		}),
+	)
+	if err == nil {
+		deliveryCounter.WithLabelValues(outcomeSent).Inc()
+		return nil
+	}
+	var deliveryErr *DeliveryError
+	if !errors.As(err, &deliveryErr) {
+		// the context is done.
+		deliveryErr = &DeliveryError{Kind: ErrorKindTransient, Err: err}
+	}
+	switch deliveryErr.Kind {
+	case ErrorKindPermanent:
+		deliveryCounter.WithLabelValues(outcomePermanent).Inc()
+	case ErrorKindUnknown:
+		deliveryCounter.WithLabelValues(outcomeUnknown).Inc()
+	default:
+		deliveryCounter.WithLabelValues(outcomeTransient).Inc()
+	}
+	return deliveryErr
+}
@@ -0,0 +1,216 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type mockSender struct {
+	errors []error
+	calls  int
+}
+
+func (m *mockSender) Send(c tgbotapi.Chattable) (tgbotapi.Message, error) {
+	m.calls += 1
+	if len(m.errors) == 0 {
+		return tgbotapi.Message{}, nil
+	}
+	err := m.errors[0]
+	m.errors = m.errors[1:]
+	return tgbotapi.Message{}, err
+}
+
+func Test_classifyError(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		wantKind       ErrorKind
+		wantRetryAfter time.Duration
+	}{
+		{
+			name:     "network error",
+			err:      errors.New("connection reset by peer"),
+			wantKind: ErrorKindTransient,
+		},
+		{
+			name:     "internal server error",
+			err:      &tgbotapi.Error{Code: 502, Message: "Bad Gateway"},
+			wantKind: ErrorKindTransient,
+		},
+		{
+			name:           "too many requests",
+			err:            &tgbotapi.Error{Code: 429, Message: "Too Many Requests: retry after 3", ResponseParameters: tgbotapi.ResponseParameters{RetryAfter: 3}},
+			wantKind:       ErrorKindRateLimited,
+			wantRetryAfter: 3 * time.Second,
+		},
+		{
+			name:     "bot was blocked",
+			err:      &tgbotapi.Error{Code: 403, Message: "Forbidden: bot was blocked by the user"},
+			wantKind: ErrorKindPermanent,
+		},
+		{
+			name:     "bad request",
+			err:      &tgbotapi.Error{Code: 400, Message: "Bad Request: chat not found"},
+			wantKind: ErrorKindPermanent,
+		},
+		{
+			name:     "can't parse entities",
+			err:      &tgbotapi.Error{Code: 400, Message: "Bad Request: can't parse entities: Unsupported start tag \"x\""},
+			wantKind: ErrorKindPermanent,
+		},
+		{
+			name:     "unknown bad request",
+			err:      &tgbotapi.Error{Code: 400, Message: "Bad Request: something new"},
+			wantKind: ErrorKindUnknown,
+		},
+		{
+			name:     "bot can't initiate conversation",
+			err:      &tgbotapi.Error{Code: 403, Message: "Forbidden: bot can't initiate conversation with a user"},
+			wantKind: ErrorKindPermanent,
+		},
+		{
+			name:     "conflict",
+			err:      &tgbotapi.Error{Code: 409, Message: "Conflict"},
+			wantKind: ErrorKindUnknown,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deliveryErr := classifyError(tt.err)
+			require.Equal(t, tt.wantKind, deliveryErr.Kind)
+			require.Equal(t, tt.wantRetryAfter, deliveryErr.RetryAfter)
+		})
+	}
+}
+
+func Test_delivery_send(t *testing.T) {
+	tests := []struct {
+		name      string
+		errors    []error
+		wantCalls int
+		wantKind  *ErrorKind
+	}{
+		{
+			name:      "all good",
+			wantCalls: 1,
+		},
+		{
+			name: "transient errors are retried",
+			errors: []error{
+				errors.New("timeout"),
+				&tgbotapi.Error{Code: 429, ResponseParameters: tgbotapi.ResponseParameters{RetryAfter: 0}},
+			},
+			wantCalls: 3,
+		},
+		{
+			name: "permanent error is not retried",
+			errors: []error{
+				&tgbotapi.Error{Code: 403, Message: "Forbidden: bot was blocked by the user"},
+			},
+			wantCalls: 1,
+			wantKind:  kindPtr(ErrorKindPermanent),
+		},
+		{
+			name: "unknown errors are retried once",
+			errors: []error{
+				&tgbotapi.Error{Code: 400, Message: "Bad Request: something new"},
+				&tgbotapi.Error{Code: 400, Message: "Bad Request: something new"},
+			},
+			wantCalls: 2,
+			wantKind:  kindPtr(ErrorKindUnknown),
+		},
+		{
+			name: "attempts exhausted",
+			errors: []error{
+				errors.New("timeout"),
+				errors.New("timeout"),
+				errors.New("timeout"),
+			},
+			wantCalls: 3,
+			wantKind:  kindPtr(ErrorKindTransient),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sender := &mockSender{errors: tt.errors}
+			d := &delivery{
+				logger: zap.L(),
+				sender: sender,
+				policy: deliveryPolicy{
+					Attempts:        3,
+					UnknownAttempts: 2,
+					BaseDelay:       time.Millisecond,
+					MaxDelay:        5 * time.Millisecond,
+				},
+			}
+			err := d.send(context.Background(), tgbotapi.NewMessage(1, "hello"))
+			require.Equal(t, tt.wantCalls, sender.calls)
+			if tt.wantKind == nil {
+				require.Nil(t, err)
+				return
+			}
+			var deliveryErr *DeliveryError
+			require.True(t, errors.As(err, &deliveryErr))
+			require.Equal(t, *tt.wantKind, deliveryErr.Kind)
+		})
+	}
+}
+
+func kindPtr(kind ErrorKind) *ErrorKind {
+	return &kind
+}
+
+func Test_unreachableReason(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantReason string
+		wantOk     bool
+	}{
+		{
+			name:       "bot was blocked",
+			err:        classifyError(&tgbotapi.Error{Code: 403, Message: "Forbidden: bot was blocked by the user"}),
+			wantReason: ReasonBotBlocked,
+			wantOk:     true,
+		},
+		{
+			name:       "user is deactivated",
+			err:        classifyError(&tgbotapi.Error{Code: 403, Message: "Forbidden: user is deactivated"}),
+			wantReason: ReasonUserDeactivated,
+			wantOk:     true,
+		},
+		{
+			name:       "chat not found",
+			err:        classifyError(&tgbotapi.Error{Code: 400, Message: "Bad Request: chat not found"}),
+			wantReason: ReasonChatNotFound,
+			wantOk:     true,
+		},
+		{
+			name:       "bot can't initiate conversation",
+			err:        classifyError(&tgbotapi.Error{Code: 403, Message: "Forbidden: bot can't initiate conversation with a user"}),
+			wantReason: ReasonForbidden,
+			wantOk:     true,
+		},
+		{
+			name: "message is too long",
+			err:  classifyError(&tgbotapi.Error{Code: 400, Message: "Bad Request: message is too long"}),
+		},
+		{
+			name: "transient error",
+			err:  classifyError(errors.New("chat not found")),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason, ok := unreachableReason(tt.err)
+			require.Equal(t, tt.wantOk, ok)
+			require.Equal(t, tt.wantReason, reason)
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package telegram
+
+import (
+	"html"
+	"strings"
+
+	"github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// ParseMode defines how telegram interprets entities in a message text.
+// See more details at https://core.telegram.org/bots/api#formatting-options.
+type ParseMode string
+
+const (
+	ParseModeHTML       ParseMode = "HTML"
+	ParseModeMarkdownV2 ParseMode = "MarkdownV2"
+)
+
+// Button is a button of an inline keyboard attached to a message.
+// Exactly one of URL and WebAppURL must be set.
+type Button struct {
+	Text string `json:"text"`
+	// URL is opened in a browser when the button is pressed.
+	URL string `json:"url,omitempty"`
+	// WebAppURL is a URL of a Telegram Web App opened when the button is pressed.
+	WebAppURL string `json:"web_app_url,omitempty"`
+}
+
+// Priority defines whether a message can be delayed according to user's notification settings.
+type Priority int
+
+const (
+	PriorityNormal Priority = iota
+	// PriorityHigh is for security-critical messages which are delivered even during quiet hours.
+	PriorityHigh
+)
+
+type Message struct {
+	UserID    UserID    `json:"user_id"`
+	Text      string    `json:"text"`
+	ParseMode ParseMode `json:"parse_mode,omitempty"`
+	// Keyboard is an optional inline keyboard, each element is a row of buttons.
+	Keyboard [][]Button `json:"keyboard,omitempty"`
+	Priority Priority   `json:"priority,omitempty"`
+}
+
+// EscapeHTML escapes a string to be safely used inside a message with ParseModeHTML.
+func EscapeHTML(s string) string {
+	return html.EscapeString(s)
+}
+
+var markdownV2Replacer = strings.NewReplacer(
+	`\`, `\\`, "_", `\_`, "*", `\*`, "[", `\[`, "]", `\]`, "(", `\(`, ")", `\)`,
+	"~", `\~`, "`", "\\`", ">", `\>`, "#", `\#`, "+", `\+`, "-", `\-`, "=", `\=`,
+	"|", `\|`, "{", `\{`, "}", `\}`, ".", `\.`, "!", `\!`,
+)
+
+// EscapeMarkdownV2 escapes a string to be safely used inside a message with ParseModeMarkdownV2.
+func EscapeMarkdownV2(s string) string {
+	return markdownV2Replacer.Replace(s)
+}
+
+// The Bot API library we use doesn't support web_app buttons yet,
+// so we build the reply markup ourselves.
+type inlineKeyboardMarkup struct {
+	InlineKeyboard [][]inlineKeyboardButton `json:"inline_keyboard"`
+}
+
+type inlineKeyboardButton struct {
+	Text   string      `json:"text"`
+	URL    string      `json:"url,omitempty"`
+	WebApp *webAppInfo `json:"web_app,omitempty"`
+}
+
+type webAppInfo struct {
+	URL string `json:"url"`
+}
+
+func (m Message) messageConfig() tgbotapi.MessageConfig {
+	config := tgbotapi.NewMessage(int64(m.UserID), m.Text)
+	config.ParseMode = string(m.ParseMode)
+	if len(m.Keyboard) == 0 {
+		return config
+	}
+	markup := inlineKeyboardMarkup{}
+	for _, row := range m.Keyboard {
+		buttons := make([]inlineKeyboardButton, 0, len(row))
+		for _, button := range row {
+			b := inlineKeyboardButton{Text: button.Text, URL: button.URL}
+			if len(button.WebAppURL) > 0 {
+				b.WebApp = &webAppInfo{URL: button.WebAppURL}
+			}
+			buttons = append(buttons, b)
+		}
+		markup.InlineKeyboard = append(markup.InlineKeyboard, buttons)
+	}
+	config.ReplyMarkup = markup
+	return config
+}
+
+// MaxMessageLength is the maximum length of a message text supported by telegram.
+const MaxMessageLength = 4096
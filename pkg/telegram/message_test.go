@@ -0,0 +1,39 @@
+package telegram
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEscapeMarkdownV2(t *testing.T) {
+	require.Equal(t, `Received 0\.1 TON \(\_test\_\)\!`, EscapeMarkdownV2("Received 0.1 TON (_test_)!"))
+}
+
+func TestEscapeHTML(t *testing.T) {
+	require.Equal(t, "Received 10 &lt;b&gt;SCAM&lt;/b&gt; &amp; co", EscapeHTML("Received 10 <b>SCAM</b> & co"))
+}
+
+func TestMessage_messageConfig(t *testing.T) {
+	msg := Message{
+		UserID:    1,
+		Text:      "<b>Received</b>",
+		ParseMode: ParseModeHTML,
+		Keyboard: [][]Button{
+			{
+				{Text: "Open", WebAppURL: "https://wallet.tonkeeper.com/"},
+				{Text: "View", URL: "https://tonviewer.com/transaction/abc"},
+			},
+		},
+	}
+	config := msg.messageConfig()
+	require.Equal(t, "HTML", config.ParseMode)
+
+	markup, err := json.Marshal(config.ReplyMarkup)
+	require.Nil(t, err)
+	require.JSONEq(t, `{"inline_keyboard":[[
+		{"text":"Open","web_app":{"url":"https://wallet.tonkeeper.com/"}},
+		{"text":"View","url":"https://tonviewer.com/transaction/abc"}
+	]]}`, string(markup))
+}
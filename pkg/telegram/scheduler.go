@@ -0,0 +1,221 @@
+package telegram
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	messageQueueDepth = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "twa_api_message_queue_depth",
+		Help: "Number of telegram messages waiting to be sent",
+	})
+)
+
+const (
+	// defaultGlobalRateLimit and defaultPerChatRateLimit follow the limits described at
+	// https://core.telegram.org/bots/faq#my-bot-is-hitting-limits-how-do-i-avoid-this.
+	defaultGlobalRateLimit  = 30
+	defaultPerChatRateLimit = 1
+)
+
+type job struct {
+	msg Message
+	// done receives the result of the delivery if it is not nil.
+	done chan<- error
+}
+
+// userQueue is a FIFO queue of messages to a particular user.
+type userQueue struct {
+	jobs []job
+	// nextAllowed is the earliest time we can send the next message to this user.
+	nextAllowed time.Time
+}
+
+// scheduler smooths out bursts of messages.
+// It enforces a global rate limit and a per-user rate limit and
+// serves users in a round-robin fashion, so one busy user can't delay messages to everyone else.
+type scheduler struct {
+	globalInterval  time.Duration
+	perUserInterval time.Duration
+
+	wakeCh chan struct{}
+
+	mu         sync.Mutex
+	nextGlobal time.Time
+	depth      int
+	// closed means next returns false as soon as there are no pending jobs.
+	closed bool
+	// queues contains users with pending messages.
+	queues map[UserID]*userQueue
+	// order is a round-robin list of users with pending messages.
+	order []UserID
+	// idle contains users without pending messages who have been sent a message recently.
+	idle map[UserID]time.Time
+}
+
+func newScheduler(globalRateLimit, perUserRateLimit float64) *scheduler {
+	return &scheduler{
+		globalInterval:  rateToInterval(globalRateLimit),
+		perUserInterval: rateToInterval(perUserRateLimit),
+		wakeCh:          make(chan struct{}, 1),
+		queues:          map[UserID]*userQueue{},
+		idle:            map[UserID]time.Time{},
+	}
+}
+
+func rateToInterval(rate float64) time.Duration {
+	if rate <= 0 {
+		return 0
+	}
+	return time.Duration(float64(time.Second) / rate)
+}
+
+func (s *scheduler) enqueue(j job) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	queue, ok := s.queues[j.msg.UserID]
+	if !ok {
+		queue = &userQueue{nextAllowed: s.idle[j.msg.UserID]}
+		delete(s.idle, j.msg.UserID)
+		s.queues[j.msg.UserID] = queue
+		s.order = append(s.order, j.msg.UserID)
+	}
+	queue.jobs = append(queue.jobs, j)
+	s.depth += 1
+	messageQueueDepth.Set(float64(s.depth))
+
+	s.wake()
+}
+
+// remove removes a pending job with the given done channel of a user.
+// It returns false if there is no such job, for example, because it has been already popped.
+func (s *scheduler) remove(userID UserID, done chan<- error) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	queue, ok := s.queues[userID]
+	if !ok {
+		return false
+	}
+	for i, j := range queue.jobs {
+		if j.done != done {
+			continue
+		}
+		queue.jobs = append(queue.jobs[:i], queue.jobs[i+1:]...)
+		if len(queue.jobs) == 0 {
+			delete(s.queues, userID)
+			for k, id := range s.order {
+				if id == userID {
+					s.order = append(s.order[:k], s.order[k+1:]...)
+					break
+				}
+			}
+			s.idle[userID] = queue.nextAllowed
+		}
+		s.depth -= 1
+		messageQueueDepth.Set(float64(s.depth))
+		return true
+	}
+	return false
+}
+
+func (s *scheduler) wake() {
+	select {
+	case s.wakeCh <- struct{}{}:
+	default:
+	}
+}
+
+// pop returns the next job that can be sent at the given moment.
+// If there is no such job, it returns how long to wait before trying again,
+// zero wait means there are no pending jobs at all.
+func (s *scheduler) pop(now time.Time) (job, time.Duration, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.order) == 0 {
+		for userID, nextAllowed := range s.idle {
+			if !nextAllowed.After(now) {
+				delete(s.idle, userID)
+			}
+		}
+		return job{}, 0, false
+	}
+	if now.Before(s.nextGlobal) {
+		return job{}, s.nextGlobal.Sub(now), false
+	}
+	var wait time.Duration
+	for i := 0; i < len(s.order); i++ {
+		userID := s.order[0]
+		s.order = append(s.order[1:], userID)
+		queue := s.queues[userID]
+		if queue.nextAllowed.After(now) {
+			if d := queue.nextAllowed.Sub(now); wait == 0 || d < wait {
+				wait = d
+			}
+			continue
+		}
+		j := queue.jobs[0]
+		queue.jobs = queue.jobs[1:]
+		queue.nextAllowed = now.Add(s.perUserInterval)
+		if len(queue.jobs) == 0 {
+			delete(s.queues, userID)
+			s.order = s.order[:len(s.order)-1]
+			s.idle[userID] = queue.nextAllowed
+		}
+		s.nextGlobal = now.Add(s.globalInterval)
+		s.depth -= 1
+		messageQueueDepth.Set(float64(s.depth))
+		return j, 0, true
+	}
+	return job{}, wait, false
+}
+
+// close lets the pending jobs out and makes next return false after them.
+func (s *scheduler) close() {
+	s.mu.Lock()
+	s.closed = true
+	s.mu.Unlock()
+	s.wake()
+}
+
+func (s *scheduler) isClosed() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.closed
+}
+
+// next blocks until there is a job that can be sent without exceeding the rate limits.
+// It returns false when ctx is done or the scheduler is closed and has no pending jobs.
+func (s *scheduler) next(ctx context.Context) (job, bool) {
+	for {
+		j, wait, ok := s.pop(time.Now())
+		if ok {
+			return j, true
+		}
+		if wait == 0 && s.isClosed() {
+			return job{}, false
+		}
+		var timer *time.Timer
+		var timerCh <-chan time.Time
+		if wait > 0 {
+			timer = time.NewTimer(wait)
+			timerCh = timer.C
+		}
+		select {
+		case <-ctx.Done():
+			return job{}, false
+		case <-s.wakeCh:
+		case <-timerCh:
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package telegram
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_scheduler_pop(t *testing.T) {
+	s := newScheduler(10, 1)
+	for _, msg := range []Message{
+		{UserID: 1, Text: "1-a"},
+		{UserID: 1, Text: "1-b"},
+		{UserID: 1, Text: "1-c"},
+		{UserID: 2, Text: "2-a"},
+		{UserID: 3, Text: "3-a"},
+	} {
+		s.enqueue(job{msg: msg})
+	}
+	now := time.Now()
+
+	var texts []string
+	for i := 0; i < 100; i++ {
+		j, wait, ok := s.pop(now)
+		if !ok {
+			if wait == 0 {
+				break
+			}
+			now = now.Add(wait)
+			continue
+		}
+		texts = append(texts, j.msg.Text)
+	}
+	// users are served in a round-robin fashion.
+	require.Equal(t, []string{"1-a", "2-a", "3-a", "1-b", "1-c"}, texts)
+	require.Equal(t, 0, s.depth)
+}
+
+func Test_scheduler_perUserLimit(t *testing.T) {
+	s := newScheduler(0, 1)
+	s.enqueue(job{msg: Message{UserID: 1, Text: "a"}})
+	s.enqueue(job{msg: Message{UserID: 1, Text: "b"}})
+	now := time.Now()
+
+	j, _, ok := s.pop(now)
+	require.True(t, ok)
+	require.Equal(t, "a", j.msg.Text)
+
+	_, wait, ok := s.pop(now.Add(100 * time.Millisecond))
+	require.False(t, ok)
+	require.Equal(t, 900*time.Millisecond, wait)
+
+	j, _, ok = s.pop(now.Add(time.Second))
+	require.True(t, ok)
+	require.Equal(t, "b", j.msg.Text)
+
+	// the user is idle but still rate limited.
+	s.enqueue(job{msg: Message{UserID: 1, Text: "c"}})
+	_, wait, ok = s.pop(now.Add(time.Second + 500*time.Millisecond))
+	require.False(t, ok)
+	require.Equal(t, 500*time.Millisecond, wait)
+}
+
+func Test_scheduler_globalLimit(t *testing.T) {
+	s := newScheduler(2, 0)
+	s.enqueue(job{msg: Message{UserID: 1, Text: "a"}})
+	s.enqueue(job{msg: Message{UserID: 2, Text: "b"}})
+	now := time.Now()
+
+	_, _, ok := s.pop(now)
+	require.True(t, ok)
+
+	_, wait, ok := s.pop(now)
+	require.False(t, ok)
+	require.Equal(t, 500*time.Millisecond, wait)
+
+	_, _, ok = s.pop(now.Add(wait))
+	require.True(t, ok)
+}
+
+func Test_scheduler_close(t *testing.T) {
+	s := newScheduler(0, 100)
+	s.enqueue(job{msg: Message{UserID: 1, Text: "1-a"}})
+	s.enqueue(job{msg: Message{UserID: 1, Text: "1-b"}})
+	s.close()
+
+	// pending jobs are still let out after close.
+	var texts []string
+	for {
+		j, ok := s.next(context.Background())
+		if !ok {
+			break
+		}
+		texts = append(texts, j.msg.Text)
+	}
+	require.Equal(t, []string{"1-a", "1-b"}, texts)
+}
+
+func Test_scheduler_remove(t *testing.T) {
+	s := newScheduler(0, 0)
+	done := make(chan error, 1)
+	s.enqueue(job{msg: Message{UserID: 1, Text: "1-a"}})
+	s.enqueue(job{msg: Message{UserID: 2, Text: "2-a"}, done: done})
+	s.enqueue(job{msg: Message{UserID: 1, Text: "1-b"}})
+
+	require.True(t, s.remove(2, done))
+	require.False(t, s.remove(2, done))
+	require.Equal(t, 2, s.depth)
+	s.close()
+
+	var texts []string
+	for {
+		j, ok := s.next(context.Background())
+		if !ok {
+			break
+		}
+		texts = append(texts, j.msg.Text)
+	}
+	require.Equal(t, []string{"1-a", "1-b"}, texts)
+}
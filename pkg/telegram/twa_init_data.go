@@ -1,33 +1,232 @@
 package telegram
 
 import (
+	"crypto/ed25519"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/Telegram-Web-Apps/init-data-golang"
 )
 
 const (
-	maxInitDataLifetime = 1 * time.Hour
+	defaultInitDataLifetime = 1 * time.Hour
 )
 
-// ExtractUserIDFromInitData extracts user ID from twa init data.
-// See more details at https://docs.twa.dev/docs/libraries/init-data-golang.
-func ExtractUserIDFromInitData(data string, telegramSecret string) (UserID, error) {
+// Telegram's Ed25519 public keys used to sign init data for third parties.
+// See more details at https://core.telegram.org/bots/webapps#validating-data-for-third-party-use.
+const (
+	productionInitDataPublicKey = "e7bf03a2fa4602af4580703d88dda5bb59f32ed8b02a56c187fe7d34caed242d"
+	testInitDataPublicKey       = "40055058a4ee38156a06562e52eece92a771bcd8346a8c4615cb7376eddf72ec"
+)
+
+// InitDataValidation defines how the authenticity of init data is checked.
+type InitDataValidation string
+
+const (
+	// InitDataValidationHash checks the HMAC "hash" field, it requires the bot token.
+	InitDataValidationHash InitDataValidation = "hash"
+	// InitDataValidationSignature checks the Ed25519 "signature" field, it requires the bot ID only.
+	InitDataValidationSignature InitDataValidation = "signature"
+	// InitDataValidationAny accepts init data if either of the checks passes.
+	InitDataValidationAny InitDataValidation = "any"
+)
+
+// User is a telegram user who opened the TWA.
+// See more details at https://core.telegram.org/bots/webapps#webappuser.
+type User struct {
+	ID        UserID `json:"id"`
+	Username  string `json:"username"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	// LanguageCode is an IETF language tag of the user's language.
+	LanguageCode string `json:"language_code"`
+	IsPremium    bool   `json:"is_premium"`
+	// AllowsWriteToPM is true if the user allowed the bot to message them.
+	AllowsWriteToPM bool `json:"allows_write_to_pm"`
+}
+
+// InitData is validated TWA init data.
+type InitData struct {
+	User User
+	// ReplayKey uniquely identifies init data, it is a hash of the signed fields,
+	// so it can't be changed without invalidating init data.
+	ReplayKey string
+}
+
+// InitDataConfig configures InitDataVerifier.
+type InitDataConfig struct {
+	Validation InitDataValidation
+	// Lifetime is how long init data is accepted after it was issued, default is one hour.
+	Lifetime time.Duration
+	// BotToken is required by InitDataValidationHash.
+	BotToken string
+	// BotID is required by InitDataValidationSignature.
+	// If it is zero, it is taken from BotToken.
+	BotID int64
+	// TestEnvironment switches to the public key of the telegram test environment.
+	TestEnvironment bool
+}
+
+// InitDataVerifier checks that TWA init data was issued by telegram and extracts a user from it.
+type InitDataVerifier struct {
+	validation InitDataValidation
+	lifetime   time.Duration
+	botToken   string
+	botID      int64
+	publicKey  ed25519.PublicKey
+}
+
+func NewInitDataVerifier(config InitDataConfig) (*InitDataVerifier, error) {
+	verifier := &InitDataVerifier{
+		validation: config.Validation,
+		lifetime:   config.Lifetime,
+		botToken:   config.BotToken,
+		botID:      config.BotID,
+	}
+	if verifier.lifetime <= 0 {
+		verifier.lifetime = defaultInitDataLifetime
+	}
+	switch config.Validation {
+	case InitDataValidationHash, InitDataValidationSignature, InitDataValidationAny:
+	default:
+		return nil, fmt.Errorf("unknown init data validation %q", config.Validation)
+	}
+	if config.Validation != InitDataValidationSignature && len(config.BotToken) == 0 {
+		return nil, fmt.Errorf("bot token is required to validate init data hash")
+	}
+	if config.Validation == InitDataValidationHash {
+		return verifier, nil
+	}
+	if verifier.botID == 0 {
+		botID, err := BotIDFromToken(config.BotToken)
+		if err != nil {
+			return nil, fmt.Errorf("bot id is required to validate init data signature: %w", err)
+		}
+		verifier.botID = botID
+	}
+	publicKey := productionInitDataPublicKey
+	if config.TestEnvironment {
+		publicKey = testInitDataPublicKey
+	}
+	key, err := hex.DecodeString(publicKey)
+	if err != nil {
+		return nil, err
+	}
+	verifier.publicKey = key
+	return verifier, nil
+}
+
+// BotIDFromToken returns the bot ID which is the part of a bot token before the colon.
+func BotIDFromToken(token string) (int64, error) {
+	id, _, found := strings.Cut(token, ":")
+	if !found {
+		return 0, fmt.Errorf("invalid bot token")
+	}
+	botID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid bot token")
+	}
+	return botID, nil
+}
+
+// Lifetime returns how long init data is accepted after it was issued.
+func (v *InitDataVerifier) Lifetime() time.Duration {
+	return v.lifetime
+}
+
+// Verify validates base64 encoded twa init data and extracts a user from it.
+func (v *InitDataVerifier) Verify(data string) (InitData, error) {
 	twaInitData, err := base64.StdEncoding.DecodeString(data)
 	if err != nil {
-		return 0, fmt.Errorf("failed to decode init data")
+		return InitData{}, fmt.Errorf("failed to decode init data")
 	}
-	if err := initdata.Validate(string(twaInitData), telegramSecret, maxInitDataLifetime); err != nil {
-		return 0, fmt.Errorf("failed to validate init data")
+	if err := v.validate(string(twaInitData), time.Now()); err != nil {
+		return InitData{}, fmt.Errorf("failed to validate init data")
 	}
-	parsedData, err := initdata.Parse(string(twaInitData))
+	return parseInitData(string(twaInitData))
+}
+
+func (v *InitDataVerifier) validate(initData string, now time.Time) error {
+	switch v.validation {
+	case InitDataValidationHash:
+		return initdata.Validate(initData, v.botToken, v.lifetime)
+	case InitDataValidationSignature:
+		return validateSignature(initData, v.botID, v.publicKey, v.lifetime, now)
+	default:
+		if err := initdata.Validate(initData, v.botToken, v.lifetime); err == nil {
+			return nil
+		}
+		return validateSignature(initData, v.botID, v.publicKey, v.lifetime, now)
+	}
+}
+
+// validateSignature checks the Ed25519 signature of init data.
+// See more details at https://core.telegram.org/bots/webapps#validating-data-for-third-party-use.
+func validateSignature(initData string, botID int64, publicKey ed25519.PublicKey, lifetime time.Duration, now time.Time) error {
+	query, err := url.ParseQuery(initData)
 	if err != nil {
-		return 0, fmt.Errorf("failed to parse init data")
+		return err
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(query.Get("signature"), "="))
+	if err != nil || len(signature) == 0 {
+		return fmt.Errorf("signature is missing")
+	}
+	authDate, err := strconv.ParseInt(query.Get("auth_date"), 10, 64)
+	if err != nil {
+		return fmt.Errorf("auth_date is missing")
+	}
+	if time.Unix(authDate, 0).Add(lifetime).Before(now) {
+		return fmt.Errorf("init data is expired")
+	}
+	dataCheckString := fmt.Sprintf("%d:WebAppData\n%s", botID, strings.Join(signedPairs(query), "\n"))
+	if !ed25519.Verify(publicKey, []byte(dataCheckString), signature) {
+		return fmt.Errorf("invalid signature")
+	}
+	return nil
+}
+
+// signedPairs returns sorted key=value pairs of init data signed by telegram in both validation modes.
+// The hash and the signature aren't covered by the signature, so they are left out.
+func signedPairs(query url.Values) []string {
+	pairs := make([]string, 0, len(query))
+	for key, values := range query {
+		if key == "hash" || key == "signature" {
+			continue
+		}
+		pairs = append(pairs, key+"="+values[0])
+	}
+	sort.Strings(pairs)
+	return pairs
+}
+
+func parseInitData(twaInitData string) (InitData, error) {
+	query, err := url.ParseQuery(twaInitData)
+	if err != nil {
+		return InitData{}, fmt.Errorf("failed to parse init data")
+	}
+	// init-data-golang doesn't know about some fields like allows_write_to_pm,
+	// so we parse the user ourselves.
+	rawUser := query.Get("user")
+	if len(rawUser) == 0 {
+		return InitData{}, fmt.Errorf("user not found in init data")
 	}
-	if parsedData.User == nil {
-		return 0, fmt.Errorf("user not found in init data")
+	var user User
+	if err := json.Unmarshal([]byte(rawUser), &user); err != nil {
+		return InitData{}, fmt.Errorf("failed to parse init data")
 	}
-	return UserID(parsedData.User.ID), nil
+	// the hash and the signature can be added or changed in the signature mode,
+	// so the key is built from the signed fields only.
+	replayKey := sha256.Sum256([]byte(strings.Join(signedPairs(query), "\n")))
+	return InitData{
+		User:      user,
+		ReplayKey: hex.EncodeToString(replayKey[:]),
+	}, nil
 }
@@ -0,0 +1,178 @@
+package telegram
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Telegram-Web-Apps/init-data-golang"
+	"github.com/stretchr/testify/require"
+)
+
+const testUser = `{"id":279058397,"first_name":"Vladislav","last_name":"Kibenko","username":"vdkfrost","language_code":"ru","is_premium":true,"allows_write_to_pm":true}`
+
+// signInitData builds init data signed with an Ed25519 private key the way telegram does it.
+func signInitData(t *testing.T, privateKey ed25519.PrivateKey, botID int64, authDate time.Time) string {
+	values := url.Values{}
+	values.Set("auth_date", fmt.Sprintf("%d", authDate.Unix()))
+	values.Set("query_id", "AAHdF6IQAAAAAN0XohDhrOrc")
+	values.Set("user", testUser)
+	var pairs []string
+	for key := range values {
+		pairs = append(pairs, key+"="+values.Get(key))
+	}
+	sort.Strings(pairs)
+	dataCheckString := fmt.Sprintf("%d:WebAppData\n%s", botID, strings.Join(pairs, "\n"))
+	values.Set("signature", base64.RawURLEncoding.EncodeToString(ed25519.Sign(privateKey, []byte(dataCheckString))))
+	return values.Encode()
+}
+
+func Test_validateSignature(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.Nil(t, err)
+	now := time.Now()
+
+	tests := []struct {
+		name     string
+		initData string
+		botID    int64
+		wantErr  string
+	}{
+		{
+			name:     "all good",
+			initData: signInitData(t, privateKey, 12345, now),
+			botID:    12345,
+		},
+		{
+			name:     "another bot",
+			initData: signInitData(t, privateKey, 12345, now),
+			botID:    54321,
+			wantErr:  "invalid signature",
+		},
+		{
+			name:     "expired",
+			initData: signInitData(t, privateKey, 12345, now.Add(-2*time.Hour)),
+			botID:    12345,
+			wantErr:  "init data is expired",
+		},
+		{
+			name:     "no signature",
+			initData: "auth_date=1&user=" + url.QueryEscape(testUser),
+			botID:    12345,
+			wantErr:  "signature is missing",
+		},
+		{
+			name:     "tampered data",
+			initData: strings.Replace(signInitData(t, privateKey, 12345, now), "Vladislav", "Mallory", 1),
+			botID:    12345,
+			wantErr:  "invalid signature",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSignature(tt.initData, tt.botID, publicKey, time.Hour, now)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.Nil(t, err)
+		})
+	}
+}
+
+func TestInitDataVerifier_Verify(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.Nil(t, err)
+	now := time.Now()
+
+	signed := signInitData(t, privateKey, 12345, now)
+	hashed := url.Values{
+		"auth_date": {fmt.Sprintf("%d", now.Unix())},
+		"query_id":  {"AAHdF6IQAAAAAN0XohDhrOrc"},
+		"user":      {testUser},
+	}
+	hashed.Set("hash", initdata.Sign(map[string]string{"query_id": hashed.Get("query_id"), "user": testUser}, "12345:secret", now))
+
+	tests := []struct {
+		name       string
+		validation InitDataValidation
+		initData   string
+		wantErr    bool
+	}{
+		{name: "hash", validation: InitDataValidationHash, initData: hashed.Encode()},
+		{name: "hash mode rejects signature", validation: InitDataValidationHash, initData: signed, wantErr: true},
+		{name: "signature", validation: InitDataValidationSignature, initData: signed},
+		{name: "signature mode rejects hash", validation: InitDataValidationSignature, initData: hashed.Encode(), wantErr: true},
+		{name: "any accepts hash", validation: InitDataValidationAny, initData: hashed.Encode()},
+		{name: "any accepts signature", validation: InitDataValidationAny, initData: signed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verifier, err := NewInitDataVerifier(InitDataConfig{Validation: tt.validation, BotToken: "12345:secret"})
+			require.Nil(t, err)
+			verifier.publicKey = publicKey
+
+			initData, err := verifier.Verify(base64.StdEncoding.EncodeToString([]byte(tt.initData)))
+			if tt.wantErr {
+				require.NotNil(t, err)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, User{
+				ID:              279058397,
+				Username:        "vdkfrost",
+				FirstName:       "Vladislav",
+				LastName:        "Kibenko",
+				LanguageCode:    "ru",
+				IsPremium:       true,
+				AllowsWriteToPM: true,
+			}, initData.User)
+			require.NotEmpty(t, initData.ReplayKey)
+		})
+	}
+}
+
+func TestInitDataVerifier_Verify_replayKey(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.Nil(t, err)
+	verifier, err := NewInitDataVerifier(InitDataConfig{Validation: InitDataValidationSignature, BotID: 12345})
+	require.Nil(t, err)
+	verifier.publicKey = publicKey
+
+	verify := func(data string) string {
+		initData, err := verifier.Verify(base64.StdEncoding.EncodeToString([]byte(data)))
+		require.Nil(t, err)
+		return initData.ReplayKey
+	}
+	signed := signInitData(t, privateKey, 12345, time.Now())
+	replayKey := verify(signed)
+
+	// the hash isn't signed, so changing it must not give a fresh replay key.
+	require.Equal(t, replayKey, verify(signed+"&hash=aaaa"))
+	require.Equal(t, replayKey, verify(signed+"&hash=bbbb"))
+}
+
+func TestNewInitDataVerifier(t *testing.T) {
+	// signatures can be validated without the bot token.
+	verifier, err := NewInitDataVerifier(InitDataConfig{Validation: InitDataValidationSignature, BotID: 12345, TestEnvironment: true})
+	require.Nil(t, err)
+	require.Equal(t, int64(12345), verifier.botID)
+	require.Equal(t, time.Hour, verifier.Lifetime())
+
+	_, err = NewInitDataVerifier(InitDataConfig{Validation: InitDataValidationHash, BotID: 12345})
+	require.EqualError(t, err, "bot token is required to validate init data hash")
+
+	_, err = NewInitDataVerifier(InitDataConfig{Validation: InitDataValidationSignature})
+	require.EqualError(t, err, "bot id is required to validate init data signature: invalid bot token")
+
+	verifier, err = NewInitDataVerifier(InitDataConfig{Validation: InitDataValidationAny, BotToken: "777:secret", Lifetime: 5 * time.Minute})
+	require.Nil(t, err)
+	require.Equal(t, int64(777), verifier.botID)
+	require.Equal(t, 5*time.Minute, verifier.Lifetime())
+}
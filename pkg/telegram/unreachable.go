@@ -0,0 +1,41 @@
+package telegram
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// Reasons why a telegram user can't receive messages from the bot.
+const (
+	ReasonBotBlocked      = "bot_blocked"
+	ReasonUserDeactivated = "user_deactivated"
+	ReasonChatNotFound    = "chat_not_found"
+	// ReasonForbidden is any other reason telegram forbids the bot to write to the user.
+	ReasonForbidden = "forbidden"
+)
+
+// UnreachableUserHandler is called when the bot can't deliver a message to a user and
+// it won't be able to do so in the future.
+type UnreachableUserHandler func(userID UserID, reason string)
+
+// unreachableReason returns a reason why the user can't receive messages
+// if err means that further messages to the user are pointless.
+func unreachableReason(err error) (string, bool) {
+	var deliveryErr *DeliveryError
+	if !errors.As(err, &deliveryErr) || deliveryErr.Kind != ErrorKindPermanent {
+		return "", false
+	}
+	description := strings.ToLower(deliveryErr.Err.Error())
+	switch {
+	case strings.Contains(description, "bot was blocked by the user"):
+		return ReasonBotBlocked, true
+	case strings.Contains(description, "user is deactivated"):
+		return ReasonUserDeactivated, true
+	case strings.Contains(description, "chat not found"):
+		return ReasonChatNotFound, true
+	case deliveryErr.Code == http.StatusForbidden:
+		return ReasonForbidden, true
+	}
+	return "", false
+}
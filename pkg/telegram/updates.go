@@ -0,0 +1,67 @@
+package telegram
+
+import (
+	"context"
+
+	"github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+
+	"github.com/tonkeeper/tonkeeper-twa-api/pkg/i18n"
+)
+
+// CommandHandler handles a bot command like /start sent by a telegram user and returns a reply.
+// languageCode is the language of the user's telegram client, a reply should be in this language.
+type CommandHandler func(ctx context.Context, userID UserID, languageCode string, args string) (Message, error)
+
+// HandleCommand registers a handler for a bot command.
+// The command is given without a leading slash. It must be called before ReceiveUpdates.
+func (b *bot) HandleCommand(command string, handler CommandHandler) {
+	b.commands[command] = handler
+}
+
+// HandleUpdate processes an update received from telegram.
+func (b *bot) HandleUpdate(ctx context.Context, update tgbotapi.Update) {
+	msg := update.Message
+	if msg == nil || msg.From == nil || !msg.IsCommand() || !msg.Chat.IsPrivate() {
+		return
+	}
+	handler, ok := b.commands[msg.Command()]
+	if !ok {
+		return
+	}
+	userID := UserID(msg.From.ID)
+	reply, err := handler(ctx, userID, msg.From.LanguageCode, msg.CommandArguments())
+	if err != nil {
+		b.logger.Error("failed to handle command",
+			zap.String("command", msg.Command()),
+			zap.Int64("user_id", int64(userID)),
+			zap.Error(err))
+		reply = Message{Text: i18n.NewPrinter(msg.From.LanguageCode).Sprintf(i18n.CommandFailed), ParseMode: ParseModeHTML}
+	}
+	if len(reply.Text) == 0 {
+		return
+	}
+	reply.UserID = userID
+	b.Send(reply)
+}
+
+// ReceiveUpdates polls telegram for updates and handles them until ctx is done.
+// It returns once the update being handled is handled, so its reply is queued before the bot is shut down.
+func (b *bot) ReceiveUpdates(ctx context.Context) {
+	config := tgbotapi.NewUpdate(0)
+	config.Timeout = 60
+	config.AllowedUpdates = []string{"message"}
+	updates := b.bot.GetUpdatesChan(config)
+	defer b.bot.StopReceivingUpdates()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case update, ok := <-updates:
+			if !ok {
+				return
+			}
+			b.HandleUpdate(ctx, update)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func commandUpdate(userID int64, text string, command string) tgbotapi.Update {
+	return tgbotapi.Update{
+		Message: &tgbotapi.Message{
+			From: &tgbotapi.User{ID: userID, LanguageCode: "ru"},
+			Chat: &tgbotapi.Chat{ID: userID, Type: "private"},
+			Text: text,
+			Entities: []tgbotapi.MessageEntity{
+				{Type: "bot_command", Offset: 0, Length: len(command) + 1},
+			},
+		},
+	}
+}
+
+func Test_bot_HandleUpdate(t *testing.T) {
+	tests := []struct {
+		name     string
+		update   tgbotapi.Update
+		wantMsgs []Message
+	}{
+		{
+			name:     "known command",
+			update:   commandUpdate(1, "/start hello", "start"),
+			wantMsgs: []Message{{UserID: 1, Text: "welcome ru hello"}},
+		},
+		{
+			name:     "command failed",
+			update:   commandUpdate(2, "/stop", "stop"),
+			wantMsgs: []Message{{UserID: 2, Text: "Что-то пошло не так, попробуйте позже.", ParseMode: ParseModeHTML}},
+		},
+		{
+			name:   "unknown command",
+			update: commandUpdate(1, "/help", "help"),
+		},
+		{
+			name: "not a command",
+			update: tgbotapi.Update{
+				Message: &tgbotapi.Message{
+					From: &tgbotapi.User{ID: 1},
+					Chat: &tgbotapi.Chat{ID: 1, Type: "private"},
+					Text: "start",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &bot{
+				logger:    zap.L(),
+				scheduler: newScheduler(0, 0),
+				commands: map[string]CommandHandler{
+					"start": func(ctx context.Context, userID UserID, languageCode string, args string) (Message, error) {
+						return Message{Text: "welcome " + languageCode + " " + args}, nil
+					},
+					"stop": func(ctx context.Context, userID UserID, languageCode string, args string) (Message, error) {
+						return Message{}, errors.New("db is down")
+					},
+				},
+			}
+			b.HandleUpdate(context.Background(), tt.update)
+
+			var msgs []Message
+			for {
+				j, _, ok := b.scheduler.pop(time.Now())
+				if !ok {
+					break
+				}
+				msgs = append(msgs, j.msg)
+			}
+			require.Equal(t, tt.wantMsgs, msgs)
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package telegram
+
+import (
+	"context"
+
+	"github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// SecretTokenHeader is a header telegram sets in every webhook request,
+// it contains a secret token configured with SetWebhook.
+const SecretTokenHeader = "X-Telegram-Bot-Api-Secret-Token"
+
+// UpdateHandler processes updates received from telegram.
+type UpdateHandler interface {
+	HandleUpdate(ctx context.Context, update tgbotapi.Update)
+}
+
+var _ UpdateHandler = (*bot)(nil)
+
+// SetWebhook asks telegram to send updates to the given URL instead of waiting for us to poll them.
+// Each webhook request will contain secretToken in the SecretTokenHeader header.
+func (b *bot) SetWebhook(url string, secretToken string) error {
+	// the Bot API library we use doesn't support secret tokens yet.
+	params := tgbotapi.Params{"url": url}
+	params.AddNonEmpty("secret_token", secretToken)
+	if err := params.AddInterface("allowed_updates", []string{"message"}); err != nil {
+		return err
+	}
+	_, err := b.bot.MakeRequest("setWebhook", params)
+	return err
+}
+
+// DeleteWebhook removes a webhook, so we can receive updates with ReceiveUpdates.
+func (b *bot) DeleteWebhook() error {
+	_, err := b.bot.Request(tgbotapi.DeleteWebhookConfig{})
+	return err
+}